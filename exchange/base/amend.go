@@ -0,0 +1,141 @@
+package base
+
+import (
+	"fmt"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+	"github.com/shopspring/decimal"
+)
+
+type OrdersFunc func(orders []*types.Order) ([]*types.OrderResult, error)
+
+type OrderFunc func(order *types.Order) (*types.Order, error)
+
+// CancelReplace 不支持原生改单的交易所先撤单再按新的价格/数量重新下单
+// orders需要带上完整的下单信息，Price/OrigQty为改单后的值，新订单沿用原ClientID
+// 撤单后查询原订单的成交量，只重新下OrigQty减去已成交的部分，没有剩余时不再下单
+func CancelReplace(orders []*types.Order, cancel OrdersFunc, fetch OrderFunc, create OrdersFunc) ([]*types.AmendResult, error) {
+	cancelResults, err := cancel(orders)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.AmendResult, len(orders))
+	replaces := make([]*types.Order, 0, len(orders))
+	replaceIdx := make([]int, 0, len(orders))
+	for i, order := range orders {
+		result[i] = &types.AmendResult{
+			Mode:         constant.AmendCancelReplace,
+			OrigOrderId:  order.OrderID,
+			OrigClientId: order.ClientID,
+		}
+		cancelResult := findResult(order, cancelResults)
+		if cancelResult == nil {
+			result[i].OrderResult = types.OrderResult{OrderId: order.OrderID, ClientId: order.ClientID, ErrCode: -1, ErrMsg: "missing cancel result"}
+			continue
+		}
+		if !cancelResult.IsSuccess {
+			result[i].OrderResult = *cancelResult
+			continue
+		}
+
+		// 撤单前可能已经部分成交，查询失败时无法确定剩余数量，不重新下单
+		canceled, err := fetch(order)
+		if err != nil {
+			result[i].OrderResult = types.OrderResult{OrderId: order.OrderID, ClientId: order.ClientID, ErrCode: -1, ErrMsg: fmt.Sprintf("canceled but fetch filled qty failed: %v", err)}
+			continue
+		}
+		remain, err := remainQty(order.OrigQty, canceled.ExecutedQty)
+		if err != nil {
+			result[i].OrderResult = types.OrderResult{OrderId: order.OrderID, ClientId: order.ClientID, ErrCode: -1, ErrMsg: fmt.Sprintf("canceled but bad qty: %v", err)}
+			continue
+		}
+		if !remain.IsPositive() {
+			result[i].OrderResult = types.OrderResult{OrderId: order.OrderID, ClientId: order.ClientID, ErrCode: -1, ErrMsg: fmt.Sprintf("canceled, filled %s, nothing left to replace", canceled.ExecutedQty)}
+			continue
+		}
+
+		replace := *order
+		replace.OrderID = ""
+		replace.OrigQty = remain.String()
+		replaces = append(replaces, &replace)
+		replaceIdx = append(replaceIdx, i)
+	}
+	if len(replaces) == 0 {
+		return result, nil
+	}
+
+	// 原订单已撤销，重新下单失败时只能通过结果告知调用方
+	createResults, err := create(replaces)
+	for j, replace := range replaces {
+		i := replaceIdx[j]
+		if err != nil {
+			result[i].OrderResult = types.OrderResult{ClientId: replace.ClientID, ErrCode: -1, ErrMsg: fmt.Sprintf("canceled but replace failed: %v", err)}
+			continue
+		}
+		var createResult *types.OrderResult
+		if len(createResults) == len(replaces) {
+			createResult = createResults[j]
+		} else {
+			createResult = findResult(replace, createResults)
+		}
+		if createResult == nil {
+			result[i].OrderResult = types.OrderResult{ClientId: replace.ClientID, ErrCode: -1, ErrMsg: "canceled but missing replace result"}
+			continue
+		}
+		result[i].OrderResult = *createResult
+	}
+	return result, nil
+}
+
+// remainQty 改单后的数量减去原订单已成交的数量
+func remainQty(origQty, executedQty string) (decimal.Decimal, error) {
+	qty, err := decimal.NewFromString(origQty)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if executedQty == "" {
+		return qty, nil
+	}
+	executed, err := decimal.NewFromString(executedQty)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return qty.Sub(executed), nil
+}
+
+// NativeAmendResults 原生改单结果转换，订单ID不变
+func NativeAmendResults(orders []*types.Order, results []*types.OrderResult) []*types.AmendResult {
+	amendResults := make([]*types.AmendResult, 0, len(orders))
+	for _, order := range orders {
+		amendResult := &types.AmendResult{
+			Mode:         constant.AmendNative,
+			OrigOrderId:  order.OrderID,
+			OrigClientId: order.ClientID,
+		}
+		if info := findResult(order, results); info != nil {
+			amendResult.OrderResult = *info
+		} else {
+			amendResult.OrderResult = types.OrderResult{OrderId: order.OrderID, ClientId: order.ClientID, ErrCode: -1, ErrMsg: "missing amend result"}
+		}
+		amendResults = append(amendResults, amendResult)
+	}
+	return amendResults
+}
+
+// findResult 按订单ID或clientId匹配结果，批量接口返回的结果不一定与请求顺序一致
+func findResult(order *types.Order, results []*types.OrderResult) *types.OrderResult {
+	for _, item := range results {
+		if item == nil {
+			continue
+		}
+		if order.OrderID != "" && item.OrderId == order.OrderID {
+			return item
+		}
+		if order.ClientID != "" && item.ClientId == order.ClientID {
+			return item
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestCancelReplace(t *testing.T) {
+	orders := []*types.Order{
+		{Symbol: "BTC_USDT", OrderID: "1", ClientID: "a", Price: "100", OrigQty: "1"},
+		{Symbol: "BTC_USDT", OrderID: "2", ClientID: "b", Price: "101", OrigQty: "1"},
+	}
+	// 撤单结果顺序与请求不一致，第二个订单撤单失败
+	cancel := func(orders []*types.Order) ([]*types.OrderResult, error) {
+		return []*types.OrderResult{
+			{IsSuccess: false, OrderId: "2", ClientId: "b", ErrCode: -2011, ErrMsg: "Unknown order sent."},
+			{IsSuccess: true, OrderId: "1", ClientId: "a"},
+		}, nil
+	}
+	fetch := func(order *types.Order) (*types.Order, error) {
+		return &types.Order{OrderID: order.OrderID, ExecutedQty: "0"}, nil
+	}
+	var created []*types.Order
+	create := func(orders []*types.Order) ([]*types.OrderResult, error) {
+		created = orders
+		return []*types.OrderResult{{IsSuccess: true, OrderId: "3", ClientId: "a"}}, nil
+	}
+
+	result, err := CancelReplace(orders, cancel, fetch, create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || created[0].OrderID != "" || created[0].Price != "100" {
+		t.Fatalf("unexpected replace orders %+v", created)
+	}
+	if orders[0].OrderID != "1" {
+		t.Fatalf("origin order modified")
+	}
+	if !result[0].IsSuccess || result[0].OrderId != "3" || result[0].OrigOrderId != "1" || result[0].Mode != constant.AmendCancelReplace {
+		t.Fatalf("unexpected result %+v", result[0])
+	}
+	if result[1].IsSuccess || result[1].ErrCode != -2011 {
+		t.Fatalf("unexpected result %+v", result[1])
+	}
+}
+
+// 撤单前已部分成交的订单只重新下剩余数量，完全成交的不再下单
+func TestCancelReplaceFilled(t *testing.T) {
+	orders := []*types.Order{
+		{Symbol: "BTC_USDT", OrderID: "1", ClientID: "a", Price: "100", OrigQty: "0.3"},
+		{Symbol: "BTC_USDT", OrderID: "2", ClientID: "b", Price: "101", OrigQty: "1"},
+		{Symbol: "BTC_USDT", OrderID: "3", ClientID: "c", Price: "102", OrigQty: "1"},
+	}
+	cancel := func(orders []*types.Order) ([]*types.OrderResult, error) {
+		result := make([]*types.OrderResult, 0, len(orders))
+		for _, order := range orders {
+			result = append(result, &types.OrderResult{IsSuccess: true, OrderId: order.OrderID, ClientId: order.ClientID})
+		}
+		return result, nil
+	}
+	executed := map[string]string{"1": "0.1", "2": "1", "3": "1.5"}
+	fetch := func(order *types.Order) (*types.Order, error) {
+		return &types.Order{OrderID: order.OrderID, ExecutedQty: executed[order.OrderID]}, nil
+	}
+	var created []*types.Order
+	create := func(orders []*types.Order) ([]*types.OrderResult, error) {
+		created = orders
+		return []*types.OrderResult{{IsSuccess: true, OrderId: "4", ClientId: "a"}}, nil
+	}
+
+	result, err := CancelReplace(orders, cancel, fetch, create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || created[0].ClientID != "a" || created[0].OrigQty != "0.2" {
+		t.Fatalf("unexpected replace orders %+v", created)
+	}
+	if !result[0].IsSuccess || result[0].OrderId != "4" {
+		t.Fatalf("unexpected result %+v", result[0])
+	}
+	for _, r := range result[1:] {
+		if r.IsSuccess || r.OrigOrderId == "" {
+			t.Fatalf("filled order should not be replaced %+v", r)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package base
+
+import (
+	"fmt"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// Endpoints 交易所实例使用的rest和ws地址
+type Endpoints struct {
+	RestUrl  string
+	PubWsUrl string
+	PriWsUrl string
+	Head     map[string]string // rest请求附加的请求头，如okx模拟盘的x-simulated-trading
+}
+
+// ResolveEndpoints 按params.Environment从presets中选择地址，params中的自定义地址优先
+// 没有该环境的预设且没有自定义全部地址时返回错误，避免误连到实盘
+func ResolveEndpoints(params *types.ExchangeParameters, presets map[constant.Environment]Endpoints) (Endpoints, error) {
+	endpoints, ok := presets[params.Environment]
+	if !ok && (params.RestUrl == "" || params.PubWsUrl == "" || params.PriWsUrl == "") {
+		return endpoints, fmt.Errorf("environment %s not supported", params.Environment.Name())
+	}
+	if params.RestUrl != "" {
+		endpoints.RestUrl = params.RestUrl
+	}
+	if params.PubWsUrl != "" {
+		endpoints.PubWsUrl = params.PubWsUrl
+	}
+	if params.PriWsUrl != "" {
+		endpoints.PriWsUrl = params.PriWsUrl
+	}
+	return endpoints, nil
+}
+
+// MustResolveEndpoints 环境配置错误时直接panic，与NewExchange对未知交易所的处理一致
+func MustResolveEndpoints(params *types.ExchangeParameters, presets map[constant.Environment]Endpoints) Endpoints {
+	endpoints, err := ResolveEndpoints(params, presets)
+	if err != nil {
+		panic(err)
+	}
+	return endpoints
+}
@@ -0,0 +1,38 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestResolveEndpoints(t *testing.T) {
+	presets := map[constant.Environment]Endpoints{
+		constant.EnvProduction: {RestUrl: "https://api", PubWsUrl: "wss://pub", PriWsUrl: "wss://pri"},
+		constant.EnvDemo:       {RestUrl: "https://api", PubWsUrl: "wss://demo/pub", PriWsUrl: "wss://demo/pri", Head: map[string]string{"x-simulated-trading": "1"}},
+	}
+
+	endpoints, err := ResolveEndpoints(&types.ExchangeParameters{}, presets)
+	if err != nil || endpoints.PubWsUrl != "wss://pub" {
+		t.Fatalf("unexpected production endpoints %+v %v", endpoints, err)
+	}
+
+	// 自定义地址覆盖预设，请求头保留
+	params := &types.ExchangeParameters{Environment: constant.EnvDemo, RestUrl: "https://proxy"}
+	endpoints, err = ResolveEndpoints(params, presets)
+	if err != nil || endpoints.RestUrl != "https://proxy" || endpoints.PriWsUrl != "wss://demo/pri" || endpoints.Head["x-simulated-trading"] != "1" {
+		t.Fatalf("unexpected demo endpoints %+v %v", endpoints, err)
+	}
+
+	// 没有预设的环境必须自定义全部地址
+	params = &types.ExchangeParameters{Environment: constant.EnvTestnet, RestUrl: "https://testnet"}
+	if _, err = ResolveEndpoints(params, presets); err == nil {
+		t.Fatalf("expect error for testnet without presets")
+	}
+	params.PubWsUrl, params.PriWsUrl = "wss://testnet/pub", "wss://testnet/pri"
+	endpoints, err = ResolveEndpoints(params, presets)
+	if err != nil || endpoints.RestUrl != "https://testnet" || endpoints.PubWsUrl != "wss://testnet/pub" {
+		t.Fatalf("unexpected custom endpoints %+v %v", endpoints, err)
+	}
+}
@@ -0,0 +1,99 @@
+package base
+
+import (
+	"strconv"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// UserTradePageFunc 按binance userTrades/myTrades的请求参数拉取一页成交，结果按时间正序
+type UserTradePageFunc func(query map[string]interface{}) ([]*types.Fill, error)
+
+// PageUserTrades binance系成交明细翻页，symbol为交易所格式，Limit语义见UserTradeParam
+// StartTime不为0或指定OrderID时按成交ID向后翻页，否则从EndTime按时间向前翻页
+func PageUserTrades(symbol string, param UserTradeParam, pageSize int, fetch UserTradePageFunc) ([]*types.Fill, error) {
+	if param.StartTime == 0 && param.OrderID == "" {
+		return pageUserTradesBackward(symbol, param, pageSize, fetch)
+	}
+	result := make([]*types.Fill, 0)
+	fromId := ""
+	for {
+		fills, err := fetch(UserTradesQuery(symbol, param, fromId, pageSize))
+		if err != nil {
+			return nil, err
+		}
+		for _, fill := range fills {
+			// 按fromId翻页时不再带时间条件，需要自己截断
+			if param.EndTime > 0 && fill.Ts > param.EndTime {
+				return result, nil
+			}
+			result = append(result, fill)
+			if param.Limit > 0 && int64(len(result)) >= param.Limit {
+				return result, nil
+			}
+		}
+		if len(fills) < pageSize {
+			return result, nil
+		}
+		lastId, err := strconv.ParseInt(fills[len(fills)-1].TradeID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		fromId = strconv.FormatInt(lastId+1, 10)
+	}
+}
+
+// pageUserTradesBackward 每次以上一页最早一条的时间为endTime向前查询，同一毫秒的成交按ID去重
+func pageUserTradesBackward(symbol string, param UserTradeParam, pageSize int, fetch UserTradePageFunc) ([]*types.Fill, error) {
+	result := make([]*types.Fill, 0)
+	page := param
+	oldestId := int64(-1)
+	for {
+		fills, err := fetch(UserTradesQuery(symbol, page, "", pageSize))
+		if err != nil {
+			return nil, err
+		}
+		older := make([]*types.Fill, 0, len(fills))
+		for _, fill := range fills {
+			id, err := strconv.ParseInt(fill.TradeID, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if oldestId < 0 || id < oldestId {
+				older = append(older, fill)
+			}
+		}
+		if len(older) == 0 {
+			break
+		}
+		result = append(older, result...)
+		if param.Limit > 0 && int64(len(result)) >= param.Limit {
+			return result[int64(len(result))-param.Limit:], nil
+		}
+		oldestId, _ = strconv.ParseInt(older[0].TradeID, 10, 64)
+		page.EndTime = older[0].Ts
+	}
+	return result, nil
+}
+
+// UserTradesQuery binance系userTrades/myTrades的请求参数，fromId不能和时间条件同时使用
+func UserTradesQuery(symbol string, param UserTradeParam, fromId string, limit int) map[string]interface{} {
+	query := map[string]interface{}{
+		"symbol": symbol,
+		"limit":  limit,
+	}
+	if param.OrderID != "" {
+		query["orderId"] = param.OrderID
+	}
+	if fromId != "" {
+		query["fromId"] = fromId
+		return query
+	}
+	if param.StartTime > 0 {
+		query["startTime"] = param.StartTime
+	}
+	if param.EndTime > 0 {
+		query["endTime"] = param.EndTime
+	}
+	return query
+}
@@ -0,0 +1,97 @@
+package base
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// fakeUserTrades 模拟binance userTrades，成交ID 1-7，时间为ID*1000
+// 带fromId或startTime时返回之后最早的一页，否则返回endTime之前最近的一页
+func fakeUserTrades(calls *int) UserTradePageFunc {
+	return func(query map[string]interface{}) ([]*types.Fill, error) {
+		*calls++
+		limit := query["limit"].(int)
+		start, end := int64(1), int64(7)
+		if v, ok := query["fromId"]; ok {
+			start, _ = strconv.ParseInt(v.(string), 10, 64)
+		}
+		if v, ok := query["startTime"]; ok {
+			start = (v.(int64) + 999) / 1000
+		}
+		if v, ok := query["endTime"]; ok && v.(int64)/1000 < end {
+			end = v.(int64) / 1000
+		}
+		_, fromId := query["fromId"]
+		_, startTime := query["startTime"]
+		if !fromId && !startTime && end-start+1 > int64(limit) {
+			start = end - int64(limit) + 1
+		}
+		fills := make([]*types.Fill, 0, limit)
+		for id := start; id <= end && len(fills) < limit; id++ {
+			fills = append(fills, &types.Fill{TradeID: strconv.FormatInt(id, 10), Ts: id * 1000})
+		}
+		return fills, nil
+	}
+}
+
+func checkFills(t *testing.T, fills []*types.Fill, first, last int64) {
+	t.Helper()
+	if int64(len(fills)) != last-first+1 {
+		t.Fatalf("expect fills %d-%d, got %d fills", first, last, len(fills))
+	}
+	for i, fill := range fills {
+		if fill.TradeID != strconv.FormatInt(first+int64(i), 10) {
+			t.Fatalf("expect fills %d-%d, got %s at %d", first, last, fill.TradeID, i)
+		}
+	}
+}
+
+func TestPageUserTradesForward(t *testing.T) {
+	calls := 0
+	fills, err := PageUserTrades("BTCUSDT", UserTradeParam{StartTime: 1000}, 3, fakeUserTrades(&calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFills(t, fills, 1, 7)
+
+	fills, _ = PageUserTrades("BTCUSDT", UserTradeParam{StartTime: 1000, EndTime: 5000}, 3, fakeUserTrades(&calls))
+	checkFills(t, fills, 1, 5)
+
+	// 指定StartTime时返回最早的Limit条
+	fills, _ = PageUserTrades("BTCUSDT", UserTradeParam{StartTime: 2000, Limit: 4}, 3, fakeUserTrades(&calls))
+	checkFills(t, fills, 2, 5)
+}
+
+func TestPageUserTradesBackward(t *testing.T) {
+	// 不指定StartTime时返回最近的Limit条，需要跨页
+	calls := 0
+	fills, err := PageUserTrades("BTCUSDT", UserTradeParam{Limit: 5}, 3, fakeUserTrades(&calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFills(t, fills, 3, 7)
+
+	fills, _ = PageUserTrades("BTCUSDT", UserTradeParam{EndTime: 5000, Limit: 4}, 3, fakeUserTrades(&calls))
+	checkFills(t, fills, 2, 5)
+
+	calls = 0
+	fills, _ = PageUserTrades("BTCUSDT", UserTradeParam{}, 3, fakeUserTrades(&calls))
+	checkFills(t, fills, 1, 7)
+	if calls != 4 {
+		t.Fatalf("expect 4 calls, got %d", calls)
+	}
+}
+
+func TestUserTradesQuery(t *testing.T) {
+	param := UserTradeParam{OrderID: "1", StartTime: 1000, EndTime: 2000}
+	query := UserTradesQuery("BTCUSDT", param, "", 1000)
+	if query["startTime"] != int64(1000) || query["endTime"] != int64(2000) || query["orderId"] != "1" {
+		t.Fatalf("unexpected query %v", query)
+	}
+	query = UserTradesQuery("BTCUSDT", param, "5", 1000)
+	if query["fromId"] != "5" || query["startTime"] != nil || query["endTime"] != nil {
+		t.Fatalf("fromId query should not carry time %v", query)
+	}
+}
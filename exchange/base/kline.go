@@ -0,0 +1,74 @@
+package base
+
+import (
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// KlinePageFunc 按时间正序返回一页K线，startTime为0时返回endTime之前最近的limit根
+type KlinePageFunc func(startTime, endTime, limit int64) ([]types.Kline, error)
+
+// ForwardPageEnd 交易所只返回区间内最近的limit根时，向后翻页把区间截断为limit个周期，保证返回从startTime开始的limit根
+// period为0(未知周期)或startTime为0时不截断
+func ForwardPageEnd(startTime, endTime, limit, period int64) int64 {
+	if startTime == 0 || period <= 0 {
+		return endTime
+	}
+	if end := startTime + period*limit - 1; end < endTime {
+		return end
+	}
+	return endTime
+}
+
+// PageKlines 指定StartTime时向后翻页拉取区间内的K线，否则从EndTime向前翻页直到取满Limit根
+// Limit为0且没有StartTime时只取一页，结果按时间正序返回
+func PageKlines(param KlineParam, pageLimit int64, fetch KlinePageFunc) ([]types.Kline, error) {
+	endTime := param.EndTime
+	if endTime == 0 {
+		endTime = utils.Millisec(time.Now())
+	}
+
+	if param.StartTime == 0 {
+		limit := param.Limit
+		if limit <= 0 {
+			limit = pageLimit
+		}
+		result := make([]types.Kline, 0, limit)
+		for int64(len(result)) < limit {
+			size := limit - int64(len(result))
+			if size > pageLimit {
+				size = pageLimit
+			}
+			klines, err := fetch(0, endTime, size)
+			if err != nil {
+				return nil, err
+			}
+			result = append(klines, result...)
+			if int64(len(klines)) < size {
+				break
+			}
+			endTime = klines[0].Ts - 1
+		}
+		return result, nil
+	}
+
+	result := make([]types.Kline, 0)
+	startTime := param.StartTime
+	for startTime <= endTime {
+		klines, err := fetch(startTime, endTime, pageLimit)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, klines...)
+		if param.Limit > 0 && int64(len(result)) >= param.Limit {
+			return result[:param.Limit], nil
+		}
+		if int64(len(klines)) < pageLimit {
+			break
+		}
+		startTime = klines[len(klines)-1].Ts + 1
+	}
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// fakeKlinePage 模拟交易所的1分钟K线接口，数据范围为[first, last]
+func fakeKlinePage(first, last int64, calls *int) KlinePageFunc {
+	return func(startTime, endTime, limit int64) ([]types.Kline, error) {
+		*calls++
+		if endTime > last {
+			endTime = last
+		}
+		endTime -= endTime % 60000
+		from := startTime
+		if from == 0 {
+			from = endTime - (limit-1)*60000
+		}
+		if from < first {
+			from = first
+		}
+		if rem := from % 60000; rem != 0 {
+			from += 60000 - rem
+		}
+		klines := make([]types.Kline, 0, limit)
+		for ts := from; ts <= endTime && int64(len(klines)) < limit; ts += 60000 {
+			klines = append(klines, types.Kline{Ts: ts})
+		}
+		return klines, nil
+	}
+}
+
+func checkContinuous(t *testing.T, klines []types.Kline) {
+	for i := 1; i < len(klines); i++ {
+		if klines[i].Ts != klines[i-1].Ts+60000 {
+			t.Fatalf("klines not continuous at %d", i)
+		}
+	}
+}
+
+func TestPageKlinesBackward(t *testing.T) {
+	last := int64(10000 * 60000)
+	calls := 0
+	param := KlineParam{EndTime: last, Limit: 2500}
+	klines, err := PageKlines(param, 1000, fakeKlinePage(0, last, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 2500 || calls != 3 {
+		t.Fatalf("got %d klines in %d calls, want 2500 in 3", len(klines), calls)
+	}
+	if klines[len(klines)-1].Ts != last || klines[0].Ts != last-2499*60000 {
+		t.Fatalf("unexpected range %d-%d", klines[0].Ts, klines[len(klines)-1].Ts)
+	}
+	checkContinuous(t, klines)
+
+	// 数据不足Limit时停止翻页
+	calls = 0
+	klines, err = PageKlines(param, 1000, fakeKlinePage(last-1199*60000, last, &calls))
+	if err != nil || len(klines) != 1200 || calls != 2 {
+		t.Fatalf("got %d klines in %d calls, err %v", len(klines), calls, err)
+	}
+	checkContinuous(t, klines)
+}
+
+func TestPageKlinesForward(t *testing.T) {
+	last := int64(10000 * 60000)
+	calls := 0
+	param := KlineParam{StartTime: last - 2099*60000, EndTime: last}
+	klines, err := PageKlines(param, 1000, fakeKlinePage(0, last, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 2100 || calls != 3 || klines[0].Ts != param.StartTime || klines[2099].Ts != last {
+		t.Fatalf("got %d klines in %d calls", len(klines), calls)
+	}
+	checkContinuous(t, klines)
+
+	param.Limit = 1500
+	klines, err = PageKlines(param, 1000, fakeKlinePage(0, last, &calls))
+	if err != nil || len(klines) != 1500 || klines[0].Ts != param.StartTime {
+		t.Fatalf("got %d klines, err %v", len(klines), err)
+	}
+}
+
+func TestForwardPageEnd(t *testing.T) {
+	// bybit/pionex只返回区间内最近的limit根，用ForwardPageEnd截断后结果应与按startTime翻页一致
+	last := int64(10000 * 60000)
+	calls := 0
+	page := fakeKlinePage(0, last, &calls)
+	newest := func(startTime, endTime, limit int64) ([]types.Kline, error) {
+		klines, err := page(0, ForwardPageEnd(startTime, endTime, limit, 60000), limit)
+		filtered := make([]types.Kline, 0, len(klines))
+		for _, k := range klines {
+			if k.Ts >= startTime {
+				filtered = append(filtered, k)
+			}
+		}
+		return filtered, err
+	}
+	param := KlineParam{StartTime: last - 2099*60000, EndTime: last, Limit: 1500}
+	klines, err := PageKlines(param, 1000, newest)
+	if err != nil || len(klines) != 1500 || klines[0].Ts != param.StartTime {
+		t.Fatalf("got %d klines, err %v", len(klines), err)
+	}
+	checkContinuous(t, klines)
+
+	if end := ForwardPageEnd(0, last, 1000, 60000); end != last {
+		t.Fatalf("backward page should not be truncated, got %d", end)
+	}
+	if end := ForwardPageEnd(60000, last, 1000, 0); end != last {
+		t.Fatalf("unknown period should not be truncated, got %d", end)
+	}
+}
@@ -0,0 +1,39 @@
+package base
+
+import (
+	"sync"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// LiquidationFilter symbols为空时订阅的是全市场强平频道，推送按订阅的交易对过滤
+type LiquidationFilter struct {
+	mutex    sync.RWMutex
+	all      bool
+	symbols  map[string]bool
+	callback func(*types.Liquidation)
+}
+
+func NewLiquidationFilter() *LiquidationFilter {
+	return &LiquidationFilter{symbols: make(map[string]bool)}
+}
+
+func (f *LiquidationFilter) Add(symbols []string, callback func(*types.Liquidation)) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.callback = callback
+	if len(symbols) == 0 {
+		f.all = true
+	}
+	addSymbols(f.symbols, symbols)
+}
+
+func (f *LiquidationFilter) Dispatch(liquidation *types.Liquidation) {
+	f.mutex.RLock()
+	callback := f.callback
+	subscribed := f.all || f.symbols[liquidation.Symbol]
+	f.mutex.RUnlock()
+	if subscribed && callback != nil {
+		callback(liquidation)
+	}
+}
@@ -0,0 +1,26 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestLiquidationFilter(t *testing.T) {
+	// 只推送订阅的交易对
+	var got []*types.Liquidation
+	filter := NewLiquidationFilter()
+	filter.Add([]string{"BTC_USDT"}, func(v *types.Liquidation) { got = append(got, v) })
+	filter.Dispatch(&types.Liquidation{Symbol: "ETH_USDT"})
+	filter.Dispatch(&types.Liquidation{Symbol: "BTC_USDT"})
+	if len(got) != 1 || got[0].Symbol != "BTC_USDT" {
+		t.Fatalf("unexpected filtered liquidations %+v", got)
+	}
+
+	// symbols为空时推送全部交易对
+	filter.Add(nil, func(v *types.Liquidation) { got = append(got, v) })
+	filter.Dispatch(&types.Liquidation{Symbol: "ETH_USDT"})
+	if len(got) != 2 {
+		t.Fatalf("all symbols subscribed, got %d", len(got))
+	}
+}
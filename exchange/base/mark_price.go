@@ -0,0 +1,124 @@
+package base
+
+import (
+	"sync"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// MarkPriceUpdate 同时包含标记价格、指数价格和资金费率的推送，按订阅的类型拆分为FundingRate/MarkPrice/IndexPrice
+type MarkPriceUpdate struct {
+	Symbol          string
+	IndexSymbol     string
+	Exchange        constant.ExchangeType
+	MarkPrice       float64
+	IndexPrice      float64
+	FundingRate     float64
+	NextFundingTime int64
+	ExchangeTs      int64 // 微秒
+	Ts              int64 // 本地收到的时间，毫秒
+}
+
+// ToFundingRate 与FetchFundingRate一致，FundingTime为下一次结算时间，推送中没有结算间隔，NextFundingTime为0，Ts为毫秒
+func (u *MarkPriceUpdate) ToFundingRate() *types.FundingRate {
+	return &types.FundingRate{
+		Symbol:      u.Symbol,
+		Exchange:    u.Exchange,
+		Method:      "current_period",
+		FundingRate: u.FundingRate,
+		FundingTime: u.NextFundingTime,
+		Ts:          u.ExchangeTs / 1000,
+	}
+}
+
+func (u *MarkPriceUpdate) ToMarkPrice() *types.MarkPrice {
+	return &types.MarkPrice{
+		Symbol:     u.Symbol,
+		Exchange:   u.Exchange,
+		MarkPrice:  u.MarkPrice,
+		IndexPrice: u.IndexPrice,
+		ExchangeTs: u.ExchangeTs,
+		Ts:         u.Ts,
+	}
+}
+
+func (u *MarkPriceUpdate) ToIndexPrice() *types.IndexPrice {
+	return &types.IndexPrice{
+		Symbol:     u.IndexSymbol,
+		Exchange:   u.Exchange,
+		IndexPrice: u.IndexPrice,
+		ExchangeTs: u.ExchangeTs,
+		Ts:         u.Ts,
+	}
+}
+
+// MarkPriceDispatcher FundingRate/MarkPrice/IndexPrice共用一个频道，按订阅的交易对分发
+type MarkPriceDispatcher struct {
+	mutex              sync.RWMutex
+	fundingRateSymbols map[string]bool
+	markPriceSymbols   map[string]bool
+	indexPriceSymbols  map[string]bool
+
+	onFundingRate func(*types.FundingRate)
+	onMarkPrice   func(*types.MarkPrice)
+	onIndexPrice  func(*types.IndexPrice)
+}
+
+func NewMarkPriceDispatcher() *MarkPriceDispatcher {
+	return &MarkPriceDispatcher{
+		fundingRateSymbols: make(map[string]bool),
+		markPriceSymbols:   make(map[string]bool),
+		indexPriceSymbols:  make(map[string]bool),
+	}
+}
+
+func (d *MarkPriceDispatcher) AddFundingRates(symbols []string, callback func(*types.FundingRate)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.onFundingRate = callback
+	addSymbols(d.fundingRateSymbols, symbols)
+}
+
+func (d *MarkPriceDispatcher) AddMarkPrices(symbols []string, callback func(*types.MarkPrice)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.onMarkPrice = callback
+	addSymbols(d.markPriceSymbols, symbols)
+}
+
+// AddIndexPrices symbols为合约交易对，推送的IndexPrice.Symbol为指数名称
+func (d *MarkPriceDispatcher) AddIndexPrices(symbols []string, callback func(*types.IndexPrice)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.onIndexPrice = callback
+	addSymbols(d.indexPriceSymbols, symbols)
+}
+
+// Dispatch 同一个推送可能同时分发给多个回调
+func (d *MarkPriceDispatcher) Dispatch(update *MarkPriceUpdate) {
+	d.mutex.RLock()
+	onFundingRate := d.onFundingRate
+	onMarkPrice := d.onMarkPrice
+	onIndexPrice := d.onIndexPrice
+	isFundingRate := d.fundingRateSymbols[update.Symbol]
+	isMarkPrice := d.markPriceSymbols[update.Symbol]
+	isIndexPrice := d.indexPriceSymbols[update.Symbol]
+	d.mutex.RUnlock()
+
+	if isFundingRate && onFundingRate != nil {
+		onFundingRate(update.ToFundingRate())
+	}
+	if isMarkPrice && onMarkPrice != nil {
+		onMarkPrice(update.ToMarkPrice())
+	}
+	if isIndexPrice && onIndexPrice != nil {
+		onIndexPrice(update.ToIndexPrice())
+	}
+}
+
+func addSymbols(subscribed map[string]bool, symbols []string) {
+	for _, symbol := range symbols {
+		subscribed[symbol] = true
+	}
+}
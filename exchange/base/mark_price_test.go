@@ -0,0 +1,44 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestMarkPriceDispatcher(t *testing.T) {
+	update := &MarkPriceUpdate{
+		Symbol:          "BTC_USDT",
+		IndexSymbol:     "BTC_USDT",
+		MarkPrice:       11794.15,
+		IndexPrice:      11784.25641265,
+		FundingRate:     0.00038167,
+		NextFundingTime: 1562306400000,
+		ExchangeTs:      1562305380000000,
+	}
+
+	// 只分发给订阅了该交易对的回调
+	var rate *types.FundingRate
+	var mark *types.MarkPrice
+	var index *types.IndexPrice
+	dispatcher := NewMarkPriceDispatcher()
+	dispatcher.AddFundingRates([]string{"BTC_USDT"}, func(v *types.FundingRate) { rate = v })
+	dispatcher.AddMarkPrices([]string{"BTC_USDT"}, func(v *types.MarkPrice) { mark = v })
+	dispatcher.AddIndexPrices([]string{"ETH_USDT"}, func(v *types.IndexPrice) { index = v })
+	dispatcher.Dispatch(update)
+	if rate == nil || rate.FundingRate != 0.00038167 || rate.FundingTime != 1562306400000 || rate.Ts != 1562305380000 {
+		t.Fatalf("unexpected funding rate %+v", rate)
+	}
+	if mark == nil || mark.MarkPrice != 11794.15 || mark.IndexPrice != 11784.25641265 || mark.ExchangeTs != 1562305380000000 {
+		t.Fatalf("unexpected mark price %+v", mark)
+	}
+	if index != nil {
+		t.Fatalf("index price not subscribed %+v", index)
+	}
+
+	dispatcher.AddIndexPrices([]string{"BTC_USDT"}, func(v *types.IndexPrice) { index = v })
+	dispatcher.Dispatch(update)
+	if index == nil || index.Symbol != "BTC_USDT" || index.IndexPrice != 11784.25641265 {
+		t.Fatalf("unexpected index price %+v", index)
+	}
+}
@@ -11,3 +11,39 @@ type TransferParam struct {
 	Amount       float64 `json:"amount"`
 	TransferType string  `json:"transfer_type"`
 }
+
+// KlineParam 历史K线查询参数，StartTime/EndTime为毫秒时间戳，为0时不限制
+type KlineParam struct {
+	Symbol    string `json:"symbol"`
+	Interval  string `json:"interval"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Limit     int64  `json:"limit"` // 返回的最大条数，为0时返回区间内全部数据；StartTime为0时返回EndTime之前最近的Limit条
+}
+
+// UserTradeParam 成交明细查询参数，StartTime/EndTime为毫秒时间戳，为0时不限制
+type UserTradeParam struct {
+	Symbol    string `json:"symbol"`
+	OrderID   string `json:"order_id"` // 只查询该订单的成交
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Limit     int64  `json:"limit"` // 返回的最大条数，为0时返回区间内全部数据；StartTime不为0时返回之后最早的Limit条，为0时返回EndTime之前最近的Limit条
+}
+
+// WithdrawParam 链上提币参数，Chain使用交易所的链名称
+type WithdrawParam struct {
+	Coin     string  `json:"coin"`
+	Chain    string  `json:"chain"`
+	Address  string  `json:"address"`
+	Tag      string  `json:"tag"`
+	Amount   float64 `json:"amount"`
+	ClientId string  `json:"client_id"`
+}
+
+// WalletRecordParam 充提记录查询参数，Coin为空时查询全部币种
+type WalletRecordParam struct {
+	Coin      string `json:"coin"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Limit     int64  `json:"limit"` // 单次查询最多返回的条数，为0时使用交易所默认值
+}
@@ -0,0 +1,27 @@
+package base
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Requester 带签名的rest请求，各交易所RestClient的HttpRequest
+type Requester interface {
+	HttpRequest(method string, uri string, param map[string]interface{}) ([]byte, *http.Response, error)
+}
+
+// GetJson 发送GET请求并把应答解析到response，非200时返回带应答内容的错误
+func GetJson(client Requester, uri string, param map[string]interface{}, response interface{}) error {
+	body, res, err := client.HttpRequest(http.MethodGet, uri, param)
+	if err != nil {
+		return fmt.Errorf("get %s err:%v", uri, err)
+	}
+	if res.StatusCode != 200 {
+		return fmt.Errorf("get %s err: %v %s", uri, res.StatusCode, body)
+	}
+	if err = json.Unmarshal(body, response); err != nil {
+		return fmt.Errorf("get %s parser err:%v", uri, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package base
+
+import (
+	"github.com/cybernonce/gotrader/pkg/httpx"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/types"
+	"github.com/gorilla/websocket"
+)
+
+// Transport 交易所实例独立的http客户端和ws拨号器，代理、超时和出口IP来自ExchangeParameters
+type Transport struct {
+	HttpClient httpx.Client
+	WsDialer   *websocket.Dialer
+}
+
+func NewTransport(params *types.ExchangeParameters) (*Transport, error) {
+	config := httpx.GetDefaultConfig()
+	config.ProxyURL = params.ProxyURL
+	config.LocalAddr = params.LocalAddr
+	if params.RequestTimeout > 0 {
+		config.RequestTimeout = params.RequestTimeout
+	}
+	if params.DialTimeout > 0 {
+		config.DialTimeout = params.DialTimeout
+	}
+	httpClient, err := httpx.NewClientWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dialer, err := ws.NewDialer(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Transport{HttpClient: httpClient, WsDialer: dialer}, nil
+}
+
+// MustNewTransport 代理或出口IP配置错误时直接panic，与MustResolveEndpoints一致
+func MustNewTransport(params *types.ExchangeParameters) *Transport {
+	transport, err := NewTransport(params)
+	if err != nil {
+		panic(err)
+	}
+	return transport
+}
@@ -0,0 +1,40 @@
+package base
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestNewTransport(t *testing.T) {
+	params := &types.ExchangeParameters{
+		ProxyURL:       "socks5://127.0.0.1:1080",
+		LocalAddr:      "127.0.0.1",
+		RequestTimeout: 2 * time.Second,
+	}
+	transport, err := NewTransport(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.HttpClient.Timeout != 2*time.Second {
+		t.Fatalf("unexpected timeout %v", transport.HttpClient.Timeout)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://www.okx.com", nil)
+	proxy, err := transport.HttpClient.Transport.(*http.Transport).Proxy(req)
+	if err != nil || proxy.String() != params.ProxyURL {
+		t.Fatalf("unexpected proxy %v %v", proxy, err)
+	}
+	proxy, err = transport.WsDialer.Proxy(req)
+	if err != nil || proxy.String() != params.ProxyURL {
+		t.Fatalf("unexpected ws proxy %v %v", proxy, err)
+	}
+
+	if _, err = NewTransport(&types.ExchangeParameters{ProxyURL: "ftp://127.0.0.1:21"}); err == nil {
+		t.Fatalf("expect error for unsupported proxy")
+	}
+	if _, err = NewTransport(&types.ExchangeParameters{LocalAddr: "bad ip"}); err == nil {
+		t.Fatalf("expect error for bad local addr")
+	}
+}
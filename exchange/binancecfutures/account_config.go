@@ -0,0 +1,95 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+const (
+	noNeedChangeMarginType   = -4046 // 保证金模式未变化
+	noNeedChangePositionSide = -4059 // 持仓模式未变化
+)
+
+var (
+	MarginMode2Binance = map[constant.MarginMode]string{
+		constant.MarginCross:    "CROSSED",
+		constant.MarginIsolated: "ISOLATED",
+	}
+)
+
+type PositionSideResponse struct {
+	DualSidePosition bool `json:"dualSidePosition"`
+}
+
+// SetLeverage 调整交易对的开仓杠杆
+func (client *RestClient) SetLeverage(symbol string, leverage int64) error {
+	param := map[string]interface{}{
+		"symbol":   Symbol2Binance(symbol),
+		"leverage": leverage,
+	}
+	return client.postAccountConfig(SetLeverage, param, 0)
+}
+
+// SetMarginType 调整交易对的保证金模式，有持仓或挂单时不能修改
+func (client *RestClient) SetMarginType(symbol string, marginMode constant.MarginMode) error {
+	marginType, ok := MarginMode2Binance[marginMode]
+	if !ok {
+		return fmt.Errorf("binance not support margin mode %s", marginMode.Name())
+	}
+	param := map[string]interface{}{
+		"symbol":     Symbol2Binance(symbol),
+		"marginType": marginType,
+	}
+	return client.postAccountConfig(MarginTypeRest, param, noNeedChangeMarginType)
+}
+
+// SetPositionMode 更改所有交易对的持仓模式，有持仓或挂单时不能修改
+func (client *RestClient) SetPositionMode(mode constant.PositionMode) error {
+	param := map[string]interface{}{
+		"dualSidePosition": fmt.Sprintf("%v", mode == constant.HedgeMode),
+	}
+	return client.postAccountConfig(PositionSideRest, param, noNeedChangePositionSide)
+}
+
+func (client *RestClient) FetchPositionMode() (constant.PositionMode, error) {
+	uri := PositionSideRest
+	body, res, err := client.HttpRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		log.Errorf("binance get /dapi/v1/positionSide/dual err:%v", err)
+		return constant.OneWayMode, err
+	}
+	if res.StatusCode != 200 {
+		return constant.OneWayMode, fmt.Errorf("binance get /dapi/v1/positionSide/dual err: %v %s", res.StatusCode, body)
+	}
+
+	var response PositionSideResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /dapi/v1/positionSide/dual parser err:%v", err)
+		return constant.OneWayMode, err
+	}
+	if response.DualSidePosition {
+		return constant.HedgeMode, nil
+	}
+	return constant.OneWayMode, nil
+}
+
+// postAccountConfig ignoreCode为配置未变化时返回的错误码，视为成功
+func (client *RestClient) postAccountConfig(uri string, param map[string]interface{}, ignoreCode int32) error {
+	body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+	if err != nil {
+		log.Errorf("binance post %s err: %v", uri, err)
+		return err
+	}
+	if res.StatusCode == 200 {
+		return nil
+	}
+
+	var errRsp BinanceErrRsp
+	if err = json.Unmarshal(body, &errRsp); err == nil && ignoreCode != 0 && errRsp.Code == ignoreCode {
+		return nil
+	}
+	return fmt.Errorf("binance post %s err: %v %s", uri, res.StatusCode, body)
+}
@@ -0,0 +1,118 @@
+package binancecfutures
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// contractSizes 接口的数量单位为张，对外统一为币的数量，按SymbolInfo.FaceVal换算
+// 下单和改单按委托价格换算，订单和成交按成交价格换算，持仓按开仓均价换算
+type contractSizes struct {
+	mutex    sync.RWMutex
+	faceVals map[string]float64
+	fetch    func() ([]*types.SymbolInfo, error)
+}
+
+func newContractSizes(fetch func() ([]*types.SymbolInfo, error)) *contractSizes {
+	return &contractSizes{
+		faceVals: make(map[string]float64),
+		fetch:    fetch,
+	}
+}
+
+// faceVal 未知的交易对重新拉取交易对信息，新上线的交割合约也能换算
+func (c *contractSizes) faceVal(symbol string) (float64, error) {
+	c.mutex.RLock()
+	faceVal, ok := c.faceVals[symbol]
+	c.mutex.RUnlock()
+	if ok {
+		return faceVal, nil
+	}
+
+	symbols, err := c.fetch()
+	if err != nil {
+		return 0, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, info := range symbols {
+		c.faceVals[info.Symbol] = info.FaceVal
+	}
+	if faceVal, ok = c.faceVals[symbol]; !ok {
+		return 0, fmt.Errorf("unknown symbol %s", symbol)
+	}
+	return faceVal, nil
+}
+
+// toContracts 返回数量换算为张的订单副本，市价单需要在Price中给出参考价格，不足一张时返回错误
+func (c *contractSizes) toContracts(order *types.Order) (*types.Order, error) {
+	faceVal, err := c.faceVal(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	qty, _ := utils.ParseFloat(order.OrigQty)
+	price, _ := utils.ParseFloat(order.Price)
+	if price <= 0 {
+		return nil, fmt.Errorf("price is required to convert %s %s to contracts", order.Symbol, order.OrigQty)
+	}
+	contracts := Qty2Contracts(qty, price, faceVal)
+	if contracts <= 0 {
+		return nil, fmt.Errorf("%s %s is less than one contract", order.Symbol, order.OrigQty)
+	}
+	converted := *order
+	converted.OrigQty = strconv.FormatInt(contracts, 10)
+	return &converted, nil
+}
+
+// orderToQty 委托数量按委托价格换算，市价单按成交均价，成交数量按成交均价换算，ExecutedAmt为成交的面值(USD)
+func (c *contractSizes) orderToQty(order *types.Order) error {
+	faceVal, err := c.faceVal(order.Symbol)
+	if err != nil {
+		return err
+	}
+	price, _ := utils.ParseFloat(order.Price)
+	avgPrice, _ := utils.ParseFloat(order.AvgPrice)
+	if price <= 0 {
+		price = avgPrice
+	}
+	origQty, _ := utils.ParseFloat(order.OrigQty)
+	executedQty, _ := utils.ParseFloat(order.ExecutedQty)
+	order.OrigQty = formatQty(Contracts2Qty(toInt(origQty), price, faceVal))
+	order.ExecutedQty = formatQty(Contracts2Qty(toInt(executedQty), avgPrice, faceVal))
+	if executedQty > 0 {
+		order.ExecutedAmt = formatQty(executedQty * faceVal)
+	}
+	return nil
+}
+
+func (c *contractSizes) fillToQty(fill *types.Fill) error {
+	faceVal, err := c.faceVal(fill.Symbol)
+	if err != nil {
+		return err
+	}
+	fill.Qty = Contracts2Qty(toInt(fill.Qty), fill.Price, faceVal)
+	return nil
+}
+
+func (c *contractSizes) positionToQty(position *types.Position) error {
+	faceVal, err := c.faceVal(position.Symbol)
+	if err != nil {
+		return err
+	}
+	position.Position = Contracts2Qty(toInt(position.Position), position.AvgCost, faceVal)
+	return nil
+}
+
+// toInt 接口返回的张数为字符串或浮点数，取整避免精度误差
+func toInt(contracts float64) int64 {
+	return int64(math.Round(contracts))
+}
+
+func formatQty(qty float64) string {
+	return strconv.FormatFloat(qty, 'f', -1, 64)
+}
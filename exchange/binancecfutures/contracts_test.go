@@ -0,0 +1,60 @@
+package binancecfutures
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestContractSizesRoundTrip(t *testing.T) {
+	calls := 0
+	sizes := newContractSizes(func() ([]*types.SymbolInfo, error) {
+		calls++
+		return []*types.SymbolInfo{{Symbol: "BTC_USD_SWAP", FaceVal: 100}, {Symbol: "ETH_USD_SWAP", FaceVal: 10}}, nil
+	})
+
+	// 下单时0.3个币按50000换算为150张
+	order := &types.Order{Symbol: "BTC_USD_SWAP", Type: constant.Limit, Price: "50000", OrigQty: "0.3"}
+	converted, err := sizes.toContracts(order)
+	if err != nil || converted.OrigQty != "150" || order.OrigQty != "0.3" {
+		t.Fatalf("toContracts = %+v, err %v", converted, err)
+	}
+
+	// 查询订单返回的张数换算回币
+	filled := &types.Order{Symbol: "BTC_USD_SWAP", Price: "50000", AvgPrice: "50000", OrigQty: "150", ExecutedQty: "150"}
+	if err = sizes.orderToQty(filled); err != nil {
+		t.Fatal(err)
+	}
+	if filled.OrigQty != "0.3" || filled.ExecutedQty != "0.3" || filled.ExecutedAmt != "15000" {
+		t.Fatalf("orderToQty = %+v", filled)
+	}
+
+	fill := &types.Fill{Symbol: "BTC_USD_SWAP", Price: 50000, Qty: 150}
+	position := &types.Position{Symbol: "ETH_USD_SWAP", AvgCost: 2500, Position: 25}
+	if err = sizes.fillToQty(fill); err != nil || fill.Qty != 0.3 {
+		t.Fatalf("fillToQty = %v, err %v", fill.Qty, err)
+	}
+	if err = sizes.positionToQty(position); err != nil || position.Position != 0.1 {
+		t.Fatalf("positionToQty = %v, err %v", position.Position, err)
+	}
+	if calls != 1 {
+		t.Fatalf("face values should be cached, fetched %d times", calls)
+	}
+}
+
+func TestContractSizesErrors(t *testing.T) {
+	sizes := newContractSizes(func() ([]*types.SymbolInfo, error) {
+		return []*types.SymbolInfo{{Symbol: "BTC_USD_SWAP", FaceVal: 100}}, nil
+	})
+	orders := []*types.Order{
+		{Symbol: "BTC_USD_SWAP", Type: constant.Market, OrigQty: "0.3"},                  // 市价单没有参考价格
+		{Symbol: "BTC_USD_SWAP", Type: constant.Limit, Price: "50000", OrigQty: "0.001"}, // 不足一张
+		{Symbol: "DOGE_USD_SWAP", Type: constant.Limit, Price: "0.1", OrigQty: "1000"},   // 未知交易对
+	}
+	for _, order := range orders {
+		if _, err := sizes.toContracts(order); err == nil {
+			t.Fatalf("expect error for %+v", order)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type OrderResponse struct {
+	ClientOrderId string `json:"clientOrderId"`
+	CumQty        string `json:"cumQty"`
+	CumBase       string `json:"cumBase"` // 成交金额，以币计价
+	ExecutedQty   string `json:"executedQty"`
+	OrderId       int64  `json:"orderId"`
+	AvgPrice      string `json:"avgPrice"`
+	OrigQty       string `json:"origQty"`
+	Price         string `json:"price"`
+	ReduceOnly    bool   `json:"reduceOnly"`
+	Side          string `json:"side"`
+	PositionSide  string `json:"positionSide"`
+	Status        string `json:"status"`
+	Symbol        string `json:"symbol"`
+	TimeInForce   string `json:"timeInForce"`
+	Type          string `json:"type"`
+	UpdateTime    int64  `json:"updateTime"`
+}
+
+// BatchOrderResponse 批量接口中的单个结果，失败时只有code和msg
+type BatchOrderResponse struct {
+	OrderResponse
+	Code int32  `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func (client *RestClient) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for start := 0; start < len(orders); start += MaxBatchCreateOrders {
+		end := start + MaxBatchCreateOrders
+		if end > len(orders) {
+			end = len(orders)
+		}
+		result = append(result, client.createBatchOrders(orders[start:end])...)
+	}
+	return result, nil
+}
+
+// createBatchOrders 数量无法换算为张的订单直接返回错误结果，其余订单一起提交，结果与请求顺序一致
+func (client *RestClient) createBatchOrders(orders []*types.Order) []*types.OrderResult {
+	result := make([]*types.OrderResult, len(orders))
+	valid := make([]*types.Order, 0, len(orders))
+	indexes := make([]int, 0, len(orders))
+	for index, order := range orders {
+		converted, err := client.contracts.toContracts(order)
+		if err != nil {
+			result[index] = orderErrTransform(order, -1, err.Error())
+			continue
+		}
+		valid = append(valid, converted)
+		indexes = append(indexes, index)
+	}
+	if len(valid) == 0 {
+		return result
+	}
+	for i, item := range client.postBatchOrders(valid) {
+		result[indexes[i]] = item
+	}
+	return result
+}
+
+func (client *RestClient) postBatchOrders(orders []*types.Order) []*types.OrderResult {
+	batch := make([]map[string]interface{}, 0, len(orders))
+	for _, order := range orders {
+		batch = append(batch, formRequest(order))
+	}
+	batchOrders, _ := json.Marshal(batch)
+	param := map[string]interface{}{
+		"batchOrders": string(batchOrders),
+	}
+
+	uri := CreatMoreOrderRest
+	body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+	if err != nil {
+		log.Errorf("binance post /dapi/v1/batchOrders err: %v", err)
+		return ordersErrTransform(orders, -1, err.Error())
+	}
+	if res.StatusCode != 200 {
+		log.Errorf("binance post /dapi/v1/batchOrders err: %v %s", res.StatusCode, body)
+		return ordersFailTransform(orders, body)
+	}
+
+	var response []BatchOrderResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance post /dapi/v1/batchOrders parsing JSON err: %v", err)
+		return ordersErrTransform(orders, -1, err.Error())
+	}
+	return batchOrderTransform(orders, response)
+}
+
+// formRequest 批量下单要求参数值均为字符串，order的数量已换算为张
+func formRequest(order *types.Order) map[string]interface{} {
+	side := Side2Binance[order.Side.Name()]
+	result := map[string]interface{}{
+		"symbol":   Symbol2Binance(order.Symbol),
+		"side":     side[0],
+		"quantity": order.OrigQty,
+	}
+	// 双向持仓模式通过positionSide区分开平，不能再传reduceOnly
+	if side[1] != "" {
+		result["positionSide"] = side[1]
+	} else if order.ReduceOnly {
+		result["reduceOnly"] = "true"
+	}
+
+	if order.Type == constant.Market {
+		result["type"] = "MARKET"
+	} else {
+		result["type"] = "LIMIT"
+		result["timeInForce"] = TimeInForce2Binance[order.Type.Name()]
+		result["price"] = order.Price
+	}
+	if order.ClientID != "" {
+		result["newClientOrderId"] = order.ClientID
+	}
+	return result
+}
+
+func batchOrderTransform(orders []*types.Order, response []BatchOrderResponse) []*types.OrderResult {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for index, order := range orders {
+		if index >= len(response) {
+			result = append(result, orderErrTransform(order, -1, "missing batch order result"))
+			continue
+		}
+		item := response[index]
+		if item.Code != 0 {
+			result = append(result, orderErrTransform(order, item.Code, item.Msg))
+			continue
+		}
+		result = append(result, orderTransform(&item.OrderResponse))
+	}
+	return result
+}
+
+func orderTransform(info *OrderResponse) *types.OrderResult {
+	var result types.OrderResult
+	if info.Status != "REJECTED" && info.Status != "EXPIRED" {
+		result.IsSuccess = true
+	}
+	result.OrderId = strconv.FormatInt(info.OrderId, 10)
+	result.ClientId = info.ClientOrderId
+	return &result
+}
+
+// ordersFailTransform 整批请求失败时，解析币安返回的错误码和错误信息
+func ordersFailTransform(orders []*types.Order, body []byte) []*types.OrderResult {
+	var errRsp BinanceErrRsp
+	if err := json.Unmarshal(body, &errRsp); err != nil {
+		return ordersErrTransform(orders, -1, string(body))
+	}
+	return ordersErrTransform(orders, errRsp.Code, errRsp.Msg)
+}
+
+func ordersErrTransform(orders []*types.Order, code int32, msg string) []*types.OrderResult {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, orderErrTransform(order, code, msg))
+	}
+	return result
+}
+
+func orderErrTransform(order *types.Order, code int32, msg string) *types.OrderResult {
+	return &types.OrderResult{
+		IsSuccess: false,
+		OrderId:   order.OrderID,
+		ClientId:  order.ClientID,
+		ErrCode:   code,
+		ErrMsg:    msg,
+	}
+}
@@ -0,0 +1,364 @@
+package binancecfutures
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type BinanceCFuturesExchange struct {
+	exchangeType constant.ExchangeType
+
+	restClient  *RestClient
+	pubWsClient *ws.WsClient
+	priWsClient *ws.WsClient
+
+	// 私有连接当前使用的listenKey，每次连接前重新申请
+	listenKey atomic.Value
+
+	// FundingRate/MarkPrice/IndexPrice共用markPrice频道，按订阅的交易对分发
+	markPrices *base.MarkPriceDispatcher
+
+	// symbols为空时订阅全市场强平频道，按订阅的交易对过滤
+	liquidations *base.LiquidationFilter
+
+	// callbacks
+	onBooktickerCallback func(*types.BookTicker)
+	onTradeCallback      func([]*types.Trade)
+	onOrderBookCallback  func(*types.OrderBook)
+	onOrderCallback      func([]*types.Order)
+	onBalanceCallback    func(*types.Assets)
+	onPositionCallback   func([]*types.Position)
+}
+
+// NewBinanceCFutures 币本位永续和交割合约，symbol格式为 BTC_USD_SWAP/BTC_USD_240628
+// 接口的数量单位为张，每张面值(USD)见 SymbolInfo.FaceVal，订单、成交和持仓对外统一换算为币的数量
+// 下单和改单按委托价格换算为张，市价单需要在Price中给出参考价格
+func NewBinanceCFutures(params *types.ExchangeParameters) *BinanceCFuturesExchange {
+	apiKey := params.AccessKey
+	secretKey := params.SecretKey
+	passPhrase := params.Passphrase
+	endpoints := base.MustResolveEndpoints(params, Environments)
+	transport := base.MustNewTransport(params)
+
+	// new client
+	client := NewRestClient(apiKey, secretKey, passPhrase, constant.BinanceCFutures)
+	client.SetEndpoints(endpoints)
+	client.SetHttpClient(transport.HttpClient)
+	exchange := &BinanceCFuturesExchange{
+		exchangeType: constant.BinanceCFutures,
+		restClient:   client,
+		markPrices:   base.NewMarkPriceDispatcher(),
+		liquidations: base.NewLiquidationFilter(),
+	}
+	// pubWsClient
+	pubWsClient := NewBinanceCFuturesPubWsClient(endpoints.PubWsUrl, exchange.OnPubWsHandle)
+	pubWsClient.SetDialer(transport.WsDialer)
+	if err := pubWsClient.Dial(ws.Connect); err != nil {
+		log.Errorf("pubWsClient.Dial err %s", err)
+	} else {
+		exchange.pubWsClient = pubWsClient
+		log.Infof("pubWsClient.Dial success")
+	}
+
+	// priWsClient
+	if len(apiKey) > 0 {
+		priWsClient := NewBinanceCFuturesPriWsClient(endpoints.PriWsUrl, apiKey, secretKey, passPhrase, exchange.newListenKey, exchange.OnPriWsHandle)
+		priWsClient.SetDialer(transport.WsDialer)
+		if err := priWsClient.Dial(ws.Connect); err != nil {
+			log.Errorf("priWsClient.Dial err %s", err)
+		} else {
+			exchange.priWsClient = priWsClient
+			log.Infof("priWsClient.Dial success")
+			exchange.KeepUserStream()
+		}
+	}
+	return exchange
+}
+
+func (binance *BinanceCFuturesExchange) GetName() (name string) {
+	return binance.exchangeType.Name()
+}
+
+func (binance *BinanceCFuturesExchange) GetType() (typ constant.ExchangeType) {
+	return binance.exchangeType
+}
+
+func (binance *BinanceCFuturesExchange) GetListenKey() (string, error) {
+	return binance.restClient.GetListenKey()
+}
+
+// newListenKey 申请新的listenKey并记录下来用于定时延长
+func (binance *BinanceCFuturesExchange) newListenKey() (string, error) {
+	listenKey, err := binance.GetListenKey()
+	if err != nil {
+		return "", err
+	}
+	binance.listenKey.Store(listenKey)
+	return listenKey, nil
+}
+
+func (binance *BinanceCFuturesExchange) currentListenKey() string {
+	listenKey, _ := binance.listenKey.Load().(string)
+	return listenKey
+}
+
+// KeepUserStream 定时延长listenKey，延长失败时重连私有连接，重连会申请新的listenKey
+func (binance *BinanceCFuturesExchange) KeepUserStream() {
+	go binance.restClient.KeepUserStream(binance.currentListenKey, binance.priWsClient.Reconnect)
+}
+
+// StopUserStream 停止定时延长listenKey
+func (binance *BinanceCFuturesExchange) StopUserStream() {
+	binance.restClient.StopUserStream()
+}
+
+func (binance *BinanceCFuturesExchange) FetchSymbols() ([]*types.SymbolInfo, error) {
+	return binance.restClient.FetchSymbols()
+}
+
+func (binance *BinanceCFuturesExchange) FetchBalance() (*types.Assets, error) {
+	return binance.restClient.FetchBalance()
+}
+
+func (binance *BinanceCFuturesExchange) FetchAssetBalance() (*types.Assets, error) {
+	return nil, fmt.Errorf("FetchAssetBalance not imp")
+}
+
+func (binance *BinanceCFuturesExchange) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	return binance.restClient.FetchOpenOrders(symbol)
+}
+
+func (binance *BinanceCFuturesExchange) FetchOrder(order *types.Order) (*types.Order, error) {
+	return binance.restClient.FetchOrder(order)
+}
+
+func (binance *BinanceCFuturesExchange) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return binance.restClient.FetchUserTrades(param)
+}
+
+func (binance *BinanceCFuturesExchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return binance.restClient.CreateBatchOrders(orders)
+}
+
+func (binance *BinanceCFuturesExchange) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return binance.restClient.CancelBatchOrders(orders)
+}
+
+func (binance *BinanceCFuturesExchange) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	return binance.restClient.AmendBatchOrders(orders)
+}
+
+func (binance *BinanceCFuturesExchange) FetchTickers() ([]*types.Ticker, error) {
+	return binance.restClient.FetchTickers()
+}
+
+func (binance *BinanceCFuturesExchange) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
+	return binance.restClient.FetchKline(symbol, interval, limit)
+}
+
+func (binance *BinanceCFuturesExchange) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return binance.restClient.FetchHistoryKline(param)
+}
+
+func (binance *BinanceCFuturesExchange) FetchFundingRate(symbol string) (*types.FundingRate, error) {
+	return binance.restClient.FetchFundingRate(symbol)
+}
+
+func (binance *BinanceCFuturesExchange) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
+	return binance.restClient.FetchFundingRateHistory(symbol, limit)
+}
+
+func (binance *BinanceCFuturesExchange) FetchPositons() ([]*types.Position, error) {
+	return binance.restClient.FetchPositons()
+}
+
+// SetLeverage 先设置保证金模式再调整杠杆
+func (binance *BinanceCFuturesExchange) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	if err := binance.restClient.SetMarginType(symbol, marginMode); err != nil {
+		return err
+	}
+	return binance.restClient.SetLeverage(symbol, leverage)
+}
+
+func (binance *BinanceCFuturesExchange) SetPositionMode(mode constant.PositionMode) error {
+	return binance.restClient.SetPositionMode(mode)
+}
+
+func (binance *BinanceCFuturesExchange) FetchPositionMode() (constant.PositionMode, error) {
+	return binance.restClient.FetchPositionMode()
+}
+
+func (binance *BinanceCFuturesExchange) PrivateTransfer(transfer base.TransferParam) (string, error) {
+	return "", fmt.Errorf("PrivateTransfer not imp")
+}
+
+func (binance *BinanceCFuturesExchange) Subscribe(params map[string]interface{}) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	if err := binance.pubWsClient.Write(params); err != nil {
+		return fmt.Errorf("Subscribe err: %s", err)
+	}
+	return nil
+}
+
+func (binance *BinanceCFuturesExchange) SubscribeBookTicker(symbols []string, callback func(*types.BookTicker)) (err error) {
+	binance.onBooktickerCallback = callback
+	return binance.subscribeStreams(symbols, "bookTicker")
+}
+
+// SubscribeTrades 订阅归集成交
+func (binance *BinanceCFuturesExchange) SubscribeTrades(symbols []string, callback func([]*types.Trade)) (err error) {
+	binance.onTradeCallback = callback
+	return binance.subscribeStreams(symbols, "aggTrade")
+}
+
+// SubscribeOrderBook 订阅20档有限深度，每次推送完整的20档
+func (binance *BinanceCFuturesExchange) SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) (err error) {
+	binance.onOrderBookCallback = callback
+	return binance.subscribeStreams(symbols, DepthStream)
+}
+
+// subscribeStreams 按交易对逐个订阅 <symbol>@<stream>，断线重连后自动重新订阅
+func (binance *BinanceCFuturesExchange) subscribeStreams(symbols []string, stream string) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	for _, symbol := range symbols {
+		binance.pubWsClient.Subscribe(symbol, stream)
+	}
+	return nil
+}
+
+func (binance *BinanceCFuturesExchange) SubscribeFundingRates(symbols []string, callback func(*types.FundingRate)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	binance.markPrices.AddFundingRates(symbols, callback)
+	return binance.subscribeStreams(symbols, MarkPriceStream)
+}
+
+func (binance *BinanceCFuturesExchange) SubscribeMarkPrices(symbols []string, callback func(*types.MarkPrice)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	binance.markPrices.AddMarkPrices(symbols, callback)
+	return binance.subscribeStreams(symbols, MarkPriceStream)
+}
+
+func (binance *BinanceCFuturesExchange) SubscribeIndexPrices(symbols []string, callback func(*types.IndexPrice)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	binance.markPrices.AddIndexPrices(symbols, callback)
+	return binance.subscribeStreams(symbols, MarkPriceStream)
+}
+
+func (binance *BinanceCFuturesExchange) SubscribeOpenInterest(symbols []string, callback func(*types.OpenInterest)) error {
+	return fmt.Errorf("not impl")
+}
+
+// SubscribeLiquidations symbols为空时订阅 !forceOrder@arr
+func (binance *BinanceCFuturesExchange) SubscribeLiquidations(symbols []string, callback func(*types.Liquidation)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	binance.liquidations.Add(symbols, callback)
+
+	if len(symbols) > 0 {
+		return binance.subscribeStreams(symbols, ForceOrderStream)
+	}
+	binance.pubWsClient.Subscribe("", AllForceOrderStream)
+	return nil
+}
+
+// SubscribeOrders 用户数据流推送全部交易对的订单，symbols不做过滤
+func (binance *BinanceCFuturesExchange) SubscribeOrders(symbols []string, callback func(orders []*types.Order)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onOrderCallback = callback
+	return nil
+}
+
+func (binance *BinanceCFuturesExchange) SubscribeBalance(callback func(*types.Assets)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onBalanceCallback = callback
+	return nil
+}
+
+func (binance *BinanceCFuturesExchange) SubscribePositions(callback func([]*types.Position)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onPositionCallback = callback
+	return nil
+}
+
+func (binance *BinanceCFuturesExchange) OnPubWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case *types.BookTicker:
+		// callback
+		if binance.onBooktickerCallback != nil {
+			binance.onBooktickerCallback(v)
+		} else {
+			log.Errorf("OnBookTicker Callback not set")
+		}
+	case *types.OrderBook:
+		if binance.onOrderBookCallback != nil {
+			binance.onOrderBookCallback(v)
+		} else {
+			log.Errorf("onOrderBook Callback not set")
+		}
+	case []*types.Trade:
+		if binance.onTradeCallback != nil {
+			binance.onTradeCallback(v)
+		} else {
+			log.Errorf("onTrade Callback not set")
+		}
+	case *base.MarkPriceUpdate:
+		binance.markPrices.Dispatch(v)
+	case *types.Liquidation:
+		binance.liquidations.Dispatch(v)
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
+
+func (binance *BinanceCFuturesExchange) OnPriWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case []*types.Order:
+		for _, order := range v {
+			if err := binance.restClient.contracts.orderToQty(order); err != nil {
+				log.Errorf("binance cfutures order %s to qty err:%v", order.OrderID, err)
+			}
+		}
+		if binance.onOrderCallback != nil {
+			binance.onOrderCallback(v)
+		} else {
+			log.Errorf("onOrder Callback not set")
+		}
+	case *types.Assets:
+		if binance.onBalanceCallback != nil {
+			binance.onBalanceCallback(v)
+		}
+	case []*types.Position:
+		for _, position := range v {
+			if err := binance.restClient.contracts.positionToQty(position); err != nil {
+				log.Errorf("binance cfutures position %s to qty err:%v", position.Symbol, err)
+			}
+		}
+		if binance.onPositionCallback != nil {
+			binance.onPositionCallback(v)
+		}
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
@@ -0,0 +1,92 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// AccountResponse 币本位按保证金币种分别计算，没有账户维度的汇总
+type AccountResponse struct {
+	Assets []AccountAsset `json:"assets"`
+}
+
+type AccountAsset struct {
+	Asset            string `json:"asset"`
+	WalletBalance    string `json:"walletBalance"`
+	UnrealizedProfit string `json:"unrealizedProfit"`
+	MarginBalance    string `json:"marginBalance"`
+	MaintMargin      string `json:"maintMargin"`
+	InitialMargin    string `json:"initialMargin"`
+	AvailableBalance string `json:"availableBalance"`
+	UpdateTime       int64  `json:"updateTime"`
+}
+
+func (client *RestClient) FetchBalance() (*types.Assets, error) {
+	uri := BalanceRest
+	body, res, err := client.HttpRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		log.Errorf("binance FetchBalance 网络错误:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /dapi/v1/account err: %v %s", res.StatusCode, body)
+	}
+
+	response := new(AccountResponse)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("binance get /dapi/v1/account parser err:%v", err)
+		return nil, err
+	}
+
+	result, err := balanceTransform(response)
+	if err != nil {
+		err := fmt.Errorf("binance get /dapi/v1/account transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// balanceTransform UniMMR取各币种 保证金余额/维持保证金 的最小值，即风险最高的币种
+func balanceTransform(response *AccountResponse) (*types.Assets, error) {
+	assets := make(map[string]types.Asset)
+	var uniMMR float64
+	for _, item := range response.Assets {
+		total, err := utils.ParseFloat(item.MarginBalance)
+		if err != nil {
+			log.Errorf("cfutures binance fetchBalance marginBalance参数转换失败:%v", err)
+			return nil, err
+		}
+		free, err := utils.ParseFloat(item.AvailableBalance)
+		if err != nil {
+			log.Errorf("cfutures binance fetchBalance availableBalance参数转换失败:%v", err)
+			return nil, err
+		}
+		if total == 0 {
+			continue
+		}
+
+		coin := strings.ToUpper(item.Asset)
+		assets[coin] = types.Asset{
+			Coin:   coin,
+			Free:   free,
+			Frozen: total - free,
+			Total:  total,
+		}
+
+		maintMargin, _ := utils.ParseFloat(item.MaintMargin)
+		if maintMargin > 0 {
+			if mmr := total / maintMargin; uniMMR == 0 || mmr < uniMMR {
+				uniMMR = mmr
+			}
+		}
+	}
+	return &types.Assets{
+		Assets: assets,
+		UniMMR: uniMMR,
+	}, nil
+}
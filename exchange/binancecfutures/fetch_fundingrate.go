@@ -0,0 +1,122 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type PremiumIndex struct {
+	Symbol          string `json:"symbol"`
+	Pair            string `json:"pair"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	LastFundingRate string `json:"lastFundingRate"` // 交割合约为空
+	InterestRate    string `json:"interestRate"`
+	NextFundingTime int64  `json:"nextFundingTime"`
+	Time            int64  `json:"time"`
+}
+
+type FundingRateHistory struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+}
+
+// FetchFundingRate 只支持永续合约，与U本位一致：FundingTime为本期结算时间，NextFundingTime为下一期结算时间
+func (client *RestClient) FetchFundingRate(symbol string) (*types.FundingRate, error) {
+	if !IsPerpSymbol(symbol) {
+		return nil, fmt.Errorf("binance cfutures %s is not perpetual", symbol)
+	}
+	queryDict := map[string]interface{}{}
+	queryDict["symbol"] = Symbol2Binance(symbol)
+	url := client.restUrl + fmt.Sprintf("%s?%s", MarkPriceRest, utils.UrlEncodeParams(queryDict))
+
+	body, res, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("binance get /dapi/v1/premiumIndex err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /dapi/v1/premiumIndex err: %v %s", res.StatusCode, body)
+	}
+
+	// 币本位即使指定symbol也返回数组
+	var response []*PremiumIndex
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /dapi/v1/premiumIndex parser err:%v", err)
+		return nil, err
+	}
+	if len(response) == 0 {
+		return nil, fmt.Errorf("binance get /dapi/v1/premiumIndex empty")
+	}
+
+	result, err := fundingRateTransform(response[0], DefaultFundingIntervalHours*time.Hour)
+	if err != nil {
+		err := fmt.Errorf("binance get /dapi/v1/premiumIndex transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func fundingRateTransform(response *PremiumIndex, interval time.Duration) (*types.FundingRate, error) {
+	rate, err := utils.ParseFloat(response.LastFundingRate)
+	if err != nil {
+		return nil, err
+	}
+	return &types.FundingRate{
+		Symbol:          Binance2Symbol(response.Symbol),
+		Method:          "current_period",
+		FundingRate:     rate,
+		FundingTime:     response.NextFundingTime,
+		NextFundingTime: response.NextFundingTime + interval.Milliseconds(),
+	}, nil
+}
+
+func (client *RestClient) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
+	queryDict := map[string]interface{}{}
+	queryDict["symbol"] = Symbol2Binance(symbol)
+	queryDict["limit"] = limit
+	url := client.restUrl + fmt.Sprintf("%s?%s", FundingRateRest, utils.UrlEncodeParams(queryDict))
+
+	body, res, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("binance get /dapi/v1/fundingRate err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /dapi/v1/fundingRate err: %v %s", res.StatusCode, body)
+	}
+
+	var response []FundingRateHistory
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /dapi/v1/fundingRate parser err:%v", err)
+		return nil, err
+	}
+	if len(response) == 0 {
+		return nil, fmt.Errorf("binance get /dapi/v1/fundingRate empty")
+	}
+	return fundingRateHistoryTransform(response), nil
+}
+
+// fundingRateHistoryTransform 币安按时间正序返回，与okx保持一致转为倒序
+func fundingRateHistoryTransform(response []FundingRateHistory) []*types.FundingRate {
+	result := make([]*types.FundingRate, 0, len(response))
+	for i := len(response) - 1; i >= 0; i-- {
+		fr := response[i]
+		rate, err := utils.ParseFloat(fr.FundingRate)
+		if err != nil {
+			log.Errorf("parser FundingRateHistory FundingRate err %s", err)
+			continue
+		}
+		result = append(result, &types.FundingRate{
+			Symbol:      Binance2Symbol(fr.Symbol),
+			FundingRate: rate,
+			FundingTime: fr.FundingTime,
+		})
+	}
+	return result
+}
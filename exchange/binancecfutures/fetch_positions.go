@@ -0,0 +1,83 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// PositionInfo positionAmt单位为张，notionalValue为折算的币数量
+type PositionInfo struct {
+	Symbol           string  `json:"symbol"`
+	PositionAmt      float64 `json:"positionAmt,string"`
+	EntryPrice       float64 `json:"entryPrice,string"`
+	MarkPrice        float64 `json:"markPrice,string"`
+	UnRealizedProfit float64 `json:"unRealizedProfit,string"`
+	LiquidationPrice float64 `json:"liquidationPrice,string"`
+	Leverage         float64 `json:"leverage,string"`
+	MarginType       string  `json:"marginType"`
+	IsolatedMargin   float64 `json:"isolatedMargin,string"`
+	PositionSide     string  `json:"positionSide"`
+	NotionalValue    float64 `json:"notionalValue,string"`
+	UpdateTime       int64   `json:"updateTime"`
+}
+
+func (client *RestClient) FetchPositons() ([]*types.Position, error) {
+	uri := PositionRest
+	body, res, err := client.HttpRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		log.Errorf("binance FetchPositons 网络错误:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		log.Errorf("binance FetchPositons %v %s", res.StatusCode, body)
+		return nil, fmt.Errorf("%s", body)
+	}
+
+	var positions []*PositionInfo
+	if err = json.Unmarshal(body, &positions); err != nil {
+		log.Errorf("binance get /dapi/v1/positionRisk parser err:%v", err)
+		return nil, err
+	}
+	result := positionTransform(positions)
+	for _, position := range result {
+		if err = client.contracts.positionToQty(position); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func positionTransform(response []*PositionInfo) []*types.Position {
+	result := make([]*types.Position, 0, len(response))
+	for _, item := range response {
+		if item.PositionAmt == 0 {
+			continue
+		}
+		result = append(result, item.ToPosition())
+	}
+	return result
+}
+
+// ToPosition 保证金以币计价，全仓模式下按名义价值/杠杆估算
+func (item *PositionInfo) ToPosition() *types.Position {
+	margin := item.IsolatedMargin
+	if item.MarginType != "isolated" && item.Leverage > 0 {
+		margin = math.Abs(item.NotionalValue) / item.Leverage
+	}
+	return &types.Position{
+		MarginMode:    Binance2MarginMode[item.MarginType],
+		Symbol:        Binance2Symbol(item.Symbol),
+		LiquidationPx: item.LiquidationPrice,
+		Side:          getPositionSide(item.PositionSide, item.PositionAmt),
+		Position:      math.Abs(item.PositionAmt),
+		AvgCost:       item.EntryPrice,
+		UnrealisedPnl: item.UnRealizedProfit,
+		Last:          item.MarkPrice,
+		Margin:        margin,
+		Leverage:      item.Leverage,
+	}
+}
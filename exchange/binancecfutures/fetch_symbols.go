@@ -0,0 +1,93 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type SymbolsResponse struct {
+	Symbols []Symbol `json:"symbols"`
+}
+
+type Symbol struct {
+	Symbol         string                   `json:"symbol"`
+	Pair           string                   `json:"pair"`
+	ContractType   string                   `json:"contractType"` // PERPETUAL/CURRENT_QUARTER/NEXT_QUARTER
+	DeliveryDate   int64                    `json:"deliveryDate"`
+	ContractStatus string                   `json:"contractStatus"`
+	ContractSize   float64                  `json:"contractSize"` // 每张合约的面值(USD)
+	BaseAsset      string                   `json:"baseAsset"`
+	QuoteAsset     string                   `json:"quoteAsset"`
+	Filters        []map[string]interface{} `json:"filters"`
+}
+
+func (client *RestClient) FetchSymbols() ([]*types.SymbolInfo, error) {
+	url := client.restUrl + SymbolsRest
+
+	body, _, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("binance get /dapi/v1/exchangeInfo err:%v", err)
+		return nil, err
+	}
+
+	response := new(SymbolsResponse)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("binance get /dapi/v1/exchangeInfo parser err:%v", err)
+		return nil, err
+	}
+
+	result, err := symbolTransform(response)
+	if err != nil {
+		err := fmt.Errorf("binance get /dapi/v1/exchangeInfo transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// symbolTransform 下单数量单位为张，FaceVal为每张合约的面值(USD)
+func symbolTransform(response *SymbolsResponse) ([]*types.SymbolInfo, error) {
+	result := make([]*types.SymbolInfo, 0, len(response.Symbols))
+	for _, item := range response.Symbols {
+		if item.ContractStatus != "TRADING" {
+			continue
+		}
+
+		info := &types.SymbolInfo{
+			Base:       strings.ToLower(item.BaseAsset),
+			Quote:      strings.ToLower(item.QuoteAsset),
+			Symbol:     Binance2Symbol(item.Symbol),
+			FaceVal:    item.ContractSize,
+			Multiplier: 1,
+		}
+		info.Name = strings.ToLower(info.Symbol)
+		if item.ContractType != "PERPETUAL" {
+			info.Expiry = item.DeliveryDate
+		}
+		for _, ft := range item.Filters {
+			switch ft["filterType"] {
+			case "PRICE_FILTER":
+				info.PxPrec = utils.DecimalMath(ft["tickSize"].(string))
+			case "LOT_SIZE":
+				info.QtyPrec = utils.DecimalMath(ft["stepSize"].(string))
+				min, err := utils.ParseFloat(ft["minQty"].(string))
+				if err != nil {
+					log.Errorf("cfutures binance fetch_symbol minQty参数转换失败:%v", err)
+					return nil, err
+				}
+				max, err := utils.ParseFloat(ft["maxQty"].(string))
+				if err != nil {
+					log.Errorf("cfutures binance fetch_symbol maxQty参数转换失败:%v", err)
+					return nil, err
+				}
+				info.MinCnt = min
+				info.MaxCnt = max
+			}
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package binancecfutures
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type TickerResponse []TickerInfo
+
+// TickerInfo volume单位为张，baseVolume为折算的币数量
+type TickerInfo struct {
+	Symbol     string `json:"symbol"`
+	Pair       string `json:"pair"`
+	HighPrice  string `json:"highPrice"`
+	LowPrice   string `json:"lowPrice"`
+	OpenPrice  string `json:"openPrice"`
+	LastPrice  string `json:"lastPrice"`
+	LastQty    string `json:"lastQty"`
+	Volume     string `json:"volume"`
+	BaseVolume string `json:"baseVolume"`
+	CloseTime  int64  `json:"closeTime"`
+}
+
+func (client *RestClient) FetchTickers() ([]*types.Ticker, error) {
+	url := client.restUrl + TickerRest
+	body, _, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("cfutures binance FetchTicker 网络错误:%v", err)
+		return nil, err
+	}
+
+	response := make(TickerResponse, 0)
+	if err := utils.JsonDecode(body, &response); err != nil {
+		return nil, err
+	}
+
+	result, err := tickersTransform(response)
+	if err != nil {
+		err := fmt.Errorf("binance get /dapi/v1/ticker/24hr transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// tickersTransform Vol与okx的vol24h一致，单位为张
+func tickersTransform(response TickerResponse) ([]*types.Ticker, error) {
+	result := make([]*types.Ticker, 0, len(response))
+	for _, item := range response {
+		if item.Symbol == "" {
+			continue
+		}
+		open, err := utils.ParseFloat(item.OpenPrice)
+		if err != nil {
+			log.Errorf("cfutures binance fetch_ticker open参数转换失败:%v", err)
+			return nil, err
+		}
+		high, err := utils.ParseFloat(item.HighPrice)
+		if err != nil {
+			log.Errorf("cfutures binance fetch_ticker high参数转换失败:%v", err)
+			return nil, err
+		}
+		low, err := utils.ParseFloat(item.LowPrice)
+		if err != nil {
+			log.Errorf("cfutures binance fetch_ticker low参数转换失败:%v", err)
+			return nil, err
+		}
+		lastPrice, err := utils.ParseFloat(item.LastPrice)
+		if err != nil {
+			log.Errorf("cfutures binance fetch_ticker close参数转换失败:%v", err)
+			return nil, err
+		}
+		lastSize, _ := utils.ParseFloat(item.LastQty)
+		volume, _ := utils.ParseFloat(item.Volume)
+
+		result = append(result, &types.Ticker{
+			Symbol:     Binance2Symbol(item.Symbol),
+			Open:       open,
+			High:       high,
+			Low:        low,
+			Vol:        volume,
+			LastPrice:  lastPrice,
+			LastSize:   lastSize,
+			Ts:         utils.Millisec(time.Now()),
+			ExchangeTs: item.CloseTime,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,5 @@
+package binancecfutures
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("package", "binancecfutures")
@@ -0,0 +1,201 @@
+package binancecfutures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	RestUrl  = "https://dapi.binance.com"
+	PubWsUrl = "wss://dstream.binance.com/stream"
+	PriWsUrl = "wss://dstream.binance.com/ws/"
+
+	// Environments 各环境的地址，通过ExchangeParameters.Environment选择
+	Environments = map[constant.Environment]base.Endpoints{
+		constant.EnvProduction: {
+			RestUrl:  RestUrl,
+			PubWsUrl: PubWsUrl,
+			PriWsUrl: PriWsUrl,
+		},
+		constant.EnvTestnet: {
+			RestUrl:  "https://testnet.binancefuture.com",
+			PubWsUrl: "wss://dstream.binancefuture.com/stream",
+			PriWsUrl: "wss://dstream.binancefuture.com/ws/",
+		},
+	}
+
+	// 有限档深度频道
+	DepthStream = "depth20@100ms"
+
+	// 下单方向对应的 side 和 positionSide，单向持仓模式positionSide为空
+	Side2Binance = map[string][2]string{
+		constant.OrderBuy.Name():   {"BUY", ""},
+		constant.OrderSell.Name():  {"SELL", ""},
+		constant.Long.Name():       {"BUY", "LONG"},
+		constant.Short.Name():      {"SELL", "SHORT"},
+		constant.CloseLong.Name():  {"SELL", "LONG"},
+		constant.CloseShort.Name(): {"BUY", "SHORT"},
+	}
+	TimeInForce2Binance = map[string]string{
+		constant.Limit.Name():    "GTC",
+		constant.GTC.Name():      "GTC",
+		constant.IOC.Name():      "IOC",
+		constant.FOK.Name():      "FOK",
+		constant.PostOnly.Name(): "GTX",
+	}
+	Binance2Side = map[string]constant.OrderSide{
+		"BUY":  constant.OrderBuy,
+		"SELL": constant.OrderSell,
+	}
+	Binance2Type = map[string]constant.OrderType{
+		"LIMIT":  constant.Limit,
+		"MARKET": constant.Market,
+	}
+	TimeInForce2Type = map[string]constant.OrderType{
+		"IOC": constant.IOC,
+		"FOK": constant.FOK,
+		"GTX": constant.PostOnly,
+	}
+	Binance2Status = map[string]constant.OrderStatus{
+		"NEW":              constant.OrderOpen,
+		"PARTIALLY_FILLED": constant.OrderPartialFilled,
+		"FILLED":           constant.OrderFilled,
+		"CANCELED":         constant.OrderCanceled,
+		"REJECTED":         constant.OrderRejected,
+		"EXPIRED":          constant.OrderCanceled,
+		"EXPIRED_IN_MATCH": constant.OrderCanceled,
+	}
+	Binance2MarginMode = map[string]string{
+		"isolated": "FIXED",
+		"cross":    "CROSSED",
+	}
+)
+
+// binanceC rest 接口url
+const (
+	OhlcvRest       = "/dapi/v1/klines?%s"    // limit: 大于1000,500-1000,100-499,小于100时 权重：10,5,2,1
+	SymbolsRest     = "/dapi/v1/exchangeInfo" // 权重 1
+	TickerRest      = "/dapi/v1/ticker/24hr"  // 带 symbol：权重 1，不带 symbol：权重 40
+	MarkPriceRest   = "/dapi/v1/premiumIndex" // 权重 10
+	FundingRateRest = "/dapi/v1/fundingRate"  // 权重 1
+	//私有接口
+	CancelMoreOrderRest = "/dapi/v1/batchOrders"       // 批量撤单接口 权重 1
+	CreatMoreOrderRest  = "/dapi/v1/batchOrders"       // 批量挂单接口 权重 5
+	AmendOrderRest      = "/dapi/v1/order"             // 改单接口 权重 1
+	BalanceRest         = "/dapi/v1/account"           // 权重 5
+	OpenOrderRest       = "/dapi/v1/openOrders"        // 带 symbol：权重 1，不带 symbol：权重 40
+	OrderRest           = "/dapi/v1/order"             // 权重 1
+	PositionRest        = "/dapi/v1/positionRisk"      // 权重 1
+	UserTread           = "/dapi/v1/userTrades"        // 权重 20
+	SetLeverage         = "/dapi/v1/leverage"          // 权重 1
+	ListenKeyRest       = "/dapi/v1/listenKey"         // 权重 1
+	MarginTypeRest      = "/dapi/v1/marginType"        // 权重 1
+	PositionSideRest    = "/dapi/v1/positionSide/dual" // 权重 1
+
+	// 批量下单每次最多5个，批量撤单每次最多10个
+	MaxBatchCreateOrders = 5
+	MaxBatchCancelOrders = 10
+
+	// 资金费率结算间隔(小时)
+	DefaultFundingIntervalHours = 8
+
+	// 币本位合约只有USD计价
+	quoteCoin  = "USD"
+	perpSuffix = "PERP"
+)
+
+// Symbol2Binance 永续 BTC_USD_SWAP => BTCUSD_PERP，交割 BTC_USD_240628 => BTCUSD_240628
+func Symbol2Binance(symbol string) string {
+	tmp := strings.Split(symbol, "_")
+	if len(tmp) != 3 {
+		panic("bad symbol:" + symbol)
+	}
+	suffix := tmp[2]
+	if suffix == "SWAP" {
+		suffix = perpSuffix
+	}
+	return tmp[0] + tmp[1] + "_" + suffix
+}
+
+// Binance2Symbol BTCUSD_PERP => BTC_USD_SWAP，BTCUSD_240628 => BTC_USD_240628
+func Binance2Symbol(symbol string) string {
+	tmp := strings.Split(symbol, "_")
+	if len(tmp) != 2 || !strings.HasSuffix(tmp[0], quoteCoin) {
+		return symbol
+	}
+	base := strings.TrimSuffix(tmp[0], quoteCoin)
+	suffix := tmp[1]
+	if suffix == perpSuffix {
+		suffix = "SWAP"
+	}
+	return fmt.Sprintf("%s_%s_%s", base, quoteCoin, suffix)
+}
+
+// IsPerpSymbol 是否为永续合约，交割合约没有资金费率
+func IsPerpSymbol(symbol string) bool {
+	return strings.HasSuffix(symbol, "_SWAP")
+}
+
+// getOrderSide 双向持仓模式下结合positionSide还原开平方向
+func getOrderSide(side, positionSide string) constant.OrderSide {
+	switch positionSide {
+	case "LONG":
+		if side == "BUY" {
+			return constant.Long
+		}
+		return constant.CloseLong
+	case "SHORT":
+		if side == "SELL" {
+			return constant.Short
+		}
+		return constant.CloseShort
+	default:
+		return Binance2Side[side]
+	}
+}
+
+// getPositionSide 单向持仓平仓后方向为空
+func getPositionSide(side string, pos float64) string {
+	switch side {
+	case "BOTH":
+		if pos > 0 {
+			return constant.Long.Name()
+		}
+		if pos < 0 {
+			return constant.Short.Name()
+		}
+		return ""
+	case "LONG":
+		return constant.Long.Name()
+	default:
+		return constant.Short.Name()
+	}
+}
+
+// Symbol2BinanceWsInstId 行情频道使用小写的交易对 btcusd_perp
+func Symbol2BinanceWsInstId(symbol string) string {
+	return strings.ToLower(Symbol2Binance(symbol))
+}
+
+// Qty2Contracts 币的数量按价格换算为张数，每张面值为faceVal(USD)，不足一张的部分舍去
+func Qty2Contracts(qty, price, faceVal float64) int64 {
+	if faceVal <= 0 {
+		return 0
+	}
+	usd := decimal.NewFromFloat(qty).Mul(decimal.NewFromFloat(price))
+	return usd.Div(decimal.NewFromFloat(faceVal)).Floor().IntPart()
+}
+
+// Contracts2Qty 张数按价格换算为币的数量
+func Contracts2Qty(contracts int64, price, faceVal float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+	usd := decimal.NewFromInt(contracts).Mul(decimal.NewFromFloat(faceVal))
+	qty, _ := usd.Div(decimal.NewFromFloat(price)).Float64()
+	return qty
+}
@@ -0,0 +1,64 @@
+package binancecfutures
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+func TestSymbol2Binance(t *testing.T) {
+	cases := map[string]string{
+		"BTC_USD_SWAP":   "BTCUSD_PERP",
+		"ETH_USD_240628": "ETHUSD_240628",
+	}
+	for symbol, instId := range cases {
+		if got := Symbol2Binance(symbol); got != instId {
+			t.Fatalf("Symbol2Binance(%s) = %s, want %s", symbol, got, instId)
+		}
+		if got := Binance2Symbol(instId); got != symbol {
+			t.Fatalf("Binance2Symbol(%s) = %s, want %s", instId, got, symbol)
+		}
+	}
+	if !IsPerpSymbol("BTC_USD_SWAP") || IsPerpSymbol("BTC_USD_240628") {
+		t.Fatalf("unexpected IsPerpSymbol")
+	}
+	if got := Symbol2BinanceWsInstId("BTC_USD_SWAP"); got != "btcusd_perp" {
+		t.Fatalf("Symbol2BinanceWsInstId = %s", got)
+	}
+}
+
+func TestContractsConvert(t *testing.T) {
+	// BTCUSD_PERP每张100USD
+	if got := Qty2Contracts(0.3, 50000, 100); got != 150 {
+		t.Fatalf("Qty2Contracts = %d, want 150", got)
+	}
+	// 不足一张舍去
+	if got := Qty2Contracts(0.0019, 50000, 100); got != 0 {
+		t.Fatalf("Qty2Contracts = %d, want 0", got)
+	}
+	if got := Contracts2Qty(150, 50000, 100); got != 0.3 {
+		t.Fatalf("Contracts2Qty = %v, want 0.3", got)
+	}
+	if got := Contracts2Qty(1, 0, 100); got != 0 {
+		t.Fatalf("Contracts2Qty without price = %v, want 0", got)
+	}
+}
+
+func TestGetPositionSide(t *testing.T) {
+	cases := []struct {
+		side   string
+		pos    float64
+		expect string
+	}{
+		{"BOTH", 1, constant.Long.Name()},
+		{"BOTH", -1, constant.Short.Name()},
+		{"BOTH", 0, ""},
+		{"LONG", 0, constant.Long.Name()},
+		{"SHORT", 1, constant.Short.Name()},
+	}
+	for _, c := range cases {
+		if got := getPositionSide(c.side, c.pos); got != c.expect {
+			t.Errorf("getPositionSide(%s, %v) = %s, want %s", c.side, c.pos, got, c.expect)
+		}
+	}
+}
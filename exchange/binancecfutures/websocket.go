@@ -0,0 +1,412 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+	"github.com/gorilla/websocket"
+)
+
+type BinanceWsData struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type BinanceImp struct {
+	accessKey  string
+	secretKey  string
+	passphrase string
+	isPrivate  bool
+	rspHandle  func(interface{})
+}
+
+func NewBinanceCFuturesPubWsClient(url string, rspHandle func(interface{})) *ws.WsClient {
+	imp := &BinanceImp{rspHandle: rspHandle}
+	client := ws.NewWsClient(url, imp, constant.BinanceCFutures, 20*time.Second, 30*time.Second)
+	// 订阅消息合约每秒最多10条，在写协程中按间隔发送
+	client.SetWriteInterval(100 * time.Millisecond)
+	return client
+}
+
+// NewBinanceCFuturesPriWsClient 每次连接(包括重连)前通过listenKey重新申请，申请失败时地址为空，Dial失败后重试
+func NewBinanceCFuturesPriWsClient(url, accessKey, secretKey, passphrase string, listenKey func() (string, error), rspHandle func(interface{})) *ws.WsClient {
+	imp := &BinanceImp{
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		passphrase: passphrase,
+		rspHandle:  rspHandle,
+		isPrivate:  true,
+	}
+	client := ws.NewWsClient(url, imp, constant.BinanceCFutures, 20*time.Second, 30*time.Second)
+	client.SetUrlFunc(func() string {
+		key, err := listenKey()
+		if err != nil {
+			log.Errorf("binance cfutures GetListenKey err:%v", err)
+			return ""
+		}
+		return url + key
+	})
+	return client
+}
+
+func (binance *BinanceImp) Ping(cli *ws.WsClient) {
+	deadline := time.Now().Add(10 * time.Second)
+	err := cli.Conn.WriteControl(websocket.PingMessage, []byte{}, deadline)
+	if err != nil {
+		log.Errorf("ping error %s", err)
+		return
+	}
+
+	log.Infof("ping %s", deadline)
+}
+
+func (binance *BinanceImp) OnConnected(cli *ws.WsClient, typ ws.ConnectType) {
+	if !binance.isPrivate {
+		log.Info("binance cfutures public ws connected")
+		return
+	}
+	log.Info("binance cfutures private ws connected")
+}
+
+// Subscribe 订阅 <symbol>@<topic>，如 bookTicker/aggTrade/depth20@100ms，symbol为空时topic为完整的频道名，如 !forceOrder@arr
+func (binance *BinanceImp) Subscribe(symbol string, topic string) map[string]interface{} {
+	stream := topic
+	if symbol != "" {
+		stream = Symbol2BinanceWsInstId(symbol) + "@" + topic
+	}
+	return map[string]interface{}{
+		"method": "SUBSCRIBE",
+		"params": []string{stream},
+		"id":     1,
+	}
+}
+
+func (binance *BinanceImp) Handle(cli *ws.WsClient, bs []byte) {
+	if binance.isPrivate {
+		binance.handleUserData(cli, bs)
+		return
+	}
+
+	var dat BinanceWsData
+	if err := sonic.Unmarshal(bs, &dat); err != nil {
+		log.WithError(err).Errorf("unmarshal binance ws data failed %s", bs)
+		return
+	}
+
+	// 订阅结果 {"result":null,"id":1}
+	if dat.Stream == "" {
+		return
+	}
+
+	// <symbol>@<channel>，有限档深度为 <symbol>@depth20@100ms
+	parts := strings.Split(dat.Stream, "@")
+	if len(parts) < 2 {
+		log.Errorf("Stream format is incorrect %s", dat.Stream)
+		return
+	}
+
+	channel := parts[1]
+	switch {
+	case channel == "bookTicker":
+		binance.onBboTbtRecv(dat.Data)
+	case channel == "aggTrade":
+		binance.onAggTrade(dat.Data)
+	case channel == "markPrice":
+		binance.onMarkPrice(dat.Data)
+	case channel == ForceOrderStream || parts[0] == "!"+ForceOrderStream:
+		binance.onForceOrder(dat.Data)
+	case strings.HasPrefix(channel, "depth"):
+		binance.onDepth(parts[0], dat.Data)
+	}
+}
+
+func (binance *BinanceImp) onAggTrade(data json.RawMessage) {
+	type aggTrade struct {
+		Symbol       string `json:"s"`
+		AggTradeId   int64  `json:"a"`
+		Price        string `json:"p"`
+		Quantity     string `json:"q"`
+		FirstTradeId int64  `json:"f"`
+		LastTradeId  int64  `json:"l"`
+		TradeTime    int64  `json:"T"`
+		IsBuyerMaker bool   `json:"m"`
+	}
+
+	var trade aggTrade
+	if err := sonic.Unmarshal(data, &trade); err != nil {
+		log.WithError(err).Error("unmarshal binance aggTrade failed")
+		return
+	}
+
+	price, _ := utils.ParseFloat(trade.Price)
+	size, _ := utils.ParseFloat(trade.Quantity)
+	// 买方是maker说明主动成交方向为卖
+	side := constant.OrderBuy
+	if trade.IsBuyerMaker {
+		side = constant.OrderSell
+	}
+
+	evt := &types.Trade{
+		Symbol:     Binance2Symbol(trade.Symbol),
+		MarketType: constant.BinanceCFutures,
+		TradeID:    strconv.FormatInt(trade.AggTradeId, 10),
+		Side:       side,
+		Price:      price,
+		Size:       size,
+		Count:      trade.LastTradeId - trade.FirstTradeId + 1,
+		ExchangeTs: trade.TradeTime * 1000,
+		LocalTs:    utils.Microsec(time.Now()),
+		EventTs:    utils.Microsec(time.Now()),
+	}
+	binance.rspHandle([]*types.Trade{evt})
+}
+
+func depthItemsTransform(levels [][]string) []types.OrderBookItem {
+	result := make([]types.OrderBookItem, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		price, _ := utils.ParseFloat(level[0])
+		qty, _ := utils.ParseFloat(level[1])
+		result = append(result, types.OrderBookItem{Price: price, Qty: qty})
+	}
+	return result
+}
+
+func (binance *BinanceImp) onBboTbtRecv(data json.RawMessage) {
+	type bookTicker struct {
+		Event     string `json:"e"`
+		UpdateID  int64  `json:"u"`
+		Symbol    string `json:"s"`
+		BidPrice  string `json:"b"`
+		BidSize   string `json:"B"`
+		AskPrice  string `json:"a"`
+		AskSize   string `json:"A"`
+		Ts        int64  `json:"T"`
+		EventTime int64  `json:"E"`
+	}
+
+	var ticker bookTicker
+	if err := sonic.Unmarshal(data, &ticker); err != nil {
+		log.WithError(err).Error("unmarshal binance tbt failed")
+		return
+	}
+
+	askPirce, _ := utils.ParseFloat(ticker.AskPrice)
+	askSize, _ := utils.ParseFloat(ticker.AskSize)
+	bidPirce, _ := utils.ParseFloat(ticker.BidPrice)
+	bidSize, _ := utils.ParseFloat(ticker.BidSize)
+
+	evt := &types.BookTicker{
+		Symbol:     Binance2Symbol(ticker.Symbol),
+		Exchange:   constant.BinanceCFutures,
+		AskPrice:   askPirce,
+		AskQty:     askSize,
+		BidPrice:   bidPirce,
+		BidQty:     bidSize,
+		ExchangeTs: ticker.Ts,
+		TraceId:    utils.RandomString(8),
+		LocalTs:    utils.Microsec(time.Now()),
+	}
+
+	binance.rspHandle(evt)
+}
+
+func (binance *BinanceImp) onDepth(instId string, data json.RawMessage) {
+	type depthUpdate struct {
+		Symbol    string     `json:"s"`
+		EventTime int64      `json:"E"`
+		Ts        int64      `json:"T"`
+		Bids      [][]string `json:"b"`
+		Asks      [][]string `json:"a"`
+	}
+
+	var book depthUpdate
+	if err := sonic.Unmarshal(data, &book); err != nil {
+		log.WithError(err).Error("unmarshal binance depth failed")
+		return
+	}
+
+	evt := &types.OrderBook{
+		Symbol:     Binance2Symbol(book.Symbol),
+		Exchange:   constant.BinanceCFutures,
+		Asks:       depthItemsTransform(book.Asks),
+		Bids:       depthItemsTransform(book.Bids),
+		ExchangeTs: book.Ts * 1000,
+		Ts:         utils.Microsec(time.Now()),
+		TraceId:    utils.RandomString(8),
+	}
+	binance.rspHandle(evt)
+}
+
+type UserDataEvent struct {
+	Event     string `json:"e"`
+	EventTime int64  `json:"E"`
+}
+
+// OrderTradeUpdate 币本位合约订单更新推送
+type OrderTradeUpdate struct {
+	Event           string `json:"e"`
+	EventTime       int64  `json:"E"`
+	TransactionTime int64  `json:"T"`
+	Order           struct {
+		Symbol          string `json:"s"`
+		ClientOrderId   string `json:"c"`
+		Side            string `json:"S"`
+		OrderType       string `json:"o"`
+		TimeInForce     string `json:"f"`
+		Quantity        string `json:"q"`
+		Price           string `json:"p"`
+		AvgPrice        string `json:"ap"`
+		ExecutionType   string `json:"x"`
+		Status          string `json:"X"`
+		OrderId         int64  `json:"i"`
+		LastExecutedQty string `json:"l"`
+		CumExecutedQty  string `json:"z"`
+		LastPrice       string `json:"L"`
+		CommissionAsset string `json:"N"`
+		Commission      string `json:"n"`
+		TradeTime       int64  `json:"T"`
+		TradeId         int64  `json:"t"`
+		IsMaker         bool   `json:"m"`
+		ReduceOnly      bool   `json:"R"`
+		PositionSide    string `json:"ps"`
+		RealizedProfit  string `json:"rp"`
+	} `json:"o"`
+}
+
+// ToOrder 数量单位为张，推送中没有合约面值，不计算ExecutedAmt，交易所实例回调前换算为币
+func (u *OrderTradeUpdate) ToOrder() *types.Order {
+	o := u.Order
+	orderType := Binance2Type[o.OrderType]
+	if typ, ok := TimeInForce2Type[o.TimeInForce]; ok && orderType == constant.Limit {
+		orderType = typ
+	}
+
+	return &types.Order{
+		Symbol:      Binance2Symbol(o.Symbol),
+		Exchange:    constant.BinanceCFutures,
+		Type:        orderType,
+		OrderID:     strconv.FormatInt(o.OrderId, 10),
+		ClientID:    o.ClientOrderId,
+		Side:        getOrderSide(o.Side, o.PositionSide),
+		Price:       o.Price,
+		OrigQty:     o.Quantity,
+		ExecutedQty: o.CumExecutedQty,
+		AvgPrice:    o.AvgPrice,
+		Fee:         o.Commission,
+		Status:      Binance2Status[o.Status],
+		ReduceOnly:  o.ReduceOnly,
+		UpdateAt:    u.TransactionTime,
+	}
+}
+
+// AccountUpdate 合约余额和持仓变动推送，只包含发生变化的币种和持仓
+type AccountUpdate struct {
+	Event           string `json:"e"`
+	EventTime       int64  `json:"E"`
+	TransactionTime int64  `json:"T"`
+	Account         struct {
+		Reason   string `json:"m"`
+		Balances []struct {
+			Asset              string `json:"a"`
+			WalletBalance      string `json:"wb"`
+			CrossWalletBalance string `json:"cw"`
+			BalanceChange      string `json:"bc"`
+		} `json:"B"`
+		Positions []struct {
+			Symbol         string `json:"s"`
+			PositionAmt    string `json:"pa"`
+			EntryPrice     string `json:"ep"`
+			UnrealizedPnl  string `json:"up"`
+			MarginType     string `json:"mt"`
+			IsolatedWallet string `json:"iw"`
+			PositionSide   string `json:"ps"`
+		} `json:"P"`
+	} `json:"a"`
+}
+
+func (u *AccountUpdate) ToAssets() *types.Assets {
+	assets := make(map[string]types.Asset, len(u.Account.Balances))
+	for _, item := range u.Account.Balances {
+		wallet, _ := utils.ParseFloat(item.WalletBalance)
+		cross, _ := utils.ParseFloat(item.CrossWalletBalance)
+		coin := strings.ToUpper(item.Asset)
+		assets[coin] = types.Asset{
+			Coin:  coin,
+			Free:  cross, // 全仓钱包余额
+			Total: wallet,
+		}
+	}
+	return &types.Assets{Assets: assets}
+}
+
+// ToPositions 与rest接口不同，持仓为0时也会返回，表示该方向已平仓
+func (u *AccountUpdate) ToPositions() []*types.Position {
+	result := make([]*types.Position, 0, len(u.Account.Positions))
+	for _, item := range u.Account.Positions {
+		amt, _ := utils.ParseFloat(item.PositionAmt)
+		entryPrice, _ := utils.ParseFloat(item.EntryPrice)
+		pnl, _ := utils.ParseFloat(item.UnrealizedPnl)
+		margin, _ := utils.ParseFloat(item.IsolatedWallet)
+		result = append(result, &types.Position{
+			MarginMode:    Binance2MarginMode[item.MarginType],
+			Symbol:        Binance2Symbol(item.Symbol),
+			Side:          getPositionSide(item.PositionSide, amt),
+			Position:      math.Abs(amt),
+			AvgCost:       entryPrice,
+			UnrealisedPnl: pnl,
+			Margin:        margin,
+		})
+	}
+	return result
+}
+
+func (binance *BinanceImp) handleUserData(cli *ws.WsClient, bs []byte) {
+	var evt UserDataEvent
+	if err := sonic.Unmarshal(bs, &evt); err != nil {
+		log.WithError(err).Error("unmarshal binance user data failed")
+		return
+	}
+
+	switch evt.Event {
+	case "ORDER_TRADE_UPDATE":
+		var update OrderTradeUpdate
+		if err := sonic.Unmarshal(bs, &update); err != nil {
+			log.WithError(err).Error("unmarshal binance ORDER_TRADE_UPDATE failed")
+			return
+		}
+		binance.rspHandle([]*types.Order{update.ToOrder()})
+	case "ACCOUNT_UPDATE":
+		var update AccountUpdate
+		if err := sonic.Unmarshal(bs, &update); err != nil {
+			log.WithError(err).Error("unmarshal binance ACCOUNT_UPDATE failed")
+			return
+		}
+		if len(update.Account.Balances) > 0 {
+			binance.rspHandle(update.ToAssets())
+		}
+		if len(update.Account.Positions) > 0 {
+			binance.rspHandle(update.ToPositions())
+		}
+	case "listenKeyExpired":
+		// 重连时会申请新的listenKey
+		log.Errorf("binance cfutures listenKey expired %s", bs)
+		cli.Reconnect()
+	case "MARGIN_CALL":
+		log.Warnf("binance cfutures margin call %s", bs)
+	case "ACCOUNT_CONFIG_UPDATE", "TRADE_LITE":
+		// 杠杆变化和精简成交推送，暂不处理
+	default:
+		log.Warnf("unknown binance user data %s", bs)
+	}
+}
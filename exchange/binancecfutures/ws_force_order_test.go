@@ -0,0 +1,39 @@
+package binancecfutures
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestOnForceOrder(t *testing.T) {
+	tests := []struct {
+		msg        string
+		symbol     string
+		side       constant.OrderSide
+		price      float64
+		size       float64
+		exchangeTs int64
+	}{
+		{
+			msg: `{"e":"forceOrder","E":1591154240950,"o":{"s":"BTCUSD_200925","ps":"BTCUSD","S":"BUY","o":"LIMIT","f":"IOC",
+				"q":"1","p":"9425.5","ap":"9496.5","X":"FILLED","l":"1","z":"1","T":1591154240949}}`,
+			symbol: "BTC_USD_200925", side: constant.OrderBuy, price: 9496.5, size: 1, exchangeTs: 1591154240949000,
+		},
+		{
+			msg:    `{"e":"forceOrder","E":1591154240960,"o":{"s":"ETHUSD_PERP","S":"SELL","q":"3","p":"230.5","ap":"0","z":"0","T":1591154240959}}`,
+			symbol: "ETH_USD_SWAP", side: constant.OrderSell, price: 230.5, size: 3, exchangeTs: 1591154240959000,
+		},
+	}
+	for _, tt := range tests {
+		var liquidation *types.Liquidation
+		imp := &BinanceImp{rspHandle: func(data interface{}) { liquidation = data.(*types.Liquidation) }}
+		imp.onForceOrder([]byte(tt.msg))
+		if liquidation == nil || liquidation.Symbol != tt.symbol || liquidation.Exchange != constant.BinanceCFutures ||
+			liquidation.Side != tt.side || liquidation.Price != tt.price || liquidation.Size != tt.size ||
+			liquidation.ExchangeTs != tt.exchangeTs {
+			t.Fatalf("unexpected liquidation %+v", liquidation)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package binancecfutures
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/binancecommon"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+// MarkPriceStream 每秒推送标记价格、指数价格和资金费率
+const MarkPriceStream = "markPrice@1s"
+
+// onMarkPrice 指数名称为 BTC_USD，永续和交割合约共用
+func (binance *BinanceImp) onMarkPrice(data json.RawMessage) {
+	var price binancecommon.MarkPrice
+	if err := sonic.Unmarshal(data, &price); err != nil {
+		log.WithError(err).Error("unmarshal binance markPrice failed")
+		return
+	}
+	symbol := Binance2Symbol(price.Symbol)
+	tmp := strings.Split(symbol, "_")
+	binance.rspHandle(price.ToUpdate(constant.BinanceCFutures, symbol, tmp[0]+"_"+tmp[1]))
+}
@@ -0,0 +1,38 @@
+package binancecfutures
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+func TestOnMarkPrice(t *testing.T) {
+	tests := []struct {
+		msg         string
+		symbol      string
+		indexSymbol string
+		markPrice   float64
+		indexPrice  float64
+		exchangeTs  int64
+	}{
+		{
+			msg: `{"e":"markPriceUpdate","E":1596095725000,"s":"BTCUSD_201225","p":"10934.62615417","P":"10962.17178236",
+				"i":"10933.62615417","r":"","T":0}`,
+			symbol: "BTC_USD_201225", indexSymbol: "BTC_USD", markPrice: 10934.62615417, indexPrice: 10933.62615417, exchangeTs: 1596095725000000,
+		},
+		{
+			msg:    `{"e":"markPriceUpdate","E":1596095726000,"s":"ETHUSD_PERP","p":"2000.5","P":"2001","i":"2000.1","r":"0.0001","T":1596096000000}`,
+			symbol: "ETH_USD_SWAP", indexSymbol: "ETH_USD", markPrice: 2000.5, indexPrice: 2000.1, exchangeTs: 1596095726000000,
+		},
+	}
+	for _, tt := range tests {
+		var update *base.MarkPriceUpdate
+		imp := &BinanceImp{rspHandle: func(data interface{}) { update = data.(*base.MarkPriceUpdate) }}
+		imp.onMarkPrice([]byte(tt.msg))
+		if update == nil || update.Symbol != tt.symbol || update.IndexSymbol != tt.indexSymbol || update.Exchange != constant.BinanceCFutures ||
+			update.MarkPrice != tt.markPrice || update.IndexPrice != tt.indexPrice || update.ExchangeTs != tt.exchangeTs {
+			t.Fatalf("unexpected update %+v", update)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package binancecommon
+
+import (
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// ForceOrder forceOrder频道的原始推送
+type ForceOrder struct {
+	Event     string `json:"e"`
+	EventTime int64  `json:"E"`
+	Order     struct {
+		Symbol    string `json:"s"`
+		Side      string `json:"S"`
+		Price     string `json:"p"`
+		AvgPrice  string `json:"ap"`
+		OrigQty   string `json:"q"`
+		FilledQty string `json:"z"`
+		TradeTime int64  `json:"T"`
+	} `json:"o"`
+}
+
+// ToLiquidation symbol为转换后的交易对，优先使用成交均价和已成交数量
+func (f *ForceOrder) ToLiquidation(exchange constant.ExchangeType, symbol string) *types.Liquidation {
+	o := f.Order
+	price, _ := utils.ParseFloat(o.AvgPrice)
+	if price == 0 {
+		price, _ = utils.ParseFloat(o.Price)
+	}
+	size, _ := utils.ParseFloat(o.FilledQty)
+	if size == 0 {
+		size, _ = utils.ParseFloat(o.OrigQty)
+	}
+	side := constant.OrderSell
+	if o.Side == "BUY" {
+		side = constant.OrderBuy
+	}
+	return &types.Liquidation{
+		Symbol:     symbol,
+		Exchange:   exchange,
+		Side:       side,
+		Price:      price,
+		Size:       size,
+		ExchangeTs: o.TradeTime * 1000,
+		Ts:         utils.Millisec(time.Now()),
+	}
+}
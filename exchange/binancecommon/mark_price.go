@@ -0,0 +1,42 @@
+// Package binancecommon binance U本位和币本位合约共用的推送解析
+//
+// sonic解析json时字段名大小写不敏感，e/E、p/P这类只差大小写的字段都需要在结构体中显式声明，否则会互相覆盖
+package binancecommon
+
+import (
+	"time"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+// MarkPrice markPrice频道的原始推送
+type MarkPrice struct {
+	Event           string `json:"e"`
+	EventTime       int64  `json:"E"`
+	Symbol          string `json:"s"`
+	MarkPrice       string `json:"p"`
+	SettlePrice     string `json:"P"` // 预估结算价
+	IndexPrice      string `json:"i"`
+	FundingRate     string `json:"r"`
+	NextFundingTime int64  `json:"T"`
+}
+
+// ToUpdate symbol和indexSymbol为转换后的交易对和指数名称
+func (p *MarkPrice) ToUpdate(exchange constant.ExchangeType, symbol, indexSymbol string) *base.MarkPriceUpdate {
+	mark, _ := utils.ParseFloat(p.MarkPrice)
+	index, _ := utils.ParseFloat(p.IndexPrice)
+	rate, _ := utils.ParseFloat(p.FundingRate)
+	return &base.MarkPriceUpdate{
+		Symbol:          symbol,
+		IndexSymbol:     indexSymbol,
+		Exchange:        exchange,
+		MarkPrice:       mark,
+		IndexPrice:      index,
+		FundingRate:     rate,
+		NextFundingTime: p.NextFundingTime,
+		ExchangeTs:      p.EventTime * 1000,
+		Ts:              utils.Millisec(time.Now()),
+	}
+}
@@ -0,0 +1,96 @@
+package binanceportfolio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/exchange/binancecfutures"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+// 持仓模式未变化时返回的错误码
+const noNeedChangePositionSide = -4059
+
+type BinanceErrRsp struct {
+	Code int32  `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// SetUMLeverage 调整U本位合约交易对的开仓杠杆
+func (client *RestClient) SetUMLeverage(symbol string, leverage int64) error {
+	param := map[string]interface{}{
+		"symbol":   Symbol2Binance(symbol),
+		"leverage": leverage,
+	}
+	uri := UMLeverageUri
+	body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+	if err != nil {
+		log.Errorf("binance post /papi/v1/um/leverage err: %v", err)
+		return err
+	}
+	if res.StatusCode != 200 {
+		return fmt.Errorf("binance post /papi/v1/um/leverage err: %v %s", res.StatusCode, body)
+	}
+	return nil
+}
+
+// SetUMPositionMode 更改U本位合约的持仓模式，有持仓或挂单时不能修改
+func (client *RestClient) SetUMPositionMode(mode constant.PositionMode) error {
+	param := map[string]interface{}{
+		"dualSidePosition": fmt.Sprintf("%v", mode == constant.HedgeMode),
+	}
+	uri := UMPositionSideUri
+	body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+	if err != nil {
+		log.Errorf("binance post /papi/v1/um/positionSide/dual err: %v", err)
+		return err
+	}
+	if res.StatusCode != 200 {
+		var errRsp BinanceErrRsp
+		if err = json.Unmarshal(body, &errRsp); err == nil && errRsp.Code == noNeedChangePositionSide {
+			return nil
+		}
+		return fmt.Errorf("binance post /papi/v1/um/positionSide/dual err: %v %s", res.StatusCode, body)
+	}
+	return nil
+}
+
+// SetCMLeverage 调整币本位合约交易对的开仓杠杆
+func (client *RestClient) SetCMLeverage(symbol string, leverage int64) error {
+	param := map[string]interface{}{
+		"symbol":   binancecfutures.Symbol2Binance(symbol),
+		"leverage": leverage,
+	}
+	uri := CMLeverageUri
+	body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+	if err != nil {
+		log.Errorf("binance post /papi/v1/cm/leverage err: %v", err)
+		return err
+	}
+	if res.StatusCode != 200 {
+		return fmt.Errorf("binance post /papi/v1/cm/leverage err: %v %s", res.StatusCode, body)
+	}
+	return nil
+}
+
+// SetCMPositionMode 更改币本位合约的持仓模式，有持仓或挂单时不能修改
+func (client *RestClient) SetCMPositionMode(mode constant.PositionMode) error {
+	param := map[string]interface{}{
+		"dualSidePosition": fmt.Sprintf("%v", mode == constant.HedgeMode),
+	}
+	uri := CMPositionSideUri
+	body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+	if err != nil {
+		log.Errorf("binance post /papi/v1/cm/positionSide/dual err: %v", err)
+		return err
+	}
+	if res.StatusCode != 200 {
+		var errRsp BinanceErrRsp
+		if err = json.Unmarshal(body, &errRsp); err == nil && errRsp.Code == noNeedChangePositionSide {
+			return nil
+		}
+		return fmt.Errorf("binance post /papi/v1/cm/positionSide/dual err: %v %s", res.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package binanceportfolio
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// AmendUMOrders 逐个调用 PUT /papi/v1/um/order 修改U本位限价单，订单ID不变
+func (client *RestClient) AmendUMOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		param := formAmendRequest(order)
+
+		uri := AmendUMOrderUri
+		body, res, err := client.HttpRequest(http.MethodPut, uri, param)
+		if err != nil {
+			log.Errorf("binance PUT /papi/v1/um/order err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance PUT /papi/v1/um/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderErrTransform(order, string(body)))
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Errorf("binance PUT /papi/v1/um/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+
+		info := orderTransform(order.Symbol, &orderResponse)
+		// 改单后可能已部分成交
+		if orderResponse.Status == "PARTIALLY_FILLED" {
+			info.IsSuccess = true
+		}
+		result = append(result, info)
+	}
+	return base.NativeAmendResults(orders, result), nil
+}
+
+// AmendCMOrders 逐个调用 PUT /papi/v1/cm/order 修改币本位限价单，订单ID不变
+func (client *RestClient) AmendCMOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		param := cmParam(formAmendRequest(order), order.Symbol)
+
+		uri := CMOrderUri
+		body, res, err := client.HttpRequest(http.MethodPut, uri, param)
+		if err != nil {
+			log.Errorf("binance PUT /papi/v1/cm/order err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance PUT /papi/v1/cm/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderErrTransform(order, string(body)))
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Errorf("binance PUT /papi/v1/cm/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+
+		info := orderTransform(order.Symbol, &orderResponse)
+		if orderResponse.Status == "PARTIALLY_FILLED" {
+			info.IsSuccess = true
+		}
+		result = append(result, info)
+	}
+	return base.NativeAmendResults(orders, result), nil
+}
+
+// formAmendRequest 改单必须同时带上side、quantity和price
+func formAmendRequest(order *types.Order) map[string]interface{} {
+	result := map[string]interface{}{
+		"symbol":   Symbol2Binance(order.Symbol),
+		"side":     Side2Binance[order.Side.Name()],
+		"quantity": order.OrigQty,
+		"price":    order.Price,
+	}
+	if order.OrderID != "" {
+		result["orderId"] = order.OrderID
+	} else {
+		result["origClientOrderId"] = order.ClientID
+	}
+	return result
+}
+
+// orderErrTransform 单个订单请求失败时的结果，保证返回结果与请求的订单一一对应
+func orderErrTransform(order *types.Order, msg string) *types.OrderResult {
+	return &types.OrderResult{
+		IsSuccess: false,
+		OrderId:   order.OrderID,
+		ClientId:  order.ClientID,
+		ErrCode:   -1,
+		ErrMsg:    msg,
+	}
+}
@@ -18,16 +18,87 @@ func (client *RestClient) CancelUMOrders(orders []*types.Order) ([]*types.OrderR
 		body, res, err := client.HttpRequest(http.MethodDelete, uri, param)
 		if err != nil {
 			log.Errorf("binance DELETE /papi/v1/um/order err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
 			continue
 		}
 		if res.StatusCode != 200 {
 			log.Errorf("binance DELETE /papi/v1/um/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderErrTransform(order, string(body)))
 			continue
 		}
 
 		var orderResponse OrderResponse
 		if err = json.Unmarshal(body, &orderResponse); err != nil {
 			log.Infof("binance DELETE /papi/v1/um/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+
+		info := orderCancelTransform(order.Symbol, &orderResponse)
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+func (client *RestClient) CancelMMOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0)
+	for _, order := range orders {
+		param := formCancelRequest(order)
+
+		uri := CancelMMOrderUri
+		body, res, err := client.HttpRequest(http.MethodDelete, uri, param)
+		if err != nil {
+			log.Errorf("binance DELETE /papi/v1/margin/order err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance DELETE /papi/v1/margin/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderErrTransform(order, string(body)))
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Infof("binance DELETE /papi/v1/margin/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+
+		info := orderCancelTransform(order.Symbol, &orderResponse)
+		// 杠杆撤单返回的clientOrderId是本次撤单请求的id
+		if orderResponse.OrigClientOrderId != "" {
+			info.ClientId = orderResponse.OrigClientOrderId
+		}
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+func (client *RestClient) CancelCMOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0)
+	for _, order := range orders {
+		param := cmParam(formCancelRequest(order), order.Symbol)
+
+		uri := CMOrderUri
+		body, res, err := client.HttpRequest(http.MethodDelete, uri, param)
+		if err != nil {
+			log.Errorf("binance DELETE /papi/v1/cm/order err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance DELETE /papi/v1/cm/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderErrTransform(order, string(body)))
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Infof("binance DELETE /papi/v1/cm/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
 			continue
 		}
 
@@ -51,8 +122,11 @@ func orderCancelTransform(symbol string, info *OrderResponse) *types.OrderResult
 func formCancelRequest(order *types.Order) map[string]interface{} {
 	result := map[string]interface{}{
 		"symbol": Symbol2Binance(order.Symbol),
-		// "orderId":           order.OrderID,
-		"origClientOrderId": order.ClientID,
+	}
+	if order.ClientID != "" {
+		result["origClientOrderId"] = order.ClientID
+	} else {
+		result["orderId"] = order.OrderID
 	}
 	return result
 }
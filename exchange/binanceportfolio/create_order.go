@@ -11,6 +11,7 @@ import (
 
 type OrderResponse struct {
 	ClientOrderId           string `json:"clientOrderId"`
+	OrigClientOrderId       string `json:"origClientOrderId"` // 杠杆撤单时返回原始的clientOrderId
 	CumQty                  string `json:"cumQty"`
 	CumQuote                string `json:"cumQuote"`
 	ExecutedQty             string `json:"executedQty"`
@@ -119,3 +120,36 @@ func (client *RestClient) CreateMMOrders(orders []*types.Order) ([]*types.OrderR
 
 	return result, nil
 }
+
+// CreateCMOrders 币本位合约下单，数量单位为张
+func (client *RestClient) CreateCMOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0)
+	for _, order := range orders {
+		param := cmParam(formRequest(order), order.Symbol)
+
+		uri := CMOrderUri
+		body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+		if err != nil {
+			log.Errorf("binance post /papi/v1/cm/order err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance post /papi/v1/cm/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderErrTransform(order, string(body)))
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Infof("binance post /papi/v1/cm/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, err.Error()))
+			continue
+		}
+
+		info := orderTransform(order.Symbol, &orderResponse)
+		result = append(result, info)
+	}
+
+	return result, nil
+}
@@ -2,8 +2,10 @@ package binanceportfolio
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/exchange/binancecfutures"
 	"github.com/cybernonce/gotrader/exchange/binancespot"
 	"github.com/cybernonce/gotrader/exchange/binanceufutures"
 	"github.com/cybernonce/gotrader/pkg/ws"
@@ -17,59 +19,103 @@ type BinancePortfolioExchange struct {
 
 	mmRestClient *binancespot.RestClient
 	umRestClient *binanceufutures.RestClient
+	cmRestClient *binancecfutures.RestClient
 
 	restClient  *RestClient
 	pubWsClient *ws.WsClient
 	priWsClient *ws.WsClient
 
+	// 私有连接当前使用的listenKey，每次连接前重新申请
+	listenKey atomic.Value
+
 	// callbacks
 	onBooktickerCallback func(*types.BookTicker)
+	onTradeCallback      func([]*types.Trade)
+	onOrderBookCallback  func(*types.OrderBook)
 	onOrderCallback      func([]*types.Order)
+	onBalanceCallback    func(*types.Assets)
+	onPositionCallback   func([]*types.Position)
+	onRiskLevelCallback  func(*types.RiskLevel)
+
+	onBalanceUpdateCallback func(*types.BalanceUpdate)
 }
 
 func NewBinancePortfoli(params *types.ExchangeParameters) *BinancePortfolioExchange {
 	apiKey := params.AccessKey
 	secretKey := params.SecretKey
 	passPhrase := params.Passphrase
+	endpoints := base.MustResolveEndpoints(params, Environments)
+	transport := base.MustNewTransport(params)
 
 	// new client
 	client := NewRestClient(apiKey, secretKey, passPhrase, constant.BinancePortfolio)
+	client.SetEndpoints(endpoints)
+	client.SetHttpClient(transport.HttpClient)
 	mmRestClient := binancespot.NewRestClient(apiKey, secretKey, passPhrase, constant.BinanceSpot)
 	umRestClient := binanceufutures.NewRestClient(apiKey, secretKey, passPhrase, constant.BinanceSpot)
+	cmRestClient := binancecfutures.NewRestClient(apiKey, secretKey, passPhrase, constant.BinanceCFutures)
+	// 行情等公共接口走各市场自己的地址
+	mmRestClient.SetEndpoints(marketEndpoints(params, binancespot.Environments))
+	umRestClient.SetEndpoints(marketEndpoints(params, binanceufutures.Environments))
+	cmRestClient.SetEndpoints(marketEndpoints(params, binancecfutures.Environments))
+	mmRestClient.SetHttpClient(transport.HttpClient)
+	umRestClient.SetHttpClient(transport.HttpClient)
+	cmRestClient.SetHttpClient(transport.HttpClient)
 	exchange := &BinancePortfolioExchange{
 		exchangeType: constant.BinancePortfolio,
 		marketType:   params.MarketType,
 		restClient:   client,
 		mmRestClient: mmRestClient,
 		umRestClient: umRestClient,
+		cmRestClient: cmRestClient,
 	}
-	// pubWsClient
-	// pubWsClient := NewOkPubWsClient(exchange.OnPubWsHandle)
-	// if err := pubWsClient.Dial(ws.Connect); err != nil {
-	// 	log.Errorf("pubWsClient.Dial err %s", err)
-	// } else {
-	// 	exchange.pubWsClient = pubWsClient
-	// 	log.Infof("pubWsClient.Dial success")
-	// }
-	// // priWsClient
+	// pubWsClient 统一账户没有单独的行情，按市场类型复用合约或现货的行情连接
+	var pubWsClient *ws.WsClient
+	switch exchange.marketType {
+	case UMExchange:
+		pubWsClient = binanceufutures.NewBinanceUFuturesPubWsClient(pubWsUrl(endpoints, binanceufutures.PubWsUrl), exchange.OnPubWsHandle)
+	case MMExchange:
+		pubWsClient = binancespot.NewBinanceSpotPubWsClient(pubWsUrl(endpoints, binancespot.PubWsUrl), exchange.OnPubWsHandle)
+	case CMExchange:
+		pubWsClient = binancecfutures.NewBinanceCFuturesPubWsClient(pubWsUrl(endpoints, binancecfutures.PubWsUrl), exchange.OnPubWsHandle)
+	}
+	if pubWsClient != nil {
+		pubWsClient.SetDialer(transport.WsDialer)
+		if err := pubWsClient.Dial(ws.Connect); err != nil {
+			log.Errorf("pubWsClient.Dial err %s", err)
+		} else {
+			exchange.pubWsClient = pubWsClient
+			log.Infof("pubWsClient.Dial success")
+		}
+	}
+	// priWsClient
 	if len(apiKey) > 0 {
-		listenKey, err := exchange.GetListenKey()
-		if err != nil {
-			log.Errorf("GetListenKey err %s", err)
+		priWsClient := NewBinancePriWsClient(endpoints.PriWsUrl, apiKey, secretKey, passPhrase, exchange.newListenKey, exchange.OnPriWsHandle)
+		priWsClient.SetDialer(transport.WsDialer)
+		if err := priWsClient.Dial(ws.Connect); err != nil {
+			log.Errorf("priWsClient.Dial err %s", err)
 		} else {
-			priWsClient := NewBinancePriWsClient(apiKey, secretKey, passPhrase, listenKey, exchange.OnPriWsHandle)
-			if err := priWsClient.Dial(ws.Connect); err != nil {
-				log.Errorf("priWsClient.Dial err %s", err)
-			} else {
-				exchange.priWsClient = priWsClient
-				log.Infof("priWsClient.Dial success")
-			}
-			exchange.KeepUserStream(listenKey)
+			exchange.priWsClient = priWsClient
+			log.Infof("priWsClient.Dial success")
+			exchange.KeepUserStream()
 		}
 	}
 	return exchange
 }
 
+// marketEndpoints 子市场只按环境选择预设地址，自定义的RestUrl是papi地址，不能用于现货和合约的公共接口
+func marketEndpoints(params *types.ExchangeParameters, presets map[constant.Environment]base.Endpoints) base.Endpoints {
+	return base.MustResolveEndpoints(&types.ExchangeParameters{Environment: params.Environment}, presets)
+}
+
+// pubWsUrl 没有自定义公共行情地址时使用市场类型对应的默认地址
+func pubWsUrl(endpoints base.Endpoints, defaultUrl string) string {
+	if endpoints.PubWsUrl != "" {
+		return endpoints.PubWsUrl
+	}
+	return defaultUrl
+}
+
 func (binance *BinancePortfolioExchange) GetName() (name string) {
 	return binance.exchangeType.Name()
 
@@ -83,8 +129,29 @@ func (binance *BinancePortfolioExchange) GetListenKey() (string, error) {
 	return binance.restClient.GetListenKey()
 }
 
-func (binance *BinancePortfolioExchange) KeepUserStream(listenKey string) {
-	go binance.restClient.KeepUserStream(listenKey)
+// newListenKey 申请新的listenKey并记录下来用于定时延长
+func (binance *BinancePortfolioExchange) newListenKey() (string, error) {
+	listenKey, err := binance.GetListenKey()
+	if err != nil {
+		return "", err
+	}
+	binance.listenKey.Store(listenKey)
+	return listenKey, nil
+}
+
+func (binance *BinancePortfolioExchange) currentListenKey() string {
+	listenKey, _ := binance.listenKey.Load().(string)
+	return listenKey
+}
+
+// KeepUserStream 定时延长listenKey，延长失败时重连私有连接，重连会申请新的listenKey
+func (binance *BinancePortfolioExchange) KeepUserStream() {
+	go binance.restClient.KeepUserStream(binance.currentListenKey, binance.priWsClient.Reconnect)
+}
+
+// StopUserStream 停止定时延长listenKey
+func (binance *BinancePortfolioExchange) StopUserStream() {
+	binance.restClient.StopUserStream()
 }
 
 func (binance *BinancePortfolioExchange) AutoCollection() (string, error) {
@@ -98,6 +165,9 @@ func (binance *BinancePortfolioExchange) FetchSymbols() ([]*types.SymbolInfo, er
 	if binance.marketType == MMExchange {
 		return binance.mmRestClient.FetchSymbols()
 	}
+	if binance.marketType == CMExchange {
+		return binance.cmRestClient.FetchSymbols()
+	}
 	return nil, fmt.Errorf("not impl")
 }
 
@@ -108,6 +178,45 @@ func (binance *BinancePortfolioExchange) FetchAssetBalance() (*types.Assets, err
 	return nil, fmt.Errorf("FetchAsseteBalance not imp")
 }
 
+func (binance *BinancePortfolioExchange) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	if binance.marketType == UMExchange {
+		return binance.restClient.FetchUMOpenOrders(symbol)
+	}
+	if binance.marketType == MMExchange {
+		return binance.restClient.FetchMMOpenOrders(symbol)
+	}
+	if binance.marketType == CMExchange {
+		return binance.restClient.FetchCMOpenOrders(symbol)
+	}
+	return nil, fmt.Errorf("not imp")
+}
+
+func (binance *BinancePortfolioExchange) FetchOrder(order *types.Order) (*types.Order, error) {
+	if binance.marketType == UMExchange {
+		return binance.restClient.FetchUMOrder(order)
+	}
+	if binance.marketType == MMExchange {
+		return binance.restClient.FetchMMOrder(order)
+	}
+	if binance.marketType == CMExchange {
+		return binance.restClient.FetchCMOrder(order)
+	}
+	return nil, fmt.Errorf("not imp")
+}
+
+func (binance *BinancePortfolioExchange) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	if binance.marketType == UMExchange {
+		return binance.restClient.FetchUMUserTrades(param)
+	}
+	if binance.marketType == MMExchange {
+		return binance.restClient.FetchMMUserTrades(param)
+	}
+	if binance.marketType == CMExchange {
+		return binance.restClient.FetchCMUserTrades(param)
+	}
+	return nil, fmt.Errorf("not imp")
+}
+
 func (binance *BinancePortfolioExchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
 	if binance.marketType == UMExchange {
 		return binance.restClient.CreateUMOrders(orders)
@@ -115,13 +224,35 @@ func (binance *BinancePortfolioExchange) CreateBatchOrders(orders []*types.Order
 	if binance.marketType == MMExchange {
 		return binance.restClient.CreateMMOrders(orders)
 	}
+	if binance.marketType == CMExchange {
+		return binance.restClient.CreateCMOrders(orders)
+	}
 	return nil, fmt.Errorf("not imp")
 }
 
 func (binance *BinancePortfolioExchange) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
 	if binance.marketType == UMExchange {
 		return binance.restClient.CancelUMOrders(orders)
+	}
+	if binance.marketType == MMExchange {
+		return binance.restClient.CancelMMOrders(orders)
+	}
+	if binance.marketType == CMExchange {
+		return binance.restClient.CancelCMOrders(orders)
+	}
+	return nil, fmt.Errorf("not imp")
+}
 
+// AmendBatchOrders 杠杆账户没有改单接口，撤单后重新下单
+func (binance *BinancePortfolioExchange) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	if binance.marketType == UMExchange {
+		return binance.restClient.AmendUMOrders(orders)
+	}
+	if binance.marketType == MMExchange {
+		return base.CancelReplace(orders, binance.restClient.CancelMMOrders, binance.restClient.FetchMMOrder, binance.restClient.CreateMMOrders)
+	}
+	if binance.marketType == CMExchange {
+		return binance.restClient.AmendCMOrders(orders)
 	}
 	return nil, fmt.Errorf("not imp")
 }
@@ -130,6 +261,9 @@ func (binance *BinancePortfolioExchange) FetchTickers() ([]*types.Ticker, error)
 	if binance.marketType == UMExchange {
 		return binance.umRestClient.FetchTickers()
 	}
+	if binance.marketType == CMExchange {
+		return binance.cmRestClient.FetchTickers()
+	}
 	return nil, fmt.Errorf("not impl")
 }
 
@@ -137,21 +271,79 @@ func (binance *BinancePortfolioExchange) FetchKline(symbol string, interval stri
 	if binance.marketType == MMExchange {
 		return binance.mmRestClient.FetchKline(symbol, interval, limit)
 	}
+	if binance.marketType == UMExchange {
+		return binance.umRestClient.FetchKline(symbol, interval, limit)
+	}
+	if binance.marketType == CMExchange {
+		return binance.cmRestClient.FetchKline(symbol, interval, limit)
+	}
+	return nil, fmt.Errorf("not impl")
+}
+
+func (binance *BinancePortfolioExchange) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	if binance.marketType == MMExchange {
+		return binance.mmRestClient.FetchHistoryKline(param)
+	}
+	if binance.marketType == UMExchange {
+		return binance.umRestClient.FetchHistoryKline(param)
+	}
+	if binance.marketType == CMExchange {
+		return binance.cmRestClient.FetchHistoryKline(param)
+	}
 	return nil, fmt.Errorf("not impl")
 }
 
 func (binance *BinancePortfolioExchange) FetchFundingRate(symbol string) (*types.FundingRate, error) {
+	if binance.marketType == UMExchange {
+		return binance.umRestClient.FetchFundingRate(symbol)
+	}
+	if binance.marketType == CMExchange {
+		return binance.cmRestClient.FetchFundingRate(symbol)
+	}
 	return nil, fmt.Errorf("not impl")
 }
 
 func (binance *BinancePortfolioExchange) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
+	if binance.marketType == UMExchange {
+		return binance.umRestClient.FetchFundingRateHistory(symbol, limit)
+	}
+	if binance.marketType == CMExchange {
+		return binance.cmRestClient.FetchFundingRateHistory(symbol, limit)
+	}
 	return nil, fmt.Errorf("not impl")
 }
 
 func (binance *BinancePortfolioExchange) FetchPositons() ([]*types.Position, error) {
+	if binance.marketType == CMExchange {
+		return binance.restClient.FetchCMPositions()
+	}
 	return binance.restClient.FetchPositons()
 }
 
+// SetLeverage 统一账户只支持全仓
+func (binance *BinancePortfolioExchange) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	if binance.marketType != UMExchange && binance.marketType != CMExchange {
+		return fmt.Errorf("not impl")
+	}
+	if marginMode != constant.MarginCross {
+		return fmt.Errorf("binance portfolio not support margin mode %s", marginMode.Name())
+	}
+	if binance.marketType == CMExchange {
+		return binance.restClient.SetCMLeverage(symbol, leverage)
+	}
+	return binance.restClient.SetUMLeverage(symbol, leverage)
+}
+
+func (binance *BinancePortfolioExchange) SetPositionMode(mode constant.PositionMode) error {
+	if binance.marketType == CMExchange {
+		return binance.restClient.SetCMPositionMode(mode)
+	}
+	if binance.marketType != UMExchange {
+		return fmt.Errorf("not impl")
+	}
+	return binance.restClient.SetUMPositionMode(mode)
+}
+
 func (binance *BinancePortfolioExchange) PrivateTransfer(transfer base.TransferParam) (string, error) {
 	return "", fmt.Errorf("PrivateTransfer not imp")
 }
@@ -167,15 +359,98 @@ func (binance *BinancePortfolioExchange) Subscribe(params map[string]interface{}
 }
 
 func (binance *BinancePortfolioExchange) SubscribeBookTicker(symbols []string, callback func(*types.BookTicker)) (err error) {
+	binance.onBooktickerCallback = callback
+	return binance.subscribeStreams(symbols, "bookTicker")
+}
+
+// SubscribeTrades 订阅归集成交
+func (binance *BinancePortfolioExchange) SubscribeTrades(symbols []string, callback func([]*types.Trade)) (err error) {
+	binance.onTradeCallback = callback
+	return binance.subscribeStreams(symbols, "aggTrade")
+}
 
-	return fmt.Errorf("not impl")
+// SubscribeOrderBook 订阅20档有限深度，每次推送完整的20档
+func (binance *BinancePortfolioExchange) SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) (err error) {
+	binance.onOrderBookCallback = callback
+	return binance.subscribeStreams(symbols, DepthStream)
 }
 
+// subscribeStreams 按交易对逐个订阅 <symbol>@<stream>，断线重连后自动重新订阅
+func (binance *BinancePortfolioExchange) subscribeStreams(symbols []string, stream string) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	for _, symbol := range symbols {
+		binance.pubWsClient.Subscribe(symbol, stream)
+	}
+	return nil
+}
+
+// SubscribeOrders 统一账户推送UM、CM和杠杆全部订单，通过Order.MarketType区分
 func (binance *BinancePortfolioExchange) SubscribeOrders(symbols []string, callback func(orders []*types.Order)) error {
 	binance.onOrderCallback = callback
 	return nil
 }
 
+func (binance *BinancePortfolioExchange) SubscribeBalance(callback func(*types.Assets)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onBalanceCallback = callback
+	return nil
+}
+
+func (binance *BinancePortfolioExchange) SubscribePositions(callback func([]*types.Position)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onPositionCallback = callback
+	return nil
+}
+
+// SubscribeRiskLevel 统一账户风险等级变化(如MARGIN_CALL)推送
+func (binance *BinancePortfolioExchange) SubscribeRiskLevel(callback func(*types.RiskLevel)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onRiskLevelCallback = callback
+	return nil
+}
+
+// SubscribeBalanceUpdate 杠杆账户充提、划转的余额变化量推送
+func (binance *BinancePortfolioExchange) SubscribeBalanceUpdate(callback func(*types.BalanceUpdate)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onBalanceUpdateCallback = callback
+	return nil
+}
+
+func (binance *BinancePortfolioExchange) OnPubWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case *types.BookTicker:
+		if binance.onBooktickerCallback != nil {
+			binance.onBooktickerCallback(v)
+		} else {
+			log.Errorf("OnBookTicker Callback not set")
+		}
+	case *types.OrderBook:
+		if binance.onOrderBookCallback != nil {
+			binance.onOrderBookCallback(v)
+		} else {
+			log.Errorf("onOrderBook Callback not set")
+		}
+	case []*types.Trade:
+		if binance.onTradeCallback != nil {
+			binance.onTradeCallback(v)
+		} else {
+			log.Errorf("onTrade Callback not set")
+		}
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
+
 func (binance *BinancePortfolioExchange) OnPriWsHandle(data interface{}) {
 	switch v := data.(type) {
 	case []*types.Order:
@@ -184,6 +459,24 @@ func (binance *BinancePortfolioExchange) OnPriWsHandle(data interface{}) {
 		} else {
 			log.Errorf("onOrder Callback not set")
 		}
+	case *types.Assets:
+		if binance.onBalanceCallback != nil {
+			binance.onBalanceCallback(v)
+		}
+	case []*types.Position:
+		if binance.onPositionCallback != nil {
+			binance.onPositionCallback(v)
+		}
+	case *types.RiskLevel:
+		if binance.onRiskLevelCallback != nil {
+			binance.onRiskLevelCallback(v)
+		} else {
+			log.Errorf("onRiskLevel Callback not set")
+		}
+	case *types.BalanceUpdate:
+		if binance.onBalanceUpdateCallback != nil {
+			binance.onBalanceUpdateCallback(v)
+		}
 	default:
 		log.Errorf("Unknown type %s", v)
 	}
@@ -0,0 +1,17 @@
+package binanceportfolio
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/exchange/binanceufutures"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestMarketEndpoints(t *testing.T) {
+	params := &types.ExchangeParameters{Environment: constant.EnvTestnet, RestUrl: "https://papi.example.com"}
+	endpoints := marketEndpoints(params, binanceufutures.Environments)
+	if endpoints.RestUrl != binanceufutures.Environments[constant.EnvTestnet].RestUrl {
+		t.Fatalf("papi RestUrl should not be forwarded to um client, got %s", endpoints.RestUrl)
+	}
+}
@@ -2,6 +2,7 @@ package binanceportfolio
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -16,11 +17,14 @@ type ListenKeyResponse struct {
 
 func (client *RestClient) GetListenKey() (string, error) {
 	url := FetchListenKey
-	body, _, err := client.HttpRequest(http.MethodPost, url, nil)
+	body, res, err := client.HttpRequest(http.MethodPost, url, nil)
 	if err != nil {
 		log.Errorf("binance FetchListenKey 网络错误:%v", err)
 		return "", err
 	}
+	if res.StatusCode != 200 {
+		return "", fmt.Errorf("binance post /papi/v1/listenKey err: %v %s", res.StatusCode, body)
+	}
 
 	var response ListenKeyResponse
 	if err = json.Unmarshal(body, &response); err != nil {
@@ -35,27 +39,42 @@ func (client *RestClient) RefreshListenKey(key string) error {
 	url := FetchListenKey
 	param := make(map[string]interface{})
 	param["listenKey"] = key
-	_, _, err := client.HttpRequest(http.MethodPut, url, param)
+	body, res, err := client.HttpRequest(http.MethodPut, url, param)
 	if err != nil {
 		log.Errorf("RefreshListenKey err: %s", err)
 		return err
 	}
+	if res.StatusCode != 200 {
+		err := fmt.Errorf("binance put /papi/v1/listenKey err: %v %s", res.StatusCode, body)
+		log.Errorf("RefreshListenKey err: %s", err)
+		return err
+	}
 	log.Infof("RefreshListenKey success: %s", key)
 
 	return nil
 }
 
-func (client *RestClient) KeepUserStream(key string) {
-	timer := time.NewTimer(30 * time.Minute) // 创建计时器一次
+// KeepUserStream listenKey有效期60分钟，定时延长当前的listenKey，延长失败时调用onFailed
+func (client *RestClient) KeepUserStream(listenKey func() string, onFailed func()) {
+	timer := time.NewTimer(KeepLiveTime)
 
 	defer timer.Stop()
 	for {
 		select {
 		case <-timer.C:
-			client.RefreshListenKey(key)
-			timer.Reset(30 * time.Minute)
-		case <-client.stopChan: // 假设有一个stopChan用于接收退出信号
+			if err := client.RefreshListenKey(listenKey()); err != nil {
+				onFailed()
+			}
+			timer.Reset(KeepLiveTime)
+		case <-client.stopChan:
 			return
 		}
 	}
 }
+
+// StopUserStream 停止定时延长listenKey，可以重复调用
+func (client *RestClient) StopUserStream() {
+	client.stopOnce.Do(func() {
+		close(client.stopChan)
+	})
+}
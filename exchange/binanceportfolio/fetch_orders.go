@@ -0,0 +1,153 @@
+package binanceportfolio
+
+import (
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/exchange/binancecfutures"
+	"github.com/cybernonce/gotrader/exchange/binancespot"
+	"github.com/cybernonce/gotrader/exchange/binanceufutures"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 单次请求最多返回的成交数量
+const maxUserTradeLimit = 1000
+
+// FetchUMOpenOrders 查询U本位合约当前挂单，返回结构与U本位合约一致
+func (client *RestClient) FetchUMOpenOrders(symbol string) ([]*types.Order, error) {
+	var response []*binanceufutures.OrderInfo
+	if err := base.GetJson(client, UMOpenOrdersUri, openOrdersParam(symbol), &response); err != nil {
+		return nil, err
+	}
+	result := make([]*types.Order, 0, len(response))
+	for _, info := range response {
+		result = append(result, umOrder(info.ToOrder()))
+	}
+	return result, nil
+}
+
+// FetchMMOpenOrders 查询杠杆当前挂单，返回结构与现货一致
+func (client *RestClient) FetchMMOpenOrders(symbol string) ([]*types.Order, error) {
+	var response []*binancespot.OrderInfo
+	if err := base.GetJson(client, MMOpenOrdersUri, openOrdersParam(symbol), &response); err != nil {
+		return nil, err
+	}
+	result := make([]*types.Order, 0, len(response))
+	for _, info := range response {
+		result = append(result, mmOrder(info.ToOrder()))
+	}
+	return result, nil
+}
+
+// FetchCMOpenOrders 查询币本位合约当前挂单，返回结构与币本位合约一致
+func (client *RestClient) FetchCMOpenOrders(symbol string) ([]*types.Order, error) {
+	var response []*binancecfutures.OrderInfo
+	if err := base.GetJson(client, CMOpenOrdersUri, cmParam(openOrdersParam(""), symbol), &response); err != nil {
+		return nil, err
+	}
+	result := make([]*types.Order, 0, len(response))
+	for _, info := range response {
+		result = append(result, cmOrder(info.ToOrder()))
+	}
+	return result, nil
+}
+
+func (client *RestClient) FetchUMOrder(order *types.Order) (*types.Order, error) {
+	var response binanceufutures.OrderInfo
+	if err := base.GetJson(client, UMOrderUri, formCancelRequest(order), &response); err != nil {
+		return nil, err
+	}
+	return umOrder(response.ToOrder()), nil
+}
+
+func (client *RestClient) FetchMMOrder(order *types.Order) (*types.Order, error) {
+	var response binancespot.OrderInfo
+	if err := base.GetJson(client, MMOrderUri, formCancelRequest(order), &response); err != nil {
+		return nil, err
+	}
+	return mmOrder(response.ToOrder()), nil
+}
+
+func (client *RestClient) FetchCMOrder(order *types.Order) (*types.Order, error) {
+	var response binancecfutures.OrderInfo
+	if err := base.GetJson(client, CMOrderUri, cmParam(formCancelRequest(order), order.Symbol), &response); err != nil {
+		return nil, err
+	}
+	return cmOrder(response.ToOrder()), nil
+}
+
+// FetchUMUserTrades 查询U本位合约成交明细，symbol必填
+func (client *RestClient) FetchUMUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return base.PageUserTrades(Symbol2Binance(param.Symbol), param, maxUserTradeLimit, func(query map[string]interface{}) ([]*types.Fill, error) {
+		var response []*binanceufutures.UserTrade
+		if err := base.GetJson(client, UMUserTradesUri, query, &response); err != nil {
+			return nil, err
+		}
+		result := make([]*types.Fill, 0, len(response))
+		for _, item := range response {
+			fill := item.ToFill()
+			fill.Exchange = constant.BinancePortfolio
+			result = append(result, fill)
+		}
+		return result, nil
+	})
+}
+
+// FetchMMUserTrades 查询杠杆成交明细，symbol必填
+func (client *RestClient) FetchMMUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return base.PageUserTrades(Symbol2Binance(param.Symbol), param, maxUserTradeLimit, func(query map[string]interface{}) ([]*types.Fill, error) {
+		var response []*binancespot.UserTrade
+		if err := base.GetJson(client, MMUserTradesUri, query, &response); err != nil {
+			return nil, err
+		}
+		result := make([]*types.Fill, 0, len(response))
+		for _, item := range response {
+			fill := item.ToFill()
+			fill.Exchange = constant.BinancePortfolio
+			result = append(result, fill)
+		}
+		return result, nil
+	})
+}
+
+// FetchCMUserTrades 查询币本位合约成交明细，symbol必填，数量单位为张
+func (client *RestClient) FetchCMUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return base.PageUserTrades(binancecfutures.Symbol2Binance(param.Symbol), param, maxUserTradeLimit, func(query map[string]interface{}) ([]*types.Fill, error) {
+		var response []*binancecfutures.UserTrade
+		if err := base.GetJson(client, CMUserTradesUri, query, &response); err != nil {
+			return nil, err
+		}
+		result := make([]*types.Fill, 0, len(response))
+		for _, item := range response {
+			fill := item.ToFill()
+			fill.Exchange = constant.BinancePortfolio
+			result = append(result, fill)
+		}
+		return result, nil
+	})
+}
+
+func openOrdersParam(symbol string) map[string]interface{} {
+	param := map[string]interface{}{}
+	if symbol != "" {
+		param["symbol"] = Symbol2Binance(symbol)
+	}
+	return param
+}
+
+func umOrder(order *types.Order) *types.Order {
+	order.Exchange = constant.BinancePortfolio
+	order.MarketType = UMExchange
+	return order
+}
+
+func mmOrder(order *types.Order) *types.Order {
+	order.Exchange = constant.BinancePortfolio
+	order.MarketType = MMExchange
+	return order
+}
+
+func cmOrder(order *types.Order) *types.Order {
+	order.Exchange = constant.BinancePortfolio
+	order.MarketType = CMExchange
+	return order
+}
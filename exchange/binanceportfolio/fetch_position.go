@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/exchange/binancecfutures"
 	"github.com/cybernonce/gotrader/trader/constant"
 	"github.com/cybernonce/gotrader/trader/types"
 )
@@ -49,6 +51,22 @@ func (client *RestClient) FetchPositons() ([]*types.Position, error) {
 	return result, nil
 }
 
+// FetchCMPositions 查询币本位合约持仓，数量单位为张
+func (client *RestClient) FetchCMPositions() ([]*types.Position, error) {
+	var positions []*binancecfutures.PositionInfo
+	if err := base.GetJson(client, CMPositionsUri, map[string]interface{}{}, &positions); err != nil {
+		return nil, err
+	}
+	result := make([]*types.Position, 0, len(positions))
+	for _, item := range positions {
+		if item.PositionAmt == 0 {
+			continue
+		}
+		result = append(result, item.ToPosition())
+	}
+	return result, nil
+}
+
 func positionTransform(response []PositionInfo) ([]*types.Position, error) {
 	result := make([]*types.Position, 0, len(response))
 	for _, item := range response {
@@ -68,13 +86,17 @@ func positionTransform(response []PositionInfo) ([]*types.Position, error) {
 	return result, nil
 }
 
+// getPositionSide 单向持仓按数量正负判断方向，持仓为0时为空表示已平仓
 func getPositionSide(side string, pos float64) string {
 	switch side {
 	case "BOTH":
 		if pos > 0 {
 			return constant.Long.Name()
 		}
-		return constant.Short.Name()
+		if pos < 0 {
+			return constant.Short.Name()
+		}
+		return ""
 	case "LONG":
 		return constant.Long.Name()
 	default:
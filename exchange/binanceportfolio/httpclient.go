@@ -3,8 +3,10 @@ package binanceportfolio
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/cybernonce/gotrader/exchange/base"
 	"github.com/cybernonce/gotrader/pkg/httpx"
 	"github.com/cybernonce/gotrader/pkg/utils"
 	"github.com/cybernonce/gotrader/trader/constant"
@@ -18,6 +20,9 @@ type RestClient struct {
 	passPhrase   string
 	exchangeType constant.ExchangeType
 	stopChan     chan struct{}
+	stopOnce     sync.Once
+	restUrl      string
+	httpClient   httpx.Client
 }
 
 type BaseOkRsp struct {
@@ -32,9 +37,22 @@ func NewRestClient(apiKey, secretKey, passPhrase string, exchangeType constant.E
 		passPhrase:   passPhrase,
 		exchangeType: exchangeType,
 		stopChan:     make(chan struct{}),
+		restUrl:      RestUrl,
+		httpClient:   httpClient,
 	}
 	return client
 }
+
+// SetEndpoints 使用指定环境的rest地址
+func (client *RestClient) SetEndpoints(endpoints base.Endpoints) {
+	client.restUrl = endpoints.RestUrl
+}
+
+// SetHttpClient 使用交易所实例独立的http客户端(代理、超时、出口IP)
+func (client *RestClient) SetHttpClient(httpClient httpx.Client) {
+	client.httpClient = httpClient
+}
+
 func (client *RestClient) HttpRequest(method string, uri string, param map[string]interface{}) ([]byte, *http.Response, error) {
 	if param == nil {
 		param = make(map[string]interface{}, 1)
@@ -45,13 +63,13 @@ func (client *RestClient) HttpRequest(method string, uri string, param map[strin
 	param["timestamp"] = time.Now().UnixMilli() - 1000
 	toSignStr := utils.UrlEncodeParams(param)
 	signature := utils.GenHexDigest(utils.HmacSha256(toSignStr, client.secretKey))
-	url := fmt.Sprintf("%s%s?%s&signature=%s", RestUrl, uri, toSignStr, signature)
+	url := fmt.Sprintf("%s%s?%s&signature=%s", client.restUrl, uri, toSignStr, signature)
 	args := &httpx.Request{
 		Url:    url,
 		Head:   header,
 		Method: method,
 	}
-	body, res, err := httpClient.Request(args)
+	body, res, err := client.httpClient.Request(args)
 	if err != nil {
 		return nil, res, err
 	}
@@ -59,7 +77,7 @@ func (client *RestClient) HttpRequest(method string, uri string, param map[strin
 }
 
 func (client *RestClient) HttpGet(url string) ([]byte, *http.Response, error) {
-	body, res, err := httpClient.Get(url)
+	body, res, err := client.httpClient.Get(url)
 	if err != nil {
 		return nil, res, err
 	}
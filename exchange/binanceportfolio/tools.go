@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/exchange/binancecfutures"
 	"github.com/cybernonce/gotrader/trader/constant"
 )
 
@@ -12,8 +14,20 @@ var (
 	PubWsUrl = "wss://fstream.binance.com/stream"
 	PriWsUrl = "wss://fstream.binance.com/pm/ws/"
 
+	// Environments 统一账户没有测试网，公共行情地址为空时按市场类型使用对应合约或现货的地址
+	Environments = map[constant.Environment]base.Endpoints{
+		constant.EnvProduction: {
+			RestUrl:  RestUrl,
+			PriWsUrl: PriWsUrl,
+		},
+	}
+
+	// 有限档深度频道
+	DepthStream = "depth20@100ms"
+
 	UMExchange = "UM"
 	MMExchange = "MM"
+	CMExchange = "CM"
 
 	BinanceOrderSide = map[string]string{
 		constant.OrderBuy.Name():   "BUY",
@@ -58,7 +72,7 @@ var (
 		"PARTIALLY_FILLED": constant.OrderPartialFilled,
 		"CANCELED":         constant.OrderCanceled,
 		"FILLED":           constant.OrderFilled,
-		"REJECTED":         constant.OrderFilled,
+		"REJECTED":         constant.OrderRejected,
 		"EXPIRED":          constant.OrderCanceled,
 	}
 )
@@ -71,6 +85,22 @@ const (
 	CreateOrderUri    = "/papi/v1/um/order"
 	CreateMMOrderUri  = "/papi/v1/margin/order"
 	CancelUMOrderUri  = "/papi/v1/um/order"
+	CancelMMOrderUri  = "/papi/v1/margin/order"
+	AmendUMOrderUri   = "/papi/v1/um/order"
+	UMLeverageUri     = "/papi/v1/um/leverage"
+	UMPositionSideUri = "/papi/v1/um/positionSide/dual"
+	UMOpenOrdersUri   = "/papi/v1/um/openOrders"
+	MMOpenOrdersUri   = "/papi/v1/margin/openOrders"
+	UMOrderUri        = "/papi/v1/um/order"
+	MMOrderUri        = "/papi/v1/margin/order"
+	UMUserTradesUri   = "/papi/v1/um/userTrades"
+	MMUserTradesUri   = "/papi/v1/margin/myTrades"
+	CMOrderUri        = "/papi/v1/cm/order"
+	CMOpenOrdersUri   = "/papi/v1/cm/openOrders"
+	CMUserTradesUri   = "/papi/v1/cm/userTrades"
+	CMPositionsUri    = "/papi/v1/cm/positionRisk"
+	CMLeverageUri     = "/papi/v1/cm/leverage"
+	CMPositionSideUri = "/papi/v1/cm/positionSide/dual"
 )
 
 func Symbol2Binance(symbol string) string {
@@ -84,3 +114,11 @@ func Symbol2BinanceWsInstId(symbol string) string {
 	}
 	panic("bad symbol:" + symbol)
 }
+
+// cmParam 币本位合约交易对格式为 BTC_USD_SWAP/BTC_USD_240628，替换请求中的symbol
+func cmParam(param map[string]interface{}, symbol string) map[string]interface{} {
+	if symbol != "" {
+		param["symbol"] = binancecfutures.Symbol2Binance(symbol)
+	}
+	return param
+}
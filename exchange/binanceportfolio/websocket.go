@@ -1,15 +1,18 @@
 package binanceportfolio
 
 import (
-	"encoding/json"
 	"strings"
 	"time"
 
-	"github.com/gorilla/websocket"
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/binancecfutures"
+	"github.com/cybernonce/gotrader/exchange/binancespot"
+	"github.com/cybernonce/gotrader/exchange/binanceufutures"
 	"github.com/cybernonce/gotrader/pkg/utils"
 	"github.com/cybernonce/gotrader/pkg/ws"
 	"github.com/cybernonce/gotrader/trader/constant"
 	"github.com/cybernonce/gotrader/trader/types"
+	"github.com/gorilla/websocket"
 )
 
 type BinanceImp struct {
@@ -21,7 +24,8 @@ type BinanceImp struct {
 	rspHandle  func(interface{})
 }
 
-func NewBinancePriWsClient(accessKey, secretKey, passphrase, listenKey string, rspHandle func(interface{})) *ws.WsClient {
+// NewBinancePriWsClient 每次连接(包括重连)前通过listenKey重新申请，申请失败时地址为空，Dial失败后重试
+func NewBinancePriWsClient(url, accessKey, secretKey, passphrase string, listenKey func() (string, error), rspHandle func(interface{})) *ws.WsClient {
 	imp := &BinanceImp{
 		accessKey:  accessKey,
 		secretKey:  secretKey,
@@ -29,8 +33,15 @@ func NewBinancePriWsClient(accessKey, secretKey, passphrase, listenKey string, r
 		rspHandle:  rspHandle,
 		isPrivate:  true,
 	}
-	url := PriWsUrl + listenKey
 	client := ws.NewWsClient(url, imp, constant.BinancePortfolio, 20*time.Second, 30*time.Second)
+	client.SetUrlFunc(func() string {
+		key, err := listenKey()
+		if err != nil {
+			log.Errorf("binance portfolio GetListenKey err:%v", err)
+			return ""
+		}
+		return url + key
+	})
 	return client
 }
 
@@ -67,45 +78,164 @@ func (binance *BinanceImp) Subscribe(symbol string, topic string) map[string]int
 	return params
 }
 
+// UserDataEvent 统一账户推送，fs区分UM、CM和杠杆(MM)业务
+type UserDataEvent struct {
+	Event        string `json:"e"`
+	EventTime    int64  `json:"E"`
+	BusinessUnit string `json:"fs"`
+}
+
+// RiskLevelChange 统一账户风险等级变化
+type RiskLevelChange struct {
+	Event        string `json:"e"`
+	EventTime    int64  `json:"E"`
+	UniMMR       string `json:"u"`
+	Status       string `json:"s"`
+	Equity       string `json:"eq"`
+	ActualEquity string `json:"ae"`
+	MaintMargin  string `json:"m"`
+}
+
+// BalanceUpdate 杠杆账户充提和划转引起的余额变化
+type BalanceUpdate struct {
+	Event     string `json:"e"`
+	EventTime int64  `json:"E"`
+	Asset     string `json:"a"`
+	Delta     string `json:"d"`
+	ClearTime int64  `json:"T"`
+}
+
+func (b *BalanceUpdate) ToBalanceUpdate() *types.BalanceUpdate {
+	delta, _ := utils.ParseFloat(b.Delta)
+	return &types.BalanceUpdate{
+		Coin:  strings.ToUpper(b.Asset),
+		Delta: delta,
+		Ts:    b.EventTime,
+	}
+}
+
+func (r *RiskLevelChange) ToRiskLevel() *types.RiskLevel {
+	uniMMR, _ := utils.ParseFloat(r.UniMMR)
+	equity, _ := utils.ParseFloat(r.Equity)
+	actualEquity, _ := utils.ParseFloat(r.ActualEquity)
+	maintMargin, _ := utils.ParseFloat(r.MaintMargin)
+	return &types.RiskLevel{
+		UniMMR:       uniMMR,
+		Status:       r.Status,
+		Equity:       equity,
+		ActualEquity: actualEquity,
+		MaintMargin:  maintMargin,
+		Ts:           r.EventTime,
+	}
+}
+
 func (binance *BinanceImp) Handle(cli *ws.WsClient, bs []byte) {
-	dict, err := utils.ByteToMap(bs)
-	if err != nil {
-		log.Warnf("Binance Portfolio 的私有ws消息解析为map失败：%v，原始消息：%v。", err, bs)
+	var evt UserDataEvent
+	if err := sonic.Unmarshal(bs, &evt); err != nil {
+		log.Warnf("Binance Portfolio 的私有ws消息解析失败：%v，原始消息：%s。", err, bs)
 		return
 	}
-	event, ok := dict["e"]
-	if ok {
-		switch event {
-		case "ORDER_TRADE_UPDATE":
-			l := dict["o"].(map[string]interface{})
-
-			if l["x"] == "TRADE" {
-				log.Infof("ORDER_TRADE_UPDATE %s", bs)
-
-				CreateAt, err := json.Number(l["T"].(json.Number)).Int64()
-				if err != nil {
-					// handle error, perhaps set T to 0 or log an error message
-					CreateAt = 0
-				}
-
-				evt := &types.Order{
-					Symbol:      strings.Replace(l["s"].(string), "USDT", "_USDT", 1), // 假设这是一个转换函数
-					Type:        Binance2Type[l["o"].(string)],                        // 假设可以直接转换
-					OrderID:     string(l["i"].(json.Number)),
-					ClientID:    l["c"].(string),
-					Side:        Binance2Side[l["S"].(string)], // 假设可以直接转换
-					Price:       l["L"].(string),
-					OrigQty:     l["q"].(string),
-					ExecutedQty: l["z"].(string),
-					AvgPrice:    l["ap"].(string),
-					Fee:         l["n"].(string),
-					MarketType:  dict["fs"].(string),
-					Status:      Binance2Status[l["X"].(string)], // 假设可以直接转换
-					CreateAt:    CreateAt,                        // 示例：使用当前时间的毫秒表示
-					UpdateAt:    time.Now().UnixNano() / int64(time.Millisecond),
-				}
-				binance.rspHandle([]*types.Order{evt})
-			}
+
+	switch evt.Event {
+	case "ORDER_TRADE_UPDATE":
+		if evt.BusinessUnit == CMExchange {
+			binance.onCMOrderUpdate(bs)
+			return
+		}
+		// UM订单，结构与U本位合约一致
+		var update binanceufutures.OrderTradeUpdate
+		if err := sonic.Unmarshal(bs, &update); err != nil {
+			log.WithError(err).Error("unmarshal binance ORDER_TRADE_UPDATE failed")
+			return
 		}
+		order := update.ToOrder()
+		order.Exchange = constant.BinancePortfolio
+		order.MarketType = UMExchange
+		binance.rspHandle([]*types.Order{order})
+	case "executionReport":
+		// 杠杆订单，结构与现货一致
+		var report binancespot.ExecutionReport
+		if err := sonic.Unmarshal(bs, &report); err != nil {
+			log.WithError(err).Error("unmarshal binance executionReport failed")
+			return
+		}
+		order := report.ToOrder()
+		order.Exchange = constant.BinancePortfolio
+		order.MarketType = MMExchange
+		binance.rspHandle([]*types.Order{order})
+	case "ACCOUNT_UPDATE":
+		if evt.BusinessUnit == CMExchange {
+			binance.onCMAccountUpdate(bs)
+			return
+		}
+		var update binanceufutures.AccountUpdate
+		if err := sonic.Unmarshal(bs, &update); err != nil {
+			log.WithError(err).Error("unmarshal binance ACCOUNT_UPDATE failed")
+			return
+		}
+		if len(update.Account.Balances) > 0 {
+			binance.rspHandle(update.ToAssets())
+		}
+		if len(update.Account.Positions) > 0 {
+			binance.rspHandle(update.ToPositions())
+		}
+	case "outboundAccountPosition":
+		var position binancespot.AccountPosition
+		if err := sonic.Unmarshal(bs, &position); err != nil {
+			log.WithError(err).Error("unmarshal binance outboundAccountPosition failed")
+			return
+		}
+		binance.rspHandle(position.ToAssets())
+	case "riskLevelChange":
+		var change RiskLevelChange
+		if err := sonic.Unmarshal(bs, &change); err != nil {
+			log.WithError(err).Error("unmarshal binance riskLevelChange failed")
+			return
+		}
+		log.Warnf("binance portfolio risk level change %s", bs)
+		binance.rspHandle(change.ToRiskLevel())
+	case "balanceUpdate":
+		// 杠杆账户充提和划转，之后还会推送outboundAccountPosition
+		var update BalanceUpdate
+		if err := sonic.Unmarshal(bs, &update); err != nil {
+			log.WithError(err).Error("unmarshal binance balanceUpdate failed")
+			return
+		}
+		binance.rspHandle(update.ToBalanceUpdate())
+	case "listenKeyExpired":
+		// 重连时会申请新的listenKey
+		log.Errorf("binance portfolio listenKey expired %s", bs)
+		cli.Reconnect()
+	case "liabilityChange", "openOrderLoss", "ACCOUNT_CONFIG_UPDATE", "CONDITIONAL_ORDER_TRADE_UPDATE":
+		log.Infof("binance portfolio %s %s", evt.Event, bs)
+	default:
+		log.Warnf("unknown binance portfolio user data %s", bs)
+	}
+}
+
+// onCMOrderUpdate 币本位订单，结构与币本位合约一致
+func (binance *BinanceImp) onCMOrderUpdate(bs []byte) {
+	var update binancecfutures.OrderTradeUpdate
+	if err := sonic.Unmarshal(bs, &update); err != nil {
+		log.WithError(err).Error("unmarshal binance CM ORDER_TRADE_UPDATE failed")
+		return
+	}
+	order := update.ToOrder()
+	order.Exchange = constant.BinancePortfolio
+	order.MarketType = CMExchange
+	binance.rspHandle([]*types.Order{order})
+}
+
+func (binance *BinanceImp) onCMAccountUpdate(bs []byte) {
+	var update binancecfutures.AccountUpdate
+	if err := sonic.Unmarshal(bs, &update); err != nil {
+		log.WithError(err).Error("unmarshal binance CM ACCOUNT_UPDATE failed")
+		return
+	}
+	if len(update.Account.Balances) > 0 {
+		binance.rspHandle(update.ToAssets())
+	}
+	if len(update.Account.Positions) > 0 {
+		binance.rspHandle(update.ToPositions())
 	}
 }
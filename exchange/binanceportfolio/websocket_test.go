@@ -0,0 +1,37 @@
+package binanceportfolio
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cybernonce/gotrader/exchange/binanceufutures"
+)
+
+func TestBalanceUpdate(t *testing.T) {
+	msg := `{"e":"balanceUpdate","E":1573200697110,"a":"usdt","d":"-100.00000000","U":1027053479517,"T":1573200697068}`
+	var update BalanceUpdate
+	if err := json.Unmarshal([]byte(msg), &update); err != nil {
+		t.Fatal(err)
+	}
+	evt := update.ToBalanceUpdate()
+	if evt.Coin != "USDT" || evt.Delta != -100 || evt.Ts != 1573200697110 {
+		t.Fatalf("unexpected balance update %+v", evt)
+	}
+}
+
+// 统一账户UM持仓推送复用U本位合约的结构，单向持仓平仓后不应被当作空仓
+func TestUMAccountUpdateFlatPosition(t *testing.T) {
+	msg := `{"e":"ACCOUNT_UPDATE","fs":"UM","E":1691484470000,"T":1691484470000,"a":{"m":"ORDER",
+		"B":[],"P":[{"s":"BTCUSDT","pa":"0","ep":"0","up":"0","mt":"cross","iw":"0","ps":"BOTH"}]}}`
+	var update binanceufutures.AccountUpdate
+	if err := json.Unmarshal([]byte(msg), &update); err != nil {
+		t.Fatal(err)
+	}
+	positions := update.ToPositions()
+	if len(positions) != 1 || positions[0].Side != "" || positions[0].Position != 0 {
+		t.Fatalf("unexpected flat position %+v", positions[0])
+	}
+	if side := getPositionSide("BOTH", 0); side != "" {
+		t.Fatalf("getPositionSide(BOTH, 0) = %s", side)
+	}
+}
@@ -1,12 +1,54 @@
 package binancespot
 
 import (
-	"fmt"
+	"encoding/json"
+	"net/http"
 
 	"github.com/cybernonce/gotrader/trader/types"
 )
 
-func (binance *BinanceSpotExchange) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+// CancelBatchOrders 现货没有批量撤单接口，逐个调用 DELETE /api/v3/order
+func (client *RestClient) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		param := formCancelRequest(order)
 
-	return nil, fmt.Errorf("not impl")
+		uri := CancelOrderUri
+		body, res, err := client.HttpRequest(http.MethodDelete, uri, param)
+		if err != nil {
+			log.Errorf("binance DELETE /api/v3/order err: %v", err)
+			result = append(result, orderErrTransform(order, -1, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance DELETE /api/v3/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderFailTransform(order, body))
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Errorf("binance DELETE /api/v3/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, -1, err.Error()))
+			continue
+		}
+
+		info := orderTransform(&orderResponse)
+		info.IsSuccess = true
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+func formCancelRequest(order *types.Order) map[string]interface{} {
+	result := map[string]interface{}{
+		"symbol": Symbol2Binance(order.Symbol),
+	}
+	if order.OrderID != "" {
+		result["orderId"] = order.OrderID
+	} else {
+		result["origClientOrderId"] = order.ClientID
+	}
+	return result
 }
@@ -1,12 +1,119 @@
 package binancespot
 
 import (
-	"fmt"
+	"encoding/json"
+	"net/http"
+	"strconv"
 
+	"github.com/cybernonce/gotrader/trader/constant"
 	"github.com/cybernonce/gotrader/trader/types"
 )
 
-func (client *RestClient) CreateBatchOrders([]*types.Order) ([]*types.OrderResult, error) {
+type OrderResponse struct {
+	Symbol              string `json:"symbol"`
+	OrderId             int64  `json:"orderId"`
+	ClientOrderId       string `json:"clientOrderId"`
+	TransactTime        int64  `json:"transactTime"`
+	Price               string `json:"price"`
+	OrigQty             string `json:"origQty"`
+	ExecutedQty         string `json:"executedQty"`
+	CummulativeQuoteQty string `json:"cummulativeQuoteQty"`
+	Status              string `json:"status"`
+	TimeInForce         string `json:"timeInForce"`
+	Type                string `json:"type"`
+	Side                string `json:"side"`
+}
+
+// CreateBatchOrders 现货没有批量下单接口，逐个调用 /api/v3/order
+func (client *RestClient) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		param := formRequest(order)
+
+		uri := CreateOrderUri
+		body, res, err := client.HttpRequest(http.MethodPost, uri, param)
+		if err != nil {
+			log.Errorf("binance post /api/v3/order err: %v", err)
+			result = append(result, orderErrTransform(order, -1, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance post /api/v3/order err: %v %s", res.StatusCode, body)
+			result = append(result, orderFailTransform(order, body))
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Errorf("binance post /api/v3/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, -1, err.Error()))
+			continue
+		}
+
+		result = append(result, orderTransform(&orderResponse))
+	}
+
+	return result, nil
+}
+
+func formRequest(order *types.Order) map[string]interface{} {
+	result := map[string]interface{}{
+		"symbol":           Symbol2Binance(order.Symbol),
+		"side":             Side2Binance[order.Side.Name()],
+		"newOrderRespType": "RESULT",
+	}
+
+	switch order.Type {
+	case constant.Market:
+		result["type"] = "MARKET"
+		// 只指定了quote金额时按金额市价成交
+		if order.OrigQty == "" && order.Amount != "" {
+			result["quoteOrderQty"] = order.Amount
+		} else {
+			result["quantity"] = order.OrigQty
+		}
+	case constant.PostOnly:
+		result["type"] = "LIMIT_MAKER"
+		result["price"] = order.Price
+		result["quantity"] = order.OrigQty
+	default:
+		result["type"] = "LIMIT"
+		result["timeInForce"] = TimeInForce2Binance[order.Type.Name()]
+		result["price"] = order.Price
+		result["quantity"] = order.OrigQty
+	}
+
+	if order.ClientID != "" {
+		result["newClientOrderId"] = order.ClientID
+	}
+	return result
+}
+
+func orderTransform(info *OrderResponse) *types.OrderResult {
+	var result types.OrderResult
+	if info.Status != "REJECTED" && info.Status != "EXPIRED" {
+		result.IsSuccess = true
+	}
+	result.OrderId = strconv.FormatInt(info.OrderId, 10)
+	result.ClientId = info.ClientOrderId
+	return &result
+}
+
+// orderFailTransform 解析币安返回的错误码和错误信息
+func orderFailTransform(order *types.Order, body []byte) *types.OrderResult {
+	var errRsp BinanceErrRsp
+	if err := json.Unmarshal(body, &errRsp); err != nil {
+		return orderErrTransform(order, -1, string(body))
+	}
+	return orderErrTransform(order, errRsp.Code, errRsp.Msg)
+}
 
-	return nil, fmt.Errorf("not impl")
+func orderErrTransform(order *types.Order, code int32, msg string) *types.OrderResult {
+	return &types.OrderResult{
+		IsSuccess: false,
+		OrderId:   order.OrderID,
+		ClientId:  order.ClientID,
+		ErrCode:   code,
+		ErrMsg:    msg,
+	}
 }
@@ -2,7 +2,7 @@ package binancespot
 
 import (
 	"fmt"
-	"time"
+	"sync/atomic"
 
 	"github.com/cybernonce/gotrader/exchange/base"
 	"github.com/cybernonce/gotrader/pkg/ws"
@@ -17,40 +17,53 @@ type BinanceSpotExchange struct {
 	pubWsClient *ws.WsClient
 	priWsClient *ws.WsClient
 
+	// 私有连接当前使用的listenKey，每次连接前重新申请
+	listenKey atomic.Value
+
 	// callbacks
 	onBooktickerCallback func(*types.BookTicker)
+	onTradeCallback      func([]*types.Trade)
+	onOrderBookCallback  func(*types.OrderBook)
 	onOrderCallback      func([]*types.Order)
+	onBalanceCallback    func(*types.Assets)
 }
 
 func NewBinanceSpot(params *types.ExchangeParameters) *BinanceSpotExchange {
 	apiKey := params.AccessKey
 	secretKey := params.SecretKey
 	passPhrase := params.Passphrase
+	endpoints := base.MustResolveEndpoints(params, Environments)
+	transport := base.MustNewTransport(params)
 
 	// new client
 	client := NewRestClient(apiKey, secretKey, passPhrase, constant.BinanceSpot)
+	client.SetEndpoints(endpoints)
+	client.SetHttpClient(transport.HttpClient)
 	exchange := &BinanceSpotExchange{
 		exchangeType: constant.BinanceSpot,
 		restClient:   client,
 	}
 	// pubWsClient
-	pubWsClient := NewBinanceSpotPubWsClient(exchange.OnPubWsHandle)
+	pubWsClient := NewBinanceSpotPubWsClient(endpoints.PubWsUrl, exchange.OnPubWsHandle)
+	pubWsClient.SetDialer(transport.WsDialer)
 	if err := pubWsClient.Dial(ws.Connect); err != nil {
 		log.Errorf("pubWsClient.Dial err %s", err)
 	} else {
 		exchange.pubWsClient = pubWsClient
 		log.Infof("pubWsClient.Dial success")
 	}
-	// // priWsClient
-	// if len(apiKey) > 0 {
-	// 	priWsClient := NewOkPriWsClient(apiKey, secretKey, passPhrase, exchange.OnPriWsHandle)
-	// 	if err := priWsClient.Dial(ws.Connect); err != nil {
-	// 		log.Errorf("priWsClient.Dial err %s", err)
-	// 	} else {
-	// 		exchange.priWsClient = priWsClient
-	// 		log.Infof("priWsClient.Dial success")
-	// 	}
-	// }
+	// priWsClient
+	if len(apiKey) > 0 {
+		priWsClient := NewBinanceSpotPriWsClient(endpoints.PriWsUrl, apiKey, secretKey, passPhrase, exchange.newListenKey, exchange.OnPriWsHandle)
+		priWsClient.SetDialer(transport.WsDialer)
+		if err := priWsClient.Dial(ws.Connect); err != nil {
+			log.Errorf("priWsClient.Dial err %s", err)
+		} else {
+			exchange.priWsClient = priWsClient
+			log.Infof("priWsClient.Dial success")
+			exchange.KeepUserStream()
+		}
+	}
 	return exchange
 }
 
@@ -63,13 +76,45 @@ func (binance *BinanceSpotExchange) GetType() (typ constant.ExchangeType) {
 	return binance.exchangeType
 }
 
+func (binance *BinanceSpotExchange) GetListenKey() (string, error) {
+	return binance.restClient.GetListenKey()
+}
+
+// newListenKey 申请新的listenKey并记录下来用于定时延长
+func (binance *BinanceSpotExchange) newListenKey() (string, error) {
+	listenKey, err := binance.GetListenKey()
+	if err != nil {
+		return "", err
+	}
+	binance.listenKey.Store(listenKey)
+	return listenKey, nil
+}
+
+func (binance *BinanceSpotExchange) currentListenKey() string {
+	listenKey, _ := binance.listenKey.Load().(string)
+	return listenKey
+}
+
+// KeepUserStream 定时延长listenKey，延长失败时重连私有连接，重连会申请新的listenKey
+func (binance *BinanceSpotExchange) KeepUserStream() {
+	go binance.restClient.KeepUserStream(binance.currentListenKey, binance.priWsClient.Reconnect)
+}
+
+// StopUserStream 停止定时延长listenKey
+func (binance *BinanceSpotExchange) StopUserStream() {
+	binance.restClient.StopUserStream()
+}
+
 func (binance *BinanceSpotExchange) FetchSymbols() ([]*types.SymbolInfo, error) {
 	return binance.restClient.FetchSymbols()
 
 }
 func (binance *BinanceSpotExchange) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
+	return binance.restClient.FetchKline(symbol, interval, limit)
+}
 
-	return nil, fmt.Errorf("not impl")
+func (binance *BinanceSpotExchange) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return binance.restClient.FetchHistoryKline(param)
 }
 
 func (binance *BinanceSpotExchange) FetchBalance() (*types.Assets, error) {
@@ -80,11 +125,59 @@ func (binance *BinanceSpotExchange) FetchAssetBalance() (*types.Assets, error) {
 	return nil, fmt.Errorf("FetchAssetBalance not imp")
 }
 
-func (binance *BinanceSpotExchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+func (binance *BinanceSpotExchange) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	return binance.restClient.FetchOpenOrders(symbol)
+}
 
+func (binance *BinanceSpotExchange) FetchOrder(order *types.Order) (*types.Order, error) {
+	return binance.restClient.FetchOrder(order)
+}
+
+func (binance *BinanceSpotExchange) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return binance.restClient.FetchUserTrades(param)
+}
+
+func (binance *BinanceSpotExchange) FetchCurrencies() ([]*types.Currency, error) {
+	return binance.restClient.FetchCurrencies()
+}
+
+func (binance *BinanceSpotExchange) FetchDepositAddress(coin string, chain string) (*types.DepositAddress, error) {
+	return binance.restClient.FetchDepositAddress(coin, chain)
+}
+
+func (binance *BinanceSpotExchange) Withdraw(param base.WithdrawParam) (string, error) {
+	return binance.restClient.Withdraw(param)
+}
+
+func (binance *BinanceSpotExchange) FetchDepositHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	return binance.restClient.FetchDepositHistory(param)
+}
+
+func (binance *BinanceSpotExchange) FetchWithdrawHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	return binance.restClient.FetchWithdrawHistory(param)
+}
+
+func (binance *BinanceSpotExchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
 	return binance.restClient.CreateBatchOrders(orders)
 }
 
+func (binance *BinanceSpotExchange) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return binance.restClient.CancelBatchOrders(orders)
+}
+
+// AmendBatchOrders 现货没有改单接口，撤单后重新下单
+func (binance *BinanceSpotExchange) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	return base.CancelReplace(orders, binance.restClient.CancelBatchOrders, binance.restClient.FetchOrder, binance.restClient.CreateBatchOrders)
+}
+
+func (binance *BinanceSpotExchange) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	return fmt.Errorf("not impl")
+}
+
+func (binance *BinanceSpotExchange) SetPositionMode(mode constant.PositionMode) error {
+	return fmt.Errorf("not impl")
+}
+
 func (binance *BinanceSpotExchange) PrivateTransfer(transfer base.TransferParam) (string, error) {
 	return "", fmt.Errorf("PrivateTransfer not imp")
 }
@@ -100,33 +193,51 @@ func (binance *BinanceSpotExchange) Subscribe(params map[string]interface{}) err
 }
 
 func (binance *BinanceSpotExchange) SubscribeBookTicker(symbols []string, callback func(*types.BookTicker)) (err error) {
-	for _, symbol := range symbols {
-		var args []string
-		args = append(args, fmt.Sprintf("%s@bookTicker", Symbol2BinanceWsInstId(symbol)))
-
-		params := map[string]interface{}{
-			"method": "SUBSCRIBE",
-			"params": args,
-			"id":     1,
-		}
+	binance.onBooktickerCallback = callback
+	return binance.subscribeStreams(symbols, "bookTicker")
+}
 
-		if binance.pubWsClient == nil {
-			return fmt.Errorf("pubWsClient is nil")
-		}
+// SubscribeTrades 订阅归集成交
+func (binance *BinanceSpotExchange) SubscribeTrades(symbols []string, callback func([]*types.Trade)) (err error) {
+	binance.onTradeCallback = callback
+	return binance.subscribeStreams(symbols, "aggTrade")
+}
 
-		if err := binance.pubWsClient.Write(params); err != nil {
-			return fmt.Errorf("Subscribe err: %s", err)
-		}
-		binance.onBooktickerCallback = callback
+// SubscribeOrderBook 订阅20档有限深度，每次推送完整的20档
+func (binance *BinanceSpotExchange) SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) (err error) {
+	binance.onOrderBookCallback = callback
+	return binance.subscribeStreams(symbols, DepthStream)
+}
 
-		time.Sleep(200 * time.Millisecond)
+// subscribeStreams 按交易对逐个订阅 <symbol>@<stream>，断线重连后自动重新订阅
+func (binance *BinanceSpotExchange) subscribeStreams(symbols []string, stream string) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	for _, symbol := range symbols {
+		binance.pubWsClient.Subscribe(symbol, stream)
 	}
-
 	return nil
 }
 
+// SubscribeOrders 用户数据流推送全部交易对的订单，symbols不做过滤
 func (binance *BinanceSpotExchange) SubscribeOrders(symbols []string, callback func(orders []*types.Order)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onOrderCallback = callback
+	return nil
+}
+
+func (binance *BinanceSpotExchange) SubscribeBalance(callback func(*types.Assets)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onBalanceCallback = callback
+	return nil
+}
 
+func (binance *BinanceSpotExchange) SubscribePositions(callback func([]*types.Position)) (err error) {
 	return fmt.Errorf("not impl")
 }
 
@@ -140,9 +251,34 @@ func (binance *BinanceSpotExchange) OnPubWsHandle(data interface{}) {
 			log.Errorf("OnBookTicker Callback not set")
 		}
 	case *types.OrderBook:
-		fmt.Println("OrderBook type", v)
-	case *types.Trade:
-		fmt.Println("Trade type", v)
+		if binance.onOrderBookCallback != nil {
+			binance.onOrderBookCallback(v)
+		} else {
+			log.Errorf("onOrderBook Callback not set")
+		}
+	case []*types.Trade:
+		if binance.onTradeCallback != nil {
+			binance.onTradeCallback(v)
+		} else {
+			log.Errorf("onTrade Callback not set")
+		}
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
+
+func (binance *BinanceSpotExchange) OnPriWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case []*types.Order:
+		if binance.onOrderCallback != nil {
+			binance.onOrderCallback(v)
+		} else {
+			log.Errorf("onOrder Callback not set")
+		}
+	case *types.Assets:
+		if binance.onBalanceCallback != nil {
+			binance.onBalanceCallback(v)
+		}
 	default:
 		log.Errorf("Unknown type %s", v)
 	}
@@ -0,0 +1,80 @@
+package binancespot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	KeepLiveTime = 30 * time.Minute
+)
+
+type ListenKeyResponse struct {
+	ListenKey string `json:"listenKey"`
+}
+
+func (client *RestClient) GetListenKey() (string, error) {
+	uri := UserDataStreamUri
+	body, res, err := client.HttpKeyRequest(http.MethodPost, uri, nil)
+	if err != nil {
+		log.Errorf("binance FetchListenKey 网络错误:%v", err)
+		return "", err
+	}
+	if res.StatusCode != 200 {
+		return "", fmt.Errorf("binance post /api/v3/userDataStream err: %v %s", res.StatusCode, body)
+	}
+
+	var response ListenKeyResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance FetchListenKey parser err:%v", err)
+		return "", err
+	}
+
+	return response.ListenKey, nil
+}
+
+func (client *RestClient) RefreshListenKey(key string) error {
+	uri := UserDataStreamUri
+	param := make(map[string]interface{})
+	param["listenKey"] = key
+	body, res, err := client.HttpKeyRequest(http.MethodPut, uri, param)
+	if err != nil {
+		log.Errorf("RefreshListenKey err: %s", err)
+		return err
+	}
+	if res.StatusCode != 200 {
+		err := fmt.Errorf("binance put /api/v3/userDataStream err: %v %s", res.StatusCode, body)
+		log.Errorf("RefreshListenKey err: %s", err)
+		return err
+	}
+	log.Infof("RefreshListenKey success: %s", key)
+
+	return nil
+}
+
+// KeepUserStream listenKey有效期60分钟，定时延长当前的listenKey，延长失败时调用onFailed
+func (client *RestClient) KeepUserStream(listenKey func() string, onFailed func()) {
+	timer := time.NewTimer(KeepLiveTime)
+
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			if err := client.RefreshListenKey(listenKey()); err != nil {
+				onFailed()
+			}
+			timer.Reset(KeepLiveTime)
+		case <-client.stopChan:
+			return
+		}
+	}
+}
+
+// StopUserStream 停止定时延长listenKey，可以重复调用
+func (client *RestClient) StopUserStream() {
+	client.stopOnce.Do(func() {
+		close(client.stopChan)
+	})
+}
@@ -0,0 +1,102 @@
+package binancespot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// OrderInfo 查询订单接口返回的订单信息
+type OrderInfo struct {
+	OrderResponse
+	Time       int64 `json:"time"`
+	UpdateTime int64 `json:"updateTime"`
+}
+
+// FetchOpenOrders 查询当前挂单，symbol为空时查询全部交易对
+func (client *RestClient) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	param := map[string]interface{}{}
+	if symbol != "" {
+		param["symbol"] = Symbol2Binance(symbol)
+	}
+
+	uri := FetchOpenOrderUri
+	body, res, err := client.HttpRequest(http.MethodGet, uri, param)
+	if err != nil {
+		log.Errorf("binance get /api/v3/openOrders err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /api/v3/openOrders err: %v %s", res.StatusCode, body)
+	}
+
+	var response []*OrderInfo
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /api/v3/openOrders parser err:%v", err)
+		return nil, err
+	}
+
+	result := make([]*types.Order, 0, len(response))
+	for _, info := range response {
+		result = append(result, info.ToOrder())
+	}
+	return result, nil
+}
+
+// FetchOrder 按OrderID或ClientID查询订单
+func (client *RestClient) FetchOrder(order *types.Order) (*types.Order, error) {
+	param := formCancelRequest(order)
+
+	uri := FetchSingleOrder
+	body, res, err := client.HttpRequest(http.MethodGet, uri, param)
+	if err != nil {
+		log.Errorf("binance get /api/v3/order err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /api/v3/order err: %v %s", res.StatusCode, body)
+	}
+
+	var response OrderInfo
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /api/v3/order parser err:%v", err)
+		return nil, err
+	}
+	return response.ToOrder(), nil
+}
+
+func (info *OrderInfo) ToOrder() *types.Order {
+	orderType := Binance2Type[info.Type]
+	if orderType == constant.Limit && (info.TimeInForce == "IOC" || info.TimeInForce == "FOK") {
+		orderType = IOC2Type[info.TimeInForce]
+	}
+
+	var avgPrice string
+	cumQty, _ := utils.ParseFloat(info.ExecutedQty)
+	cumQuote, _ := utils.ParseFloat(info.CummulativeQuoteQty)
+	if cumQty > 0 {
+		avgPrice = strconv.FormatFloat(cumQuote/cumQty, 'f', -1, 64)
+	}
+
+	return &types.Order{
+		Symbol:      Binance2Symbol(info.Symbol),
+		Exchange:    constant.BinanceSpot,
+		Type:        orderType,
+		OrderID:     strconv.FormatInt(info.OrderId, 10),
+		ClientID:    info.ClientOrderId,
+		Side:        Binance2Side[info.Side],
+		Price:       info.Price,
+		OrigQty:     info.OrigQty,
+		ExecutedQty: info.ExecutedQty,
+		ExecutedAmt: info.CummulativeQuoteQty,
+		AvgPrice:    avgPrice,
+		Status:      Binance2Status[info.Status],
+		CreateAt:    info.Time,
+		UpdateAt:    info.UpdateTime,
+	}
+}
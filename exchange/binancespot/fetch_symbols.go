@@ -21,7 +21,7 @@ type Symbol struct {
 }
 
 func (client *RestClient) FetchSymbols() ([]*types.SymbolInfo, error) {
-	url := RestUrl + FetchSymbolUri
+	url := client.restUrl + FetchSymbolUri
 
 	body, _, err := client.HttpGet(url)
 	if err != nil {
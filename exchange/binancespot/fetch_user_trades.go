@@ -0,0 +1,88 @@
+package binancespot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 单次请求最多返回的成交数量
+const maxUserTradeLimit = 1000
+
+type UserTrade struct {
+	Symbol          string `json:"symbol"`
+	Id              int64  `json:"id"`
+	OrderId         int64  `json:"orderId"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+}
+
+// FetchUserTrades 查询账户成交明细，symbol必填，startTime和endTime间隔不能超过24小时
+func (client *RestClient) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return base.PageUserTrades(Symbol2Binance(param.Symbol), param, maxUserTradeLimit, client.fetchUserTrades)
+}
+
+func (client *RestClient) fetchUserTrades(param map[string]interface{}) ([]*types.Fill, error) {
+	uri := FetchUserTradeUri
+	body, res, err := client.HttpRequest(http.MethodGet, uri, param)
+	if err != nil {
+		log.Errorf("binance get /api/v3/myTrades err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /api/v3/myTrades err: %v %s", res.StatusCode, body)
+	}
+
+	var response []*UserTrade
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /api/v3/myTrades parser err:%v", err)
+		return nil, err
+	}
+	return userTradesTransform(response), nil
+}
+
+func userTradesTransform(response []*UserTrade) []*types.Fill {
+	result := make([]*types.Fill, 0, len(response))
+	for _, item := range response {
+		result = append(result, item.ToFill())
+	}
+	return result
+}
+
+func (t *UserTrade) ToFill() *types.Fill {
+	price, _ := utils.ParseFloat(t.Price)
+	qty, _ := utils.ParseFloat(t.Qty)
+	fee, _ := utils.ParseFloat(t.Commission)
+	fill := &types.Fill{
+		Symbol:   Binance2Symbol(t.Symbol),
+		Exchange: constant.BinanceSpot,
+		TradeID:  strconv.FormatInt(t.Id, 10),
+		OrderID:  strconv.FormatInt(t.OrderId, 10),
+		Side:     constant.OrderSell,
+		Price:    price,
+		Qty:      qty,
+		Fee:      fee,
+		FeeCoin:  t.CommissionAsset,
+		Role:     constant.Taker,
+		Ts:       t.Time,
+	}
+	if t.IsBuyer {
+		fill.Side = constant.OrderBuy
+	}
+	if t.IsMaker {
+		fill.Role = constant.Maker
+	}
+	return fill
+}
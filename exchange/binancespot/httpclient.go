@@ -3,8 +3,10 @@ package binancespot
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/cybernonce/gotrader/exchange/base"
 	"github.com/cybernonce/gotrader/pkg/httpx"
 	"github.com/cybernonce/gotrader/pkg/utils"
 	"github.com/cybernonce/gotrader/trader/constant"
@@ -17,6 +19,10 @@ type RestClient struct {
 	secretKey    string
 	passPhrase   string
 	exchangeType constant.ExchangeType
+	stopChan     chan struct{}
+	stopOnce     sync.Once
+	restUrl      string
+	httpClient   httpx.Client
 }
 
 type BaseOkRsp struct {
@@ -24,15 +30,35 @@ type BaseOkRsp struct {
 	Msg  string `json:"msg"`
 }
 
+// BinanceErrRsp 币安接口出错时返回的结构
+type BinanceErrRsp struct {
+	Code int32  `json:"code"`
+	Msg  string `json:"msg"`
+}
+
 func NewRestClient(apiKey, secretKey, passPhrase string, exchangeType constant.ExchangeType) *RestClient {
 	client := &RestClient{
 		apiKey:       apiKey,
 		secretKey:    secretKey,
 		passPhrase:   passPhrase,
 		exchangeType: exchangeType,
+		stopChan:     make(chan struct{}),
+		restUrl:      RestUrl,
+		httpClient:   httpClient,
 	}
 	return client
 }
+
+// SetEndpoints 使用指定环境的rest地址
+func (client *RestClient) SetEndpoints(endpoints base.Endpoints) {
+	client.restUrl = endpoints.RestUrl
+}
+
+// SetHttpClient 使用交易所实例独立的http客户端(代理、超时、出口IP)
+func (client *RestClient) SetHttpClient(httpClient httpx.Client) {
+	client.httpClient = httpClient
+}
+
 func (client *RestClient) HttpRequest(method string, uri string, param map[string]interface{}) ([]byte, *http.Response, error) {
 	header := map[string]string{
 		"X-MBX-APIKEY": client.apiKey,
@@ -40,13 +66,34 @@ func (client *RestClient) HttpRequest(method string, uri string, param map[strin
 	param["timestamp"] = time.Now().UnixMilli() - 1000
 	toSignStr := utils.UrlEncodeParams(param)
 	signature := utils.GenHexDigest(utils.HmacSha256(toSignStr, client.secretKey))
-	url := fmt.Sprintf("%s%s?%s&signature=%s", RestUrl, uri, toSignStr, signature)
+	url := fmt.Sprintf("%s%s?%s&signature=%s", client.restUrl, uri, toSignStr, signature)
+	args := &httpx.Request{
+		Url:    url,
+		Head:   header,
+		Method: method,
+	}
+	body, res, err := client.httpClient.Request(args)
+	if err != nil {
+		return nil, res, err
+	}
+	return *body, res, err
+}
+
+// HttpKeyRequest 只需要API Key不需要签名的接口，如 userDataStream
+func (client *RestClient) HttpKeyRequest(method string, uri string, param map[string]interface{}) ([]byte, *http.Response, error) {
+	header := map[string]string{
+		"X-MBX-APIKEY": client.apiKey,
+	}
+	url := client.restUrl + uri
+	if len(param) > 0 {
+		url = fmt.Sprintf("%s?%s", url, utils.UrlEncodeParams(param))
+	}
 	args := &httpx.Request{
 		Url:    url,
 		Head:   header,
 		Method: method,
 	}
-	body, res, err := httpClient.Request(args)
+	body, res, err := client.httpClient.Request(args)
 	if err != nil {
 		return nil, res, err
 	}
@@ -54,7 +101,7 @@ func (client *RestClient) HttpRequest(method string, uri string, param map[strin
 }
 
 func (client *RestClient) HttpGet(url string) ([]byte, *http.Response, error) {
-	body, res, err := httpClient.Get(url)
+	body, res, err := client.httpClient.Get(url)
 	if err != nil {
 		return nil, res, err
 	}
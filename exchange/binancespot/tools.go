@@ -3,12 +3,67 @@ package binancespot
 import (
 	"fmt"
 	"strings"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/constant"
 )
 
 var (
 	RestUrl  = "https://api.binance.com"
 	PubWsUrl = "wss://stream.binance.com:9443/stream"
 	PriWsUrl = "wss://stream.binance.com:9443/ws/"
+
+	// Environments 各环境的地址，通过ExchangeParameters.Environment选择
+	Environments = map[constant.Environment]base.Endpoints{
+		constant.EnvProduction: {
+			RestUrl:  RestUrl,
+			PubWsUrl: PubWsUrl,
+			PriWsUrl: PriWsUrl,
+		},
+		constant.EnvTestnet: {
+			RestUrl:  "https://testnet.binance.vision",
+			PubWsUrl: "wss://stream.testnet.binance.vision/stream",
+			PriWsUrl: "wss://stream.testnet.binance.vision/ws/",
+		},
+	}
+
+	// 有限档深度频道
+	DepthStream = "depth20@100ms"
+
+	Side2Binance = map[string]string{
+		constant.OrderBuy.Name():  "BUY",
+		constant.OrderSell.Name(): "SELL",
+	}
+	Binance2Side = map[string]constant.OrderSide{
+		"BUY":  constant.OrderBuy,
+		"SELL": constant.OrderSell,
+	}
+	// 币安现货IOC/FOK是LIMIT单的timeInForce，只挂单是独立的LIMIT_MAKER类型
+	TimeInForce2Binance = map[string]string{
+		constant.Limit.Name(): "GTC",
+		constant.GTC.Name():   "GTC",
+		constant.IOC.Name():   "IOC",
+		constant.FOK.Name():   "FOK",
+	}
+	Binance2Type = map[string]constant.OrderType{
+		"LIMIT":       constant.Limit,
+		"MARKET":      constant.Market,
+		"LIMIT_MAKER": constant.PostOnly,
+	}
+	IOC2Type = map[string]constant.OrderType{
+		"IOC": constant.IOC,
+		"FOK": constant.FOK,
+	}
+	Binance2Status = map[string]constant.OrderStatus{
+		"NEW":              constant.OrderOpen,
+		"PARTIALLY_FILLED": constant.OrderPartialFilled,
+		"FILLED":           constant.OrderFilled,
+		"CANCELED":         constant.OrderCanceled,
+		"PENDING_CANCEL":   constant.OrderOpen,
+		"REJECTED":         constant.OrderRejected,
+		"EXPIRED":          constant.OrderCanceled,
+		"EXPIRED_IN_MATCH": constant.OrderCanceled,
+	}
 )
 
 const (
@@ -33,8 +88,25 @@ const (
 	PrivateTransferWithType = "/sapi/v1/sub-account/universalTransfer" // 权重(IP): 1
 	PrivateCurrenciesUri    = "/sapi/v1/capital/config/getall"         // 权重(IP): 10
 	PrivateDepositAddr      = "/sapi/v1/capital/deposit/address"       // 权重(IP): 10
+	UserDataStreamUri       = "/api/v3/userDataStream"                 // 权重(IP): 2
 )
 
+// 币安交易对常见的计价币种，按长度优先匹配
+var quoteCoins = []string{"FDUSD", "USDT", "USDC", "TUSD", "BUSD", "BTC", "ETH", "BNB", "TRY", "EUR"}
+
+func Symbol2Binance(symbol string) string {
+	return strings.Replace(symbol, "_", "", -1)
+}
+
+func Binance2Symbol(symbol string) string {
+	for _, quote := range quoteCoins {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return symbol[:len(symbol)-len(quote)] + "_" + quote
+		}
+	}
+	return symbol
+}
+
 func Symbol2BinanceWsInstId(symbol string) string {
 	tmp := strings.Split(symbol, "_")
 	if len(tmp) == 2 {
@@ -0,0 +1,243 @@
+package binancespot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type CurrencyConfig struct {
+	Coin        string `json:"coin"`
+	NetworkList []struct {
+		Network        string `json:"network"`
+		DepositEnable  bool   `json:"depositEnable"`
+		WithdrawEnable bool   `json:"withdrawEnable"`
+		WithdrawFee    string `json:"withdrawFee"`
+		WithdrawMin    string `json:"withdrawMin"`
+		WithdrawMax    string `json:"withdrawMax"`
+	} `json:"networkList"`
+}
+
+type DepositAddressResponse struct {
+	Address string `json:"address"`
+	Coin    string `json:"coin"`
+	Tag     string `json:"tag"`
+}
+
+type WithdrawResponse struct {
+	Id string `json:"id"`
+}
+
+type DepositRecord struct {
+	Id         string `json:"id"`
+	Amount     string `json:"amount"`
+	Coin       string `json:"coin"`
+	Network    string `json:"network"`
+	Status     int64  `json:"status"`
+	Address    string `json:"address"`
+	AddressTag string `json:"addressTag"`
+	TxId       string `json:"txId"`
+	InsertTime int64  `json:"insertTime"`
+}
+
+type WithdrawRecord struct {
+	Id              string `json:"id"`
+	Amount          string `json:"amount"`
+	TransactionFee  string `json:"transactionFee"`
+	Coin            string `json:"coin"`
+	Status          int64  `json:"status"`
+	Address         string `json:"address"`
+	AddressTag      string `json:"addressTag"`
+	TxId            string `json:"txId"`
+	ApplyTime       string `json:"applyTime"` // UTC时间 2019-10-12 11:12:02
+	Network         string `json:"network"`
+	WithdrawOrderId string `json:"withdrawOrderId"`
+}
+
+var (
+	// 0:待确认 6:已上账但不可提 7:错误充值 8:等待用户确认 1:成功
+	Binance2DepositStatus = map[int64]constant.WalletStatus{
+		0: constant.WalletPending,
+		6: constant.WalletSuccess,
+		7: constant.WalletFailed,
+		8: constant.WalletPending,
+		1: constant.WalletSuccess,
+	}
+	// 0:已发送确认邮件 1:已撤销 2:等待确认 3:被拒绝 4:处理中 5:提现交易失败 6:提现完成
+	Binance2WithdrawStatus = map[int64]constant.WalletStatus{
+		0: constant.WalletPending,
+		1: constant.WalletCanceled,
+		2: constant.WalletPending,
+		3: constant.WalletFailed,
+		4: constant.WalletPending,
+		5: constant.WalletFailed,
+		6: constant.WalletSuccess,
+	}
+)
+
+func (client *RestClient) FetchCurrencies() ([]*types.Currency, error) {
+	var response []*CurrencyConfig
+	if err := base.GetJson(client, PrivateCurrenciesUri, map[string]interface{}{}, &response); err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.Currency, 0, len(response))
+	for _, item := range response {
+		currency := &types.Currency{
+			Coin:     item.Coin,
+			Networks: make([]*types.CurrencyNetwork, 0, len(item.NetworkList)),
+		}
+		for _, network := range item.NetworkList {
+			fee, _ := utils.ParseFloat(network.WithdrawFee)
+			min, _ := utils.ParseFloat(network.WithdrawMin)
+			max, _ := utils.ParseFloat(network.WithdrawMax)
+			currency.Networks = append(currency.Networks, &types.CurrencyNetwork{
+				Chain:       network.Network,
+				CanDeposit:  network.DepositEnable,
+				CanWithdraw: network.WithdrawEnable,
+				WithdrawFee: fee,
+				WithdrawMin: min,
+				WithdrawMax: max,
+			})
+		}
+		result = append(result, currency)
+	}
+	return result, nil
+}
+
+// FetchDepositAddress chain为空时返回默认网络的地址
+func (client *RestClient) FetchDepositAddress(coin string, chain string) (*types.DepositAddress, error) {
+	param := map[string]interface{}{
+		"coin": strings.ToUpper(coin),
+	}
+	if chain != "" {
+		param["network"] = chain
+	}
+	var response DepositAddressResponse
+	if err := base.GetJson(client, PrivateDepositAddr, param, &response); err != nil {
+		return nil, err
+	}
+	return &types.DepositAddress{
+		Coin:    response.Coin,
+		Chain:   chain,
+		Address: response.Address,
+		Tag:     response.Tag,
+	}, nil
+}
+
+func (client *RestClient) Withdraw(param base.WithdrawParam) (string, error) {
+	query := map[string]interface{}{
+		"coin":    strings.ToUpper(param.Coin),
+		"network": param.Chain,
+		"address": param.Address,
+		"amount":  strconv.FormatFloat(param.Amount, 'f', -1, 64),
+	}
+	if param.Tag != "" {
+		query["addressTag"] = param.Tag
+	}
+	if param.ClientId != "" {
+		query["withdrawOrderId"] = param.ClientId
+	}
+
+	uri := PrivateWithDrawUri
+	body, res, err := client.HttpRequest(http.MethodPost, uri, query)
+	if err != nil {
+		log.Errorf("binance post /sapi/v1/capital/withdraw/apply err: %v", err)
+		return "", err
+	}
+	if res.StatusCode != 200 {
+		return "", fmt.Errorf("binance post /sapi/v1/capital/withdraw/apply err: %v %s", res.StatusCode, body)
+	}
+
+	var response WithdrawResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance post /sapi/v1/capital/withdraw/apply parser err:%v", err)
+		return "", err
+	}
+	return response.Id, nil
+}
+
+func (client *RestClient) FetchDepositHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	var response []*DepositRecord
+	if err := base.GetJson(client, FetchDepositHistoryUri, walletRecordQuery(param), &response); err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.WalletRecord, 0, len(response))
+	for _, item := range response {
+		amount, _ := utils.ParseFloat(item.Amount)
+		result = append(result, &types.WalletRecord{
+			Id:        item.Id,
+			Type:      constant.Deposit,
+			Coin:      item.Coin,
+			Chain:     item.Network,
+			Address:   item.Address,
+			Tag:       item.AddressTag,
+			TxId:      item.TxId,
+			Amount:    amount,
+			Status:    Binance2DepositStatus[item.Status],
+			RawStatus: strconv.FormatInt(item.Status, 10),
+			Ts:        item.InsertTime,
+		})
+	}
+	return result, nil
+}
+
+func (client *RestClient) FetchWithdrawHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	var response []*WithdrawRecord
+	if err := base.GetJson(client, FetchWithDrawHistoryUri, walletRecordQuery(param), &response); err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.WalletRecord, 0, len(response))
+	for _, item := range response {
+		amount, _ := utils.ParseFloat(item.Amount)
+		fee, _ := utils.ParseFloat(item.TransactionFee)
+		var ts int64
+		if applyTime, err := time.Parse(time.DateTime, item.ApplyTime); err == nil {
+			ts = applyTime.UnixMilli()
+		}
+		result = append(result, &types.WalletRecord{
+			Id:        item.Id,
+			Type:      constant.Withdraw,
+			Coin:      item.Coin,
+			Chain:     item.Network,
+			Address:   item.Address,
+			Tag:       item.AddressTag,
+			TxId:      item.TxId,
+			Amount:    amount,
+			Fee:       fee,
+			Status:    Binance2WithdrawStatus[item.Status],
+			RawStatus: strconv.FormatInt(item.Status, 10),
+			ClientId:  item.WithdrawOrderId,
+			Ts:        ts,
+		})
+	}
+	return result, nil
+}
+
+// walletRecordQuery 充提记录查询区间不能超过90天
+func walletRecordQuery(param base.WalletRecordParam) map[string]interface{} {
+	query := map[string]interface{}{}
+	if param.Coin != "" {
+		query["coin"] = strings.ToUpper(param.Coin)
+	}
+	if param.StartTime > 0 {
+		query["startTime"] = param.StartTime
+	}
+	if param.EndTime > 0 {
+		query["endTime"] = param.EndTime
+	}
+	if param.Limit > 0 {
+		query["limit"] = param.Limit
+	}
+	return query
+}
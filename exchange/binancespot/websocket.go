@@ -2,6 +2,7 @@ package binancespot
 
 import (
 	"encoding/json"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,10 +28,32 @@ type BinanceImp struct {
 	rspHandle  func(interface{})
 }
 
-func NewBinanceSpotPubWsClient(rspHandle func(interface{})) *ws.WsClient {
+func NewBinanceSpotPubWsClient(url string, rspHandle func(interface{})) *ws.WsClient {
 	imp := &BinanceImp{rspHandle: rspHandle}
-	client := ws.NewWsClient(PubWsUrl, imp, constant.BinanceSpot, 20*time.Second, 30*time.Second)
+	client := ws.NewWsClient(url, imp, constant.BinanceSpot, 20*time.Second, 30*time.Second)
+	// 订阅消息现货每秒最多5条，在写协程中按间隔发送
+	client.SetWriteInterval(200 * time.Millisecond)
+	return client
+}
 
+// NewBinanceSpotPriWsClient 每次连接(包括重连)前通过listenKey重新申请，申请失败时地址为空，Dial失败后重试
+func NewBinanceSpotPriWsClient(url, accessKey, secretKey, passphrase string, listenKey func() (string, error), rspHandle func(interface{})) *ws.WsClient {
+	imp := &BinanceImp{
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		passphrase: passphrase,
+		rspHandle:  rspHandle,
+		isPrivate:  true,
+	}
+	client := ws.NewWsClient(url, imp, constant.BinanceSpot, 20*time.Second, 30*time.Second)
+	client.SetUrlFunc(func() string {
+		key, err := listenKey()
+		if err != nil {
+			log.Errorf("binance spot GetListenKey err:%v", err)
+			return ""
+		}
+		return url + key
+	})
 	return client
 }
 
@@ -55,39 +78,103 @@ func (binance *BinanceImp) OnConnected(cli *ws.WsClient, typ ws.ConnectType) {
 	// keepAlive(cli.Conn, 20*time.Second)
 }
 
+// Subscribe 订阅 <symbol>@<topic>，symbol为空时topic为完整的频道名，如 !forceOrder@arr
 func (binance *BinanceImp) Subscribe(symbol string, topic string) map[string]interface{} {
-	params := map[string]interface{}{
-		"op": "subscribe",
-		"args": []map[string]string{
-			{
-				"channel": "bbo-tbt",
-				"instId":  Symbol2BinanceWsInstId(symbol),
-			},
-		},
+	stream := topic
+	if symbol != "" {
+		stream = Symbol2BinanceWsInstId(symbol) + "@" + topic
+	}
+	return map[string]interface{}{
+		"method": "SUBSCRIBE",
+		"params": []string{stream},
+		"id":     1,
 	}
-	return params
 }
 
 func (binance *BinanceImp) Handle(cli *ws.WsClient, bs []byte) {
+	if binance.isPrivate {
+		binance.handleUserData(cli, bs)
+		return
+	}
+
 	var dat BinanceWsData
 	if err := sonic.Unmarshal(bs, &dat); err != nil {
 		log.WithError(err).Error("unmarshal ok ws data failed, bs", bs)
 		return
 	}
 
+	// <symbol>@<channel>，有限档深度为 <symbol>@depth20@100ms
 	parts := strings.Split(dat.Stream, "@")
-	if len(parts) != 2 {
+	if len(parts) < 2 {
 		log.Errorf("Stream format is incorrect %s", dat.Stream)
 		return
 	}
 
 	channel := parts[1]
-	switch channel {
-	case "bookTicker":
+	switch {
+	case channel == "bookTicker":
 		binance.onBboTbtRecv(dat.Data)
+	case channel == "aggTrade":
+		binance.onAggTrade(dat.Data)
+	case strings.HasPrefix(channel, "depth"):
+		binance.onDepth(parts[0], dat.Data)
 	}
 }
 
+func (binance *BinanceImp) onAggTrade(data json.RawMessage) {
+	type aggTrade struct {
+		Symbol       string `json:"s"`
+		AggTradeId   int64  `json:"a"`
+		Price        string `json:"p"`
+		Quantity     string `json:"q"`
+		FirstTradeId int64  `json:"f"`
+		LastTradeId  int64  `json:"l"`
+		TradeTime    int64  `json:"T"`
+		IsBuyerMaker bool   `json:"m"`
+	}
+
+	var trade aggTrade
+	if err := sonic.Unmarshal(data, &trade); err != nil {
+		log.WithError(err).Error("unmarshal binance aggTrade failed")
+		return
+	}
+
+	price, _ := utils.ParseFloat(trade.Price)
+	size, _ := utils.ParseFloat(trade.Quantity)
+	// 买方是maker说明主动成交方向为卖
+	side := constant.OrderBuy
+	if trade.IsBuyerMaker {
+		side = constant.OrderSell
+	}
+
+	evt := &types.Trade{
+		Symbol:     Binance2Symbol(trade.Symbol),
+		MarketType: constant.BinanceSpot,
+		TradeID:    strconv.FormatInt(trade.AggTradeId, 10),
+		Side:       side,
+		Price:      price,
+		Size:       size,
+		Count:      trade.LastTradeId - trade.FirstTradeId + 1,
+		ExchangeTs: trade.TradeTime * 1000,
+		LocalTs:    utils.Microsec(time.Now()),
+		EventTs:    utils.Microsec(time.Now()),
+	}
+	binance.rspHandle([]*types.Trade{evt})
+}
+
+func depthItemsTransform(levels [][]string) []types.OrderBookItem {
+	result := make([]types.OrderBookItem, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		price, _ := utils.ParseFloat(level[0])
+		qty, _ := utils.ParseFloat(level[1])
+		result = append(result, types.OrderBookItem{Price: price, Qty: qty})
+	}
+	return result
+}
+
 func (binance *BinanceImp) onBboTbtRecv(data json.RawMessage) {
 	/***
 	{
@@ -135,6 +222,161 @@ func (binance *BinanceImp) onBboTbtRecv(data json.RawMessage) {
 	binance.rspHandle(evt)
 }
 
+// onDepth 现货有限档深度推送不带交易对，从stream名称中获取
+func (binance *BinanceImp) onDepth(instId string, data json.RawMessage) {
+	type depth struct {
+		LastUpdateId int64      `json:"lastUpdateId"`
+		Bids         [][]string `json:"bids"`
+		Asks         [][]string `json:"asks"`
+	}
+
+	var book depth
+	if err := sonic.Unmarshal(data, &book); err != nil {
+		log.WithError(err).Error("unmarshal binance depth failed")
+		return
+	}
+
+	now := utils.Microsec(time.Now())
+	evt := &types.OrderBook{
+		Symbol:     Binance2Symbol(strings.ToUpper(instId)),
+		Exchange:   constant.BinanceSpot,
+		Asks:       depthItemsTransform(book.Asks),
+		Bids:       depthItemsTransform(book.Bids),
+		ExchangeTs: now,
+		Ts:         now,
+		TraceId:    utils.RandomString(8),
+	}
+	binance.rspHandle(evt)
+}
+
+type UserDataEvent struct {
+	Event     string `json:"e"`
+	EventTime int64  `json:"E"`
+}
+
+// ExecutionReport 现货订单更新推送
+type ExecutionReport struct {
+	Event             string `json:"e"`
+	EventTime         int64  `json:"E"`
+	Symbol            string `json:"s"`
+	ClientOrderId     string `json:"c"`
+	Side              string `json:"S"`
+	OrderType         string `json:"o"`
+	TimeInForce       string `json:"f"`
+	Quantity          string `json:"q"`
+	Price             string `json:"p"`
+	OrigClientOrderId string `json:"C"` // 撤单时为原始的clientOrderId
+	ExecutionType     string `json:"x"`
+	Status            string `json:"X"`
+	RejectReason      string `json:"r"`
+	OrderId           int64  `json:"i"`
+	LastExecutedQty   string `json:"l"`
+	CumExecutedQty    string `json:"z"`
+	LastExecutedPrice string `json:"L"`
+	Commission        string `json:"n"`
+	TransactionTime   int64  `json:"T"`
+	CreateTime        int64  `json:"O"`
+	CumQuoteQty       string `json:"Z"`
+}
+
+func (r *ExecutionReport) ToOrder() *types.Order {
+	clientId := r.ClientOrderId
+	if r.OrigClientOrderId != "" {
+		clientId = r.OrigClientOrderId
+	}
+
+	orderType := Binance2Type[r.OrderType]
+	if orderType == constant.Limit && (r.TimeInForce == "IOC" || r.TimeInForce == "FOK") {
+		orderType = IOC2Type[r.TimeInForce]
+	}
+
+	var avgPrice string
+	cumQty, _ := utils.ParseFloat(r.CumExecutedQty)
+	cumQuote, _ := utils.ParseFloat(r.CumQuoteQty)
+	if cumQty > 0 {
+		avgPrice = strconv.FormatFloat(cumQuote/cumQty, 'f', -1, 64)
+	}
+
+	return &types.Order{
+		Symbol:      Binance2Symbol(r.Symbol),
+		Exchange:    constant.BinanceSpot,
+		Type:        orderType,
+		OrderID:     strconv.FormatInt(r.OrderId, 10),
+		ClientID:    clientId,
+		Side:        Binance2Side[r.Side],
+		Price:       r.Price,
+		OrigQty:     r.Quantity,
+		ExecutedQty: r.CumExecutedQty,
+		ExecutedAmt: r.CumQuoteQty,
+		AvgPrice:    avgPrice,
+		Fee:         r.Commission,
+		Status:      Binance2Status[r.Status],
+		CreateAt:    r.CreateTime,
+		UpdateAt:    r.TransactionTime,
+	}
+}
+
+// AccountPosition 现货余额变动推送，只包含发生变化的币种
+type AccountPosition struct {
+	Event      string `json:"e"`
+	EventTime  int64  `json:"E"`
+	UpdateTime int64  `json:"u"`
+	Balances   []struct {
+		Asset  string `json:"a"`
+		Free   string `json:"f"`
+		Locked string `json:"l"`
+	} `json:"B"`
+}
+
+func (p *AccountPosition) ToAssets() *types.Assets {
+	assets := make(map[string]types.Asset, len(p.Balances))
+	for _, item := range p.Balances {
+		free, _ := utils.ParseFloat(item.Free)
+		locked, _ := utils.ParseFloat(item.Locked)
+		coin := strings.ToUpper(item.Asset)
+		assets[coin] = types.Asset{
+			Coin:   coin,
+			Free:   free,
+			Frozen: locked,
+			Total:  free + locked,
+		}
+	}
+	return &types.Assets{Assets: assets}
+}
+
+func (binance *BinanceImp) handleUserData(cli *ws.WsClient, bs []byte) {
+	var evt UserDataEvent
+	if err := sonic.Unmarshal(bs, &evt); err != nil {
+		log.WithError(err).Error("unmarshal binance user data failed")
+		return
+	}
+
+	switch evt.Event {
+	case "executionReport":
+		var report ExecutionReport
+		if err := sonic.Unmarshal(bs, &report); err != nil {
+			log.WithError(err).Error("unmarshal binance executionReport failed")
+			return
+		}
+		binance.rspHandle([]*types.Order{report.ToOrder()})
+	case "outboundAccountPosition":
+		var position AccountPosition
+		if err := sonic.Unmarshal(bs, &position); err != nil {
+			log.WithError(err).Error("unmarshal binance outboundAccountPosition failed")
+			return
+		}
+		binance.rspHandle(position.ToAssets())
+	case "listenKeyExpired":
+		// 重连时会申请新的listenKey
+		log.Errorf("binance spot listenKey expired %s", bs)
+		cli.Reconnect()
+	case "balanceUpdate":
+		// 充提和划转引起的余额变化，之后会推送outboundAccountPosition
+	default:
+		log.Warnf("unknown binance user data %s", bs)
+	}
+}
+
 func keepAlive(c *websocket.Conn, timeout time.Duration) {
 	ticker := time.NewTicker(timeout)
 
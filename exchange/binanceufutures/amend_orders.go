@@ -0,0 +1,55 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// AmendBatchOrders 逐个调用 PUT /fapi/v1/order 修改限价单的价格和数量，订单ID不变
+func (client *RestClient) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		param := formAmendRequest(order)
+
+		uri := AmendOrderRest
+		body, res, err := client.HttpRequest(http.MethodPut, uri, param)
+		if err != nil {
+			log.Errorf("binance PUT /fapi/v1/order err: %v", err)
+			result = append(result, orderErrTransform(order, -1, err.Error()))
+			continue
+		}
+		if res.StatusCode != 200 {
+			log.Errorf("binance PUT /fapi/v1/order err: %v %s", res.StatusCode, body)
+			result = append(result, ordersFailTransform([]*types.Order{order}, body)...)
+			continue
+		}
+
+		var orderResponse OrderResponse
+		if err = json.Unmarshal(body, &orderResponse); err != nil {
+			log.Errorf("binance PUT /fapi/v1/order parsing JSON err: %v", err)
+			result = append(result, orderErrTransform(order, -1, err.Error()))
+			continue
+		}
+		result = append(result, orderTransform(&orderResponse))
+	}
+	return base.NativeAmendResults(orders, result), nil
+}
+
+// formAmendRequest 改单必须同时带上side、quantity和price
+func formAmendRequest(order *types.Order) map[string]interface{} {
+	result := map[string]interface{}{
+		"symbol":   Symbol2Binance(order.Symbol),
+		"side":     Side2Binance[order.Side.Name()][0],
+		"quantity": order.OrigQty,
+		"price":    order.Price,
+	}
+	if order.OrderID != "" {
+		result["orderId"] = order.OrderID
+	} else {
+		result["origClientOrderId"] = order.ClientID
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// CancelBatchOrders 批量撤单只能针对同一个交易对，且orderId和clientOrderId不能混用，按此分组后每组最多10个
+func (client *RestClient) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	type groupKey struct {
+		symbol    string
+		byOrderId bool
+	}
+	keys := make([]groupKey, 0)
+	groups := make(map[groupKey][]*types.Order)
+	for _, order := range orders {
+		key := groupKey{symbol: order.Symbol, byOrderId: order.OrderID != ""}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], order)
+	}
+
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, key := range keys {
+		group := groups[key]
+		for start := 0; start < len(group); start += MaxBatchCancelOrders {
+			end := start + MaxBatchCancelOrders
+			if end > len(group) {
+				end = len(group)
+			}
+			result = append(result, client.cancelBatchOrders(group[start:end], key.byOrderId)...)
+		}
+	}
+	return result, nil
+}
+
+func (client *RestClient) cancelBatchOrders(orders []*types.Order, byOrderId bool) []*types.OrderResult {
+	param := formCancelRequest(orders, byOrderId)
+
+	uri := CancelMoreOrderRest
+	body, res, err := client.HttpRequest(http.MethodDelete, uri, param)
+	if err != nil {
+		log.Errorf("binance DELETE /fapi/v1/batchOrders err: %v", err)
+		return ordersErrTransform(orders, -1, err.Error())
+	}
+	if res.StatusCode != 200 {
+		log.Errorf("binance DELETE /fapi/v1/batchOrders err: %v %s", res.StatusCode, body)
+		return ordersFailTransform(orders, body)
+	}
+
+	var response []BatchOrderResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance DELETE /fapi/v1/batchOrders parsing JSON err: %v", err)
+		return ordersErrTransform(orders, -1, err.Error())
+	}
+	return batchOrderTransform(orders, response)
+}
+
+func formCancelRequest(orders []*types.Order, byOrderId bool) map[string]interface{} {
+	result := map[string]interface{}{
+		"symbol": Symbol2Binance(orders[0].Symbol),
+	}
+	if byOrderId {
+		ids := make([]json.Number, 0, len(orders))
+		for _, order := range orders {
+			ids = append(ids, json.Number(order.OrderID))
+		}
+		orderIdList, _ := json.Marshal(ids)
+		result["orderIdList"] = string(orderIdList)
+	} else {
+		ids := make([]string, 0, len(orders))
+		for _, order := range orders {
+			ids = append(ids, order.ClientID)
+		}
+		clientIdList, _ := json.Marshal(ids)
+		result["origClientOrderIdList"] = string(clientIdList)
+	}
+	return result
+}
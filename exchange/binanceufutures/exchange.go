@@ -2,7 +2,7 @@ package binanceufutures
 
 import (
 	"fmt"
-	"time"
+	"sync/atomic"
 
 	"github.com/cybernonce/gotrader/exchange/base"
 	"github.com/cybernonce/gotrader/pkg/ws"
@@ -17,24 +17,44 @@ type BinanceUFuturesExchange struct {
 	pubWsClient *ws.WsClient
 	priWsClient *ws.WsClient
 
+	// 私有连接当前使用的listenKey，每次连接前重新申请
+	listenKey atomic.Value
+
+	// FundingRate/MarkPrice/IndexPrice共用markPrice频道，按订阅的交易对分发
+	markPrices *base.MarkPriceDispatcher
+
+	// symbols为空时订阅全市场强平频道，按订阅的交易对过滤
+	liquidations *base.LiquidationFilter
+
 	// callbacks
 	onBooktickerCallback func(*types.BookTicker)
+	onTradeCallback      func([]*types.Trade)
+	onOrderBookCallback  func(*types.OrderBook)
 	onOrderCallback      func([]*types.Order)
+	onBalanceCallback    func(*types.Assets)
+	onPositionCallback   func([]*types.Position)
 }
 
 func NewBinanceUFutures(params *types.ExchangeParameters) *BinanceUFuturesExchange {
 	apiKey := params.AccessKey
 	secretKey := params.SecretKey
 	passPhrase := params.Passphrase
+	endpoints := base.MustResolveEndpoints(params, Environments)
+	transport := base.MustNewTransport(params)
 
 	// new client
 	client := NewRestClient(apiKey, secretKey, passPhrase, constant.BinanceUFutures)
+	client.SetEndpoints(endpoints)
+	client.SetHttpClient(transport.HttpClient)
 	exchange := &BinanceUFuturesExchange{
 		exchangeType: constant.BinanceUFutures,
 		restClient:   client,
+		markPrices:   base.NewMarkPriceDispatcher(),
+		liquidations: base.NewLiquidationFilter(),
 	}
 	// pubWsClient
-	pubWsClient := NewBinanceUFuturesPubWsClient(exchange.OnPubWsHandle)
+	pubWsClient := NewBinanceUFuturesPubWsClient(endpoints.PubWsUrl, exchange.OnPubWsHandle)
+	pubWsClient.SetDialer(transport.WsDialer)
 	if err := pubWsClient.Dial(ws.Connect); err != nil {
 		log.Errorf("pubWsClient.Dial err %s", err)
 	} else {
@@ -42,16 +62,18 @@ func NewBinanceUFutures(params *types.ExchangeParameters) *BinanceUFuturesExchan
 		log.Infof("pubWsClient.Dial success")
 	}
 
-	// // priWsClient
-	// if len(apiKey) > 0 {
-	// 	priWsClient := NewOkPriWsClient(apiKey, secretKey, passPhrase, exchange.OnPriWsHandle)
-	// 	if err := priWsClient.Dial(ws.Connect); err != nil {
-	// 		log.Errorf("priWsClient.Dial err %s", err)
-	// 	} else {
-	// 		exchange.priWsClient = priWsClient
-	// 		log.Infof("priWsClient.Dial success")
-	// 	}
-	// }
+	// priWsClient
+	if len(apiKey) > 0 {
+		priWsClient := NewBinanceUFuturesPriWsClient(endpoints.PriWsUrl, apiKey, secretKey, passPhrase, exchange.newListenKey, exchange.OnPriWsHandle)
+		priWsClient.SetDialer(transport.WsDialer)
+		if err := priWsClient.Dial(ws.Connect); err != nil {
+			log.Errorf("priWsClient.Dial err %s", err)
+		} else {
+			exchange.priWsClient = priWsClient
+			log.Infof("priWsClient.Dial success")
+			exchange.KeepUserStream()
+		}
+	}
 	return exchange
 }
 
@@ -64,26 +86,69 @@ func (binance *BinanceUFuturesExchange) GetType() (typ constant.ExchangeType) {
 	return binance.exchangeType
 }
 
+func (binance *BinanceUFuturesExchange) GetListenKey() (string, error) {
+	return binance.restClient.GetListenKey()
+}
+
+// newListenKey 申请新的listenKey并记录下来用于定时延长
+func (binance *BinanceUFuturesExchange) newListenKey() (string, error) {
+	listenKey, err := binance.GetListenKey()
+	if err != nil {
+		return "", err
+	}
+	binance.listenKey.Store(listenKey)
+	return listenKey, nil
+}
+
+func (binance *BinanceUFuturesExchange) currentListenKey() string {
+	listenKey, _ := binance.listenKey.Load().(string)
+	return listenKey
+}
+
+// KeepUserStream 定时延长listenKey，延长失败时重连私有连接，重连会申请新的listenKey
+func (binance *BinanceUFuturesExchange) KeepUserStream() {
+	go binance.restClient.KeepUserStream(binance.currentListenKey, binance.priWsClient.Reconnect)
+}
+
+// StopUserStream 停止定时延长listenKey
+func (binance *BinanceUFuturesExchange) StopUserStream() {
+	binance.restClient.StopUserStream()
+}
+
 func (binance *BinanceUFuturesExchange) FetchSymbols() ([]*types.SymbolInfo, error) {
 	return binance.restClient.FetchSymbols()
 }
 
 func (binance *BinanceUFuturesExchange) FetchBalance() (*types.Assets, error) {
-	return nil, fmt.Errorf("not impl")
+	return binance.restClient.FetchBalance()
 }
 
 func (binance *BinanceUFuturesExchange) FetchAssetBalance() (*types.Assets, error) {
 	return nil, fmt.Errorf("FetchAssetBalance not imp")
 }
 
-func (binance *BinanceUFuturesExchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+func (binance *BinanceUFuturesExchange) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	return binance.restClient.FetchOpenOrders(symbol)
+}
 
-	return nil, fmt.Errorf("not impl")
+func (binance *BinanceUFuturesExchange) FetchOrder(order *types.Order) (*types.Order, error) {
+	return binance.restClient.FetchOrder(order)
+}
+
+func (binance *BinanceUFuturesExchange) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return binance.restClient.FetchUserTrades(param)
+}
+
+func (binance *BinanceUFuturesExchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return binance.restClient.CreateBatchOrders(orders)
 }
 
 func (binance *BinanceUFuturesExchange) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return binance.restClient.CancelBatchOrders(orders)
+}
 
-	return nil, fmt.Errorf("not impl")
+func (binance *BinanceUFuturesExchange) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	return binance.restClient.AmendBatchOrders(orders)
 }
 
 func (binance *BinanceUFuturesExchange) FetchTickers() ([]*types.Ticker, error) {
@@ -91,19 +156,39 @@ func (binance *BinanceUFuturesExchange) FetchTickers() ([]*types.Ticker, error)
 }
 
 func (binance *BinanceUFuturesExchange) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
-	return nil, fmt.Errorf("not impl")
+	return binance.restClient.FetchKline(symbol, interval, limit)
+}
+
+func (binance *BinanceUFuturesExchange) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return binance.restClient.FetchHistoryKline(param)
 }
 
 func (binance *BinanceUFuturesExchange) FetchFundingRate(symbol string) (*types.FundingRate, error) {
-	return nil, fmt.Errorf("not impl")
+	return binance.restClient.FetchFundingRate(symbol)
 }
 
 func (binance *BinanceUFuturesExchange) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
-	return nil, fmt.Errorf("not impl")
+	return binance.restClient.FetchFundingRateHistory(symbol, limit)
 }
 
 func (binance *BinanceUFuturesExchange) FetchPositons() ([]*types.Position, error) {
-	return nil, fmt.Errorf("not impl")
+	return binance.restClient.FetchPositons()
+}
+
+// SetLeverage 先设置保证金模式再调整杠杆
+func (binance *BinanceUFuturesExchange) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	if err := binance.restClient.SetMarginType(symbol, marginMode); err != nil {
+		return err
+	}
+	return binance.restClient.SetLeverage(symbol, leverage)
+}
+
+func (binance *BinanceUFuturesExchange) SetPositionMode(mode constant.PositionMode) error {
+	return binance.restClient.SetPositionMode(mode)
+}
+
+func (binance *BinanceUFuturesExchange) FetchPositionMode() (constant.PositionMode, error) {
+	return binance.restClient.FetchPositionMode()
 }
 
 func (binance *BinanceUFuturesExchange) PrivateTransfer(transfer base.TransferParam) (string, error) {
@@ -121,34 +206,98 @@ func (binance *BinanceUFuturesExchange) Subscribe(params map[string]interface{})
 }
 
 func (binance *BinanceUFuturesExchange) SubscribeBookTicker(symbols []string, callback func(*types.BookTicker)) (err error) {
+	binance.onBooktickerCallback = callback
+	return binance.subscribeStreams(symbols, "bookTicker")
+}
+
+// SubscribeTrades 订阅归集成交
+func (binance *BinanceUFuturesExchange) SubscribeTrades(symbols []string, callback func([]*types.Trade)) (err error) {
+	binance.onTradeCallback = callback
+	return binance.subscribeStreams(symbols, "aggTrade")
+}
+
+// SubscribeOrderBook 订阅20档有限深度，每次推送完整的20档
+func (binance *BinanceUFuturesExchange) SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) (err error) {
+	binance.onOrderBookCallback = callback
+	return binance.subscribeStreams(symbols, DepthStream)
+}
+
+// subscribeStreams 按交易对逐个订阅 <symbol>@<stream>，断线重连后自动重新订阅
+func (binance *BinanceUFuturesExchange) subscribeStreams(symbols []string, stream string) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
 	for _, symbol := range symbols {
-		var args []string
-		args = append(args, fmt.Sprintf("%s@bookTicker", Symbol2BinanceWsInstId(symbol)))
+		binance.pubWsClient.Subscribe(symbol, stream)
+	}
+	return nil
+}
 
-		params := map[string]interface{}{
-			"method": "SUBSCRIBE",
-			"params": args,
-			"id":     1,
-		}
+func (binance *BinanceUFuturesExchange) SubscribeFundingRates(symbols []string, callback func(*types.FundingRate)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	binance.markPrices.AddFundingRates(symbols, callback)
+	return binance.subscribeStreams(symbols, MarkPriceStream)
+}
 
-		if binance.pubWsClient == nil {
-			return fmt.Errorf("pubWsClient is nil")
-		}
+func (binance *BinanceUFuturesExchange) SubscribeMarkPrices(symbols []string, callback func(*types.MarkPrice)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	binance.markPrices.AddMarkPrices(symbols, callback)
+	return binance.subscribeStreams(symbols, MarkPriceStream)
+}
 
-		if err := binance.pubWsClient.Write(params); err != nil {
-			return fmt.Errorf("Subscribe err: %s", err)
-		}
-		binance.onBooktickerCallback = callback
+func (binance *BinanceUFuturesExchange) SubscribeIndexPrices(symbols []string, callback func(*types.IndexPrice)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	binance.markPrices.AddIndexPrices(symbols, callback)
+	return binance.subscribeStreams(symbols, MarkPriceStream)
+}
+
+func (binance *BinanceUFuturesExchange) SubscribeOpenInterest(symbols []string, callback func(*types.OpenInterest)) error {
+	return fmt.Errorf("not impl")
+}
 
-		time.Sleep(200 * time.Millisecond)
+// SubscribeLiquidations symbols为空时订阅 !forceOrder@arr
+func (binance *BinanceUFuturesExchange) SubscribeLiquidations(symbols []string, callback func(*types.Liquidation)) error {
+	if binance.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
 	}
+	binance.liquidations.Add(symbols, callback)
 
+	if len(symbols) > 0 {
+		return binance.subscribeStreams(symbols, ForceOrderStream)
+	}
+	binance.pubWsClient.Subscribe("", AllForceOrderStream)
 	return nil
 }
 
+// SubscribeOrders 用户数据流推送全部交易对的订单，symbols不做过滤
 func (binance *BinanceUFuturesExchange) SubscribeOrders(symbols []string, callback func(orders []*types.Order)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onOrderCallback = callback
+	return nil
+}
 
-	return fmt.Errorf("not impl")
+func (binance *BinanceUFuturesExchange) SubscribeBalance(callback func(*types.Assets)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onBalanceCallback = callback
+	return nil
+}
+
+func (binance *BinanceUFuturesExchange) SubscribePositions(callback func([]*types.Position)) (err error) {
+	if binance.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	binance.onPositionCallback = callback
+	return nil
 }
 
 func (binance *BinanceUFuturesExchange) OnPubWsHandle(data interface{}) {
@@ -161,9 +310,42 @@ func (binance *BinanceUFuturesExchange) OnPubWsHandle(data interface{}) {
 			log.Errorf("OnBookTicker Callback not set")
 		}
 	case *types.OrderBook:
-		fmt.Println("OrderBook type", v)
-	case *types.Trade:
-		fmt.Println("Trade type", v)
+		if binance.onOrderBookCallback != nil {
+			binance.onOrderBookCallback(v)
+		} else {
+			log.Errorf("onOrderBook Callback not set")
+		}
+	case []*types.Trade:
+		if binance.onTradeCallback != nil {
+			binance.onTradeCallback(v)
+		} else {
+			log.Errorf("onTrade Callback not set")
+		}
+	case *base.MarkPriceUpdate:
+		binance.markPrices.Dispatch(v)
+	case *types.Liquidation:
+		binance.liquidations.Dispatch(v)
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
+
+func (binance *BinanceUFuturesExchange) OnPriWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case []*types.Order:
+		if binance.onOrderCallback != nil {
+			binance.onOrderCallback(v)
+		} else {
+			log.Errorf("onOrder Callback not set")
+		}
+	case *types.Assets:
+		if binance.onBalanceCallback != nil {
+			binance.onBalanceCallback(v)
+		}
+	case []*types.Position:
+		if binance.onPositionCallback != nil {
+			binance.onPositionCallback(v)
+		}
 	default:
 		log.Errorf("Unknown type %s", v)
 	}
@@ -0,0 +1,104 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type AccountResponse struct {
+	TotalWalletBalance    string         `json:"totalWalletBalance"`
+	TotalUnrealizedProfit string         `json:"totalUnrealizedProfit"`
+	TotalMarginBalance    string         `json:"totalMarginBalance"`
+	TotalInitialMargin    string         `json:"totalInitialMargin"`
+	TotalMaintMargin      string         `json:"totalMaintMargin"`
+	AvailableBalance      string         `json:"availableBalance"`
+	Assets                []AccountAsset `json:"assets"`
+}
+
+type AccountAsset struct {
+	Asset            string `json:"asset"`
+	WalletBalance    string `json:"walletBalance"`
+	UnrealizedProfit string `json:"unrealizedProfit"`
+	MarginBalance    string `json:"marginBalance"`
+	AvailableBalance string `json:"availableBalance"`
+	UpdateTime       int64  `json:"updateTime"`
+}
+
+func (client *RestClient) FetchBalance() (*types.Assets, error) {
+	uri := BalanceRest
+	body, res, err := client.HttpRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		log.Errorf("binance FetchBalance 网络错误:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /fapi/v2/account err: %v %s", res.StatusCode, body)
+	}
+
+	response := new(AccountResponse)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("binance get /fapi/v2/account parser err:%v", err)
+		return nil, err
+	}
+
+	result, err := balanceTransform(response)
+	if err != nil {
+		err := fmt.Errorf("binance get /fapi/v2/account transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func balanceTransform(response *AccountResponse) (*types.Assets, error) {
+	assets := make(map[string]types.Asset)
+	for _, item := range response.Assets {
+		total, err := utils.ParseFloat(item.MarginBalance)
+		if err != nil {
+			log.Errorf("futures binance fetchBalance marginBalance参数转换失败:%v", err)
+			return nil, err
+		}
+		free, err := utils.ParseFloat(item.AvailableBalance)
+		if err != nil {
+			log.Errorf("futures binance fetchBalance availableBalance参数转换失败:%v", err)
+			return nil, err
+		}
+		if total == 0 {
+			continue
+		}
+
+		coin := strings.ToUpper(item.Asset)
+		assets[coin] = types.Asset{
+			Coin:   coin,
+			Free:   free,
+			Frozen: total - free,
+			Total:  total,
+		}
+	}
+
+	totalEq, _ := utils.ParseFloat(response.TotalMarginBalance)
+	freeEq, _ := utils.ParseFloat(response.AvailableBalance)
+	maintMargin, _ := utils.ParseFloat(response.TotalMaintMargin)
+	initialMargin, _ := utils.ParseFloat(response.TotalInitialMargin)
+
+	// 与okx的mgnRatio保持一致，保证金余额/维持保证金
+	var uniMMR float64
+	if maintMargin > 0 {
+		uniMMR = totalEq / maintMargin
+	}
+	var accountMargin float64
+	if totalEq > 0 {
+		accountMargin = initialMargin / totalEq
+	}
+	return &types.Assets{
+		Assets:        assets,
+		TotalUsdEq:    totalEq,
+		FreeUsdEq:     freeEq,
+		UniMMR:        uniMMR,
+		AccountMargin: accountMargin,
+	}, nil
+}
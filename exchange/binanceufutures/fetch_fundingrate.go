@@ -0,0 +1,138 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type PremiumIndex struct {
+	Symbol          string `json:"symbol"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	LastFundingRate string `json:"lastFundingRate"`
+	InterestRate    string `json:"interestRate"`
+	NextFundingTime int64  `json:"nextFundingTime"`
+	Time            int64  `json:"time"`
+}
+
+type FundingInfo struct {
+	Symbol               string `json:"symbol"`
+	FundingIntervalHours int64  `json:"fundingIntervalHours"`
+}
+
+// FetchFundingRate lastFundingRate为本期(nextFundingTime结算)的预测费率，
+// 与okx保持一致：FundingTime为本期结算时间，NextFundingTime为下一期结算时间
+func (client *RestClient) FetchFundingRate(symbol string) (*types.FundingRate, error) {
+	queryDict := map[string]interface{}{}
+	queryDict["symbol"] = Symbol2Binance(symbol)
+	url := client.restUrl + fmt.Sprintf("%s?%s", MarkPriceRest, utils.UrlEncodeParams(queryDict))
+
+	body, res, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("binance get /fapi/v1/premiumIndex err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /fapi/v1/premiumIndex err: %v %s", res.StatusCode, body)
+	}
+
+	response := new(PremiumIndex)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("binance get /fapi/v1/premiumIndex parser err:%v", err)
+		return nil, err
+	}
+
+	interval := client.fetchFundingInterval(symbol)
+	result, err := fundingRateTransform(response, interval)
+	if err != nil {
+		err := fmt.Errorf("binance get /fapi/v1/premiumIndex transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// fundingInfoTTL 资金费率间隔很少调整，fundingInfo的结果缓存一段时间
+const fundingInfoTTL = time.Hour
+
+// fundingIntervalCache 缓存各交易对的资金费率结算间隔，零值可用
+type fundingIntervalCache struct {
+	mutex     sync.Mutex
+	intervals map[string]time.Duration
+	expireAt  time.Time
+}
+
+func (c *fundingIntervalCache) get(now time.Time) (map[string]time.Duration, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.intervals == nil || now.After(c.expireAt) {
+		return nil, false
+	}
+	return c.intervals, true
+}
+
+func (c *fundingIntervalCache) set(intervals map[string]time.Duration, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.intervals = intervals
+	c.expireAt = now.Add(fundingInfoTTL)
+}
+
+// fetchFundingInterval 获取资金费率结算间隔，失败时使用默认的8小时
+func (client *RestClient) fetchFundingInterval(symbol string) time.Duration {
+	intervals, ok := client.fundingIntervals.get(time.Now())
+	if !ok {
+		var err error
+		if intervals, err = client.fetchFundingInfo(); err != nil {
+			return DefaultFundingIntervalHours * time.Hour
+		}
+		client.fundingIntervals.set(intervals, time.Now())
+	}
+	if interval, ok := intervals[Symbol2Binance(symbol)]; ok {
+		return interval
+	}
+	return DefaultFundingIntervalHours * time.Hour
+}
+
+func (client *RestClient) fetchFundingInfo() (map[string]time.Duration, error) {
+	body, res, err := client.HttpGet(client.restUrl + FundingInfoRest)
+	if err != nil {
+		log.Errorf("binance get /fapi/v1/fundingInfo err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		log.Errorf("binance get /fapi/v1/fundingInfo err: %v %s", res.StatusCode, body)
+		return nil, fmt.Errorf("binance get /fapi/v1/fundingInfo err: %v", res.StatusCode)
+	}
+
+	var infos []FundingInfo
+	if err = json.Unmarshal(body, &infos); err != nil {
+		log.Errorf("binance get /fapi/v1/fundingInfo parser err:%v", err)
+		return nil, err
+	}
+	intervals := make(map[string]time.Duration, len(infos))
+	for _, info := range infos {
+		if info.FundingIntervalHours > 0 {
+			intervals[info.Symbol] = time.Duration(info.FundingIntervalHours) * time.Hour
+		}
+	}
+	return intervals, nil
+}
+
+func fundingRateTransform(response *PremiumIndex, interval time.Duration) (*types.FundingRate, error) {
+	rate, err := utils.ParseFloat(response.LastFundingRate)
+	if err != nil {
+		return nil, err
+	}
+	return &types.FundingRate{
+		Symbol:          Binance2Symbol(response.Symbol),
+		Method:          "current_period",
+		FundingRate:     rate,
+		FundingTime:     response.NextFundingTime,
+		NextFundingTime: response.NextFundingTime + interval.Milliseconds(),
+	}, nil
+}
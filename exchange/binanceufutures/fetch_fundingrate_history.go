@@ -0,0 +1,64 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type FundingRateHistory struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+	MarkPrice   string `json:"markPrice"`
+}
+
+func (client *RestClient) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
+	queryDict := map[string]interface{}{}
+	queryDict["symbol"] = Symbol2Binance(symbol)
+	queryDict["limit"] = limit
+	url := client.restUrl + fmt.Sprintf("%s?%s", FundingRateRest, utils.UrlEncodeParams(queryDict))
+
+	body, res, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("binance get /fapi/v1/fundingRate err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /fapi/v1/fundingRate err: %v %s", res.StatusCode, body)
+	}
+
+	var response []FundingRateHistory
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /fapi/v1/fundingRate parser err:%v", err)
+		return nil, err
+	}
+
+	if len(response) == 0 {
+		err := fmt.Errorf("binance get /fapi/v1/fundingRate empty")
+		return nil, err
+	}
+
+	return fundingRateHistoryTransform(response), nil
+}
+
+// fundingRateHistoryTransform 币安按时间正序返回，与okx保持一致转为倒序
+func fundingRateHistoryTransform(response []FundingRateHistory) []*types.FundingRate {
+	result := make([]*types.FundingRate, 0, len(response))
+	for i := len(response) - 1; i >= 0; i-- {
+		fr := response[i]
+		rate, err := utils.ParseFloat(fr.FundingRate)
+		if err != nil {
+			log.Errorf("parser FundingRateHistory FundingRate err %s", err)
+			continue
+		}
+		result = append(result, &types.FundingRate{
+			Symbol:      Binance2Symbol(fr.Symbol),
+			FundingRate: rate,
+			FundingTime: fr.FundingTime,
+		})
+	}
+	return result
+}
@@ -0,0 +1,22 @@
+package binanceufutures
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFundingIntervalCache(t *testing.T) {
+	var cache fundingIntervalCache
+	now := time.Now()
+	if _, ok := cache.get(now); ok {
+		t.Fatal("empty cache should miss")
+	}
+	cache.set(map[string]time.Duration{"BTCUSDT": 4 * time.Hour}, now)
+	intervals, ok := cache.get(now.Add(fundingInfoTTL - time.Second))
+	if !ok || intervals["BTCUSDT"] != 4*time.Hour {
+		t.Fatalf("unexpected cache hit %v %v", ok, intervals)
+	}
+	if _, ok := cache.get(now.Add(fundingInfoTTL + time.Second)); ok {
+		t.Fatal("expired cache should miss")
+	}
+}
@@ -0,0 +1,133 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+	"github.com/spf13/cast"
+)
+
+// 单次请求最多返回的K线数量
+const maxKlineLimit = 1500
+
+type KlineResponse [][]interface{}
+
+func (client *RestClient) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
+	param := map[string]interface{}{
+		"symbol":   Symbol2Binance(symbol),
+		"interval": interval,
+		"limit":    limit,
+	}
+	return client.fetchKline(param)
+}
+
+// FetchHistoryKline 按startTime向后翻页拉取区间内的K线，未指定startTime时从endTime向前翻页
+func (client *RestClient) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return base.PageKlines(param, maxKlineLimit, func(startTime, endTime, limit int64) ([]types.Kline, error) {
+		query := map[string]interface{}{
+			"symbol":   Symbol2Binance(param.Symbol),
+			"interval": param.Interval,
+			"endTime":  endTime,
+			"limit":    limit,
+		}
+		if startTime > 0 {
+			query["startTime"] = startTime
+		}
+		return client.fetchKline(query)
+	})
+}
+
+func (client *RestClient) fetchKline(param map[string]interface{}) ([]types.Kline, error) {
+	url := client.restUrl + fmt.Sprintf(OhlcvRest, utils.UrlEncodeParams(param))
+	body, res, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("binance get /fapi/v1/klines err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /fapi/v1/klines err: %v %s", res.StatusCode, body)
+	}
+
+	response := make(KlineResponse, 0)
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /fapi/v1/klines parser err:%v", err)
+		return nil, err
+	}
+
+	result, err := klineTransform(response, utils.Millisec(time.Now()))
+	if err != nil {
+		err := fmt.Errorf("binance get /fapi/v1/klines transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func klineTransform(response KlineResponse, now int64) ([]types.Kline, error) {
+	/***
+	[
+	  [
+	    1499040000000,      // 开盘时间
+	    "0.01634790",       // 开盘价
+	    "0.80000000",       // 最高价
+	    "0.01575800",       // 最低价
+	    "0.01577100",       // 收盘价(当前K线未结束的即为最新价)
+	    "148976.11427815",  // 成交量
+	    1499644799999,      // 收盘时间
+	    ...
+	  ]
+	]
+	***/
+	result := make([]types.Kline, 0, len(response))
+	for _, dat := range response {
+		if len(dat) < 7 {
+			return nil, fmt.Errorf("kline data len less 7 %v", len(dat))
+		}
+		ts, err := cast.ToInt64E(dat[0])
+		if err != nil {
+			return nil, err
+		}
+		open, err := cast.ToFloat64E(dat[1])
+		if err != nil {
+			return nil, err
+		}
+		high, err := cast.ToFloat64E(dat[2])
+		if err != nil {
+			return nil, err
+		}
+		low, err := cast.ToFloat64E(dat[3])
+		if err != nil {
+			return nil, err
+		}
+		close, err := cast.ToFloat64E(dat[4])
+		if err != nil {
+			return nil, err
+		}
+		vol, err := cast.ToFloat64E(dat[5])
+		if err != nil {
+			return nil, err
+		}
+		closeTime, err := cast.ToInt64E(dat[6])
+		if err != nil {
+			return nil, err
+		}
+
+		var confirm int64
+		if closeTime < now {
+			confirm = 1
+		}
+		result = append(result, types.Kline{
+			Ts:      ts,
+			Open:    open,
+			High:    high,
+			Low:     low,
+			Close:   close,
+			Vol:     vol,
+			Confirm: confirm,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// OrderInfo 查询订单接口返回的订单信息
+type OrderInfo struct {
+	OrderResponse
+	Time int64 `json:"time"`
+}
+
+// FetchOpenOrders 查询当前挂单，symbol为空时查询全部交易对
+func (client *RestClient) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	param := map[string]interface{}{}
+	if symbol != "" {
+		param["symbol"] = Symbol2Binance(symbol)
+	}
+
+	uri := OpenOrderRest
+	body, res, err := client.HttpRequest(http.MethodGet, uri, param)
+	if err != nil {
+		log.Errorf("binance get /fapi/v1/openOrders err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /fapi/v1/openOrders err: %v %s", res.StatusCode, body)
+	}
+
+	var response []*OrderInfo
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /fapi/v1/openOrders parser err:%v", err)
+		return nil, err
+	}
+
+	result := make([]*types.Order, 0, len(response))
+	for _, info := range response {
+		result = append(result, info.ToOrder())
+	}
+	return result, nil
+}
+
+// FetchOrder 按OrderID或ClientID查询订单
+func (client *RestClient) FetchOrder(order *types.Order) (*types.Order, error) {
+	param := map[string]interface{}{
+		"symbol": Symbol2Binance(order.Symbol),
+	}
+	if order.OrderID != "" {
+		param["orderId"] = order.OrderID
+	} else {
+		param["origClientOrderId"] = order.ClientID
+	}
+
+	uri := OrderRest
+	body, res, err := client.HttpRequest(http.MethodGet, uri, param)
+	if err != nil {
+		log.Errorf("binance get /fapi/v1/order err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /fapi/v1/order err: %v %s", res.StatusCode, body)
+	}
+
+	var response OrderInfo
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /fapi/v1/order parser err:%v", err)
+		return nil, err
+	}
+	return response.ToOrder(), nil
+}
+
+func (info *OrderInfo) ToOrder() *types.Order {
+	orderType := Binance2Type[info.Type]
+	if typ, ok := TimeInForce2Type[info.TimeInForce]; ok && orderType == constant.Limit {
+		orderType = typ
+	}
+
+	var executedAmt string
+	cumQty, _ := utils.ParseFloat(info.ExecutedQty)
+	if cumQty > 0 {
+		executedAmt = info.CumQuote
+	}
+
+	return &types.Order{
+		Symbol:      Binance2Symbol(info.Symbol),
+		Exchange:    constant.BinanceUFutures,
+		Type:        orderType,
+		OrderID:     strconv.FormatInt(info.OrderId, 10),
+		ClientID:    info.ClientOrderId,
+		Side:        getOrderSide(info.Side, info.PositionSide),
+		Price:       info.Price,
+		OrigQty:     info.OrigQty,
+		ExecutedQty: info.ExecutedQty,
+		ExecutedAmt: executedAmt,
+		AvgPrice:    info.AvgPrice,
+		Status:      Binance2Status[info.Status],
+		ReduceOnly:  info.ReduceOnly,
+		CreateAt:    info.Time,
+		UpdateAt:    info.UpdateTime,
+	}
+}
@@ -0,0 +1,97 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type PositionInfo struct {
+	Symbol           string  `json:"symbol"`
+	PositionAmt      float64 `json:"positionAmt,string"`
+	EntryPrice       float64 `json:"entryPrice,string"`
+	MarkPrice        float64 `json:"markPrice,string"`
+	UnRealizedProfit float64 `json:"unRealizedProfit,string"`
+	LiquidationPrice float64 `json:"liquidationPrice,string"`
+	Leverage         float64 `json:"leverage,string"`
+	MarginType       string  `json:"marginType"`
+	IsolatedMargin   float64 `json:"isolatedMargin,string"`
+	PositionSide     string  `json:"positionSide"`
+	Notional         float64 `json:"notional,string"`
+	UpdateTime       int64   `json:"updateTime"`
+}
+
+func (client *RestClient) FetchPositons() ([]*types.Position, error) {
+	uri := PositionRest
+	body, res, err := client.HttpRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		log.Errorf("binance FetchPositons 网络错误:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		log.Errorf("binance FetchPositons %v %s", res.StatusCode, body)
+		return nil, fmt.Errorf("%s", body)
+	}
+
+	var positions []PositionInfo
+	if err = json.Unmarshal(body, &positions); err != nil {
+		log.Errorf("binance get /fapi/v2/positionRisk parser err:%v", err)
+		return nil, err
+	}
+	result, err := positionTransform(positions)
+	if err != nil {
+		err := fmt.Errorf("binance FetchPositons transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func positionTransform(response []PositionInfo) ([]*types.Position, error) {
+	result := make([]*types.Position, 0, len(response))
+	for _, item := range response {
+		if math.Abs(item.PositionAmt) == 0 {
+			continue
+		}
+		// 全仓模式下按名义价值/杠杆估算占用保证金
+		margin := item.IsolatedMargin
+		if item.MarginType != "isolated" && item.Leverage > 0 {
+			margin = math.Abs(item.Notional) / item.Leverage
+		}
+		info := &types.Position{
+			MarginMode:    Binance2MarginMode[item.MarginType],
+			Symbol:        Binance2Symbol(item.Symbol),
+			LiquidationPx: item.LiquidationPrice,
+			Position:      math.Abs(item.PositionAmt),
+			AvgCost:       item.EntryPrice,
+			UnrealisedPnl: item.UnRealizedProfit,
+			Last:          item.MarkPrice,
+			Margin:        margin,
+			Leverage:      item.Leverage,
+		}
+		info.Side = getPositionSide(item.PositionSide, item.PositionAmt)
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// getPositionSide 单向持仓按数量正负判断方向，持仓为0时为空表示已平仓
+func getPositionSide(side string, pos float64) string {
+	switch side {
+	case "BOTH":
+		if pos > 0 {
+			return constant.Long.Name()
+		}
+		if pos < 0 {
+			return constant.Short.Name()
+		}
+		return ""
+	case "LONG":
+		return constant.Long.Name()
+	default:
+		return constant.Short.Name()
+	}
+}
@@ -22,7 +22,7 @@ type Symbol struct {
 }
 
 func (client *RestClient) FetchSymbols() ([]*types.SymbolInfo, error) {
-	url := RestUrl + SymbolsRest
+	url := client.restUrl + SymbolsRest
 
 	body, _, err := client.HttpGet(url)
 	if err != nil {
@@ -28,7 +28,7 @@ func (t *TickerInfo) valid() bool {
 }
 
 func (client *RestClient) FetchTickers() ([]*types.Ticker, error) {
-	url := RestUrl + TickerRest
+	url := client.restUrl + TickerRest
 	body, _, err := client.HttpGet(url)
 	if err != nil {
 		log.Errorf("futures binance FetchTicker 网络错误:%v", err)
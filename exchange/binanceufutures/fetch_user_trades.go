@@ -0,0 +1,87 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 单次请求最多返回的成交数量
+const maxUserTradeLimit = 1000
+
+type UserTrade struct {
+	Symbol          string `json:"symbol"`
+	Id              int64  `json:"id"`
+	OrderId         int64  `json:"orderId"`
+	Side            string `json:"side"`
+	PositionSide    string `json:"positionSide"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	RealizedPnl     string `json:"realizedPnl"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	Maker           bool   `json:"maker"`
+}
+
+// FetchUserTrades 查询账户成交明细，symbol必填，startTime和endTime间隔不能超过7天
+func (client *RestClient) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return base.PageUserTrades(Symbol2Binance(param.Symbol), param, maxUserTradeLimit, client.fetchUserTrades)
+}
+
+func (client *RestClient) fetchUserTrades(param map[string]interface{}) ([]*types.Fill, error) {
+	uri := UserTread
+	body, res, err := client.HttpRequest(http.MethodGet, uri, param)
+	if err != nil {
+		log.Errorf("binance get /fapi/v1/userTrades err:%v", err)
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("binance get /fapi/v1/userTrades err: %v %s", res.StatusCode, body)
+	}
+
+	var response []*UserTrade
+	if err = json.Unmarshal(body, &response); err != nil {
+		log.Errorf("binance get /fapi/v1/userTrades parser err:%v", err)
+		return nil, err
+	}
+	return userTradesTransform(response), nil
+}
+
+func userTradesTransform(response []*UserTrade) []*types.Fill {
+	result := make([]*types.Fill, 0, len(response))
+	for _, item := range response {
+		result = append(result, item.ToFill())
+	}
+	return result
+}
+
+func (t *UserTrade) ToFill() *types.Fill {
+	price, _ := utils.ParseFloat(t.Price)
+	qty, _ := utils.ParseFloat(t.Qty)
+	fee, _ := utils.ParseFloat(t.Commission)
+	fill := &types.Fill{
+		Symbol:   Binance2Symbol(t.Symbol),
+		Exchange: constant.BinanceUFutures,
+		TradeID:  strconv.FormatInt(t.Id, 10),
+		OrderID:  strconv.FormatInt(t.OrderId, 10),
+		Side:     getOrderSide(t.Side, t.PositionSide),
+		Price:    price,
+		Qty:      qty,
+		Fee:      fee,
+		FeeCoin:  t.CommissionAsset,
+		Role:     constant.Taker,
+		Ts:       t.Time,
+	}
+	if t.Maker {
+		fill.Role = constant.Maker
+	}
+	return fill
+}
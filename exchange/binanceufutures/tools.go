@@ -3,12 +3,75 @@ package binanceufutures
 import (
 	"fmt"
 	"strings"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/constant"
 )
 
 var (
 	RestUrl  = "https://fapi.binance.com"
 	PubWsUrl = "wss://fstream.binance.com/stream"
 	PriWsUrl = "wss://fstream.binance.com/ws/"
+
+	// Environments 各环境的地址，通过ExchangeParameters.Environment选择
+	Environments = map[constant.Environment]base.Endpoints{
+		constant.EnvProduction: {
+			RestUrl:  RestUrl,
+			PubWsUrl: PubWsUrl,
+			PriWsUrl: PriWsUrl,
+		},
+		constant.EnvTestnet: {
+			RestUrl:  "https://testnet.binancefuture.com",
+			PubWsUrl: "wss://fstream.binancefuture.com/stream",
+			PriWsUrl: "wss://fstream.binancefuture.com/ws/",
+		},
+	}
+
+	// 有限档深度频道
+	DepthStream = "depth20@100ms"
+
+	// 下单方向对应的 side 和 positionSide，单向持仓模式positionSide为空
+	Side2Binance = map[string][2]string{
+		constant.OrderBuy.Name():   {"BUY", ""},
+		constant.OrderSell.Name():  {"SELL", ""},
+		constant.Long.Name():       {"BUY", "LONG"},
+		constant.Short.Name():      {"SELL", "SHORT"},
+		constant.CloseLong.Name():  {"SELL", "LONG"},
+		constant.CloseShort.Name(): {"BUY", "SHORT"},
+	}
+	TimeInForce2Binance = map[string]string{
+		constant.Limit.Name():    "GTC",
+		constant.GTC.Name():      "GTC",
+		constant.IOC.Name():      "IOC",
+		constant.FOK.Name():      "FOK",
+		constant.PostOnly.Name(): "GTX",
+	}
+	Binance2Side = map[string]constant.OrderSide{
+		"BUY":  constant.OrderBuy,
+		"SELL": constant.OrderSell,
+	}
+	Binance2Type = map[string]constant.OrderType{
+		"LIMIT":  constant.Limit,
+		"MARKET": constant.Market,
+	}
+	TimeInForce2Type = map[string]constant.OrderType{
+		"IOC": constant.IOC,
+		"FOK": constant.FOK,
+		"GTX": constant.PostOnly,
+	}
+	Binance2Status = map[string]constant.OrderStatus{
+		"NEW":              constant.OrderOpen,
+		"PARTIALLY_FILLED": constant.OrderPartialFilled,
+		"FILLED":           constant.OrderFilled,
+		"CANCELED":         constant.OrderCanceled,
+		"REJECTED":         constant.OrderRejected,
+		"EXPIRED":          constant.OrderCanceled,
+		"EXPIRED_IN_MATCH": constant.OrderCanceled,
+	}
+	Binance2MarginMode = map[string]string{
+		"isolated": "FIXED",
+		"cross":    "CROSSED",
+	}
 )
 
 // binanceF rest 接口url
@@ -20,12 +83,15 @@ const (
 	FetchHistoryTrade = "/fapi/v1/aggTrades"    // 权重 20
 	TradeRest         = "/fapi/v1/trades?%s"    // 权重 5
 	MarkPriceRest     = "/fapi/v1/premiumIndex" // 权重 1
+	FundingRateRest   = "/fapi/v1/fundingRate"  // 与 /fapi/v1/fundingInfo 共享 500/5min/IP
+	FundingInfoRest   = "/fapi/v1/fundingInfo"  // 只返回调整过资金费率间隔或上下限的交易对
 	//私有接口
 	CancelAllOrderRest  = "/fapi/v1/allOpenOrders" // 撤所有单接口 权重 1
 	CancelOneOrderRest  = "/fapi/v1/order"         // 撤单接口 权重 1
 	CancelMoreOrderRest = "/fapi/v1/batchOrders"   // 批量撤单接口 权重 1
 	CreatOneOrderRest   = "/fapi/v1/order"         // 挂单接口 权重 0
 	CreatMoreOrderRest  = "/fapi/v1/batchOrders"   // 批量挂单接口 权重 5
+	AmendOrderRest      = "/fapi/v1/order"         // 改单接口 权重 1
 	BalanceRest         = "/fapi/v2/account"       // 权重 5
 	OpenOrderRest       = "/fapi/v1/openOrders"    // 带 symbol：权重 1，不带 symbol：权重 40
 	OrderRest           = "/fapi/v1/order"         // 权重 1
@@ -34,9 +100,53 @@ const (
 	RiskLimitRest       = "/fapi/v2/positionRisk"  // 权重 5
 	FetchLeverage       = "/fapi/v2/account"
 	SetLeverage         = "/fapi/v1/leverage"
-	FetchFundingFeeRest = "/fapi/v1/income" // 权重 30
+	FetchFundingFeeRest = "/fapi/v1/income"            // 权重 30
+	ListenKeyRest       = "/fapi/v1/listenKey"         // 权重 1
+	MarginTypeRest      = "/fapi/v1/marginType"        // 权重 1
+	PositionSideRest    = "/fapi/v1/positionSide/dual" // 权重 1
+
+	// 批量下单每次最多5个，批量撤单每次最多10个
+	MaxBatchCreateOrders = 5
+	MaxBatchCancelOrders = 10
+
+	// 默认资金费率结算间隔(小时)
+	DefaultFundingIntervalHours = 8
 )
 
+// 币安交易对常见的计价币种，按长度优先匹配
+var quoteCoins = []string{"FDUSD", "USDT", "USDC", "TUSD", "BUSD", "BTC", "ETH", "BNB", "TRY", "EUR"}
+
+func Symbol2Binance(symbol string) string {
+	return strings.Replace(symbol, "_", "", -1)
+}
+
+func Binance2Symbol(symbol string) string {
+	for _, quote := range quoteCoins {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return symbol[:len(symbol)-len(quote)] + "_" + quote
+		}
+	}
+	return symbol
+}
+
+// getOrderSide 双向持仓模式下结合positionSide还原开平方向
+func getOrderSide(side, positionSide string) constant.OrderSide {
+	switch positionSide {
+	case "LONG":
+		if side == "BUY" {
+			return constant.Long
+		}
+		return constant.CloseLong
+	case "SHORT":
+		if side == "SELL" {
+			return constant.Short
+		}
+		return constant.CloseShort
+	default:
+		return Binance2Side[side]
+	}
+}
+
 func Symbol2BinanceWsInstId(symbol string) string {
 	tmp := strings.Split(symbol, "_")
 	if len(tmp) == 2 {
@@ -0,0 +1,56 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+func TestAccountUpdate(t *testing.T) {
+	msg := `{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"a":{"m":"ORDER",
+		"B":[{"a":"USDT","wb":"122624.12345678","cw":"100.12345678","bc":"50.12345678"}],
+		"P":[{"s":"BTCUSDT","pa":"0","ep":"0.00000","up":"0","mt":"cross","iw":"0","ps":"BOTH"},
+			{"s":"ETHUSDT","pa":"-2","ep":"3000","up":"1.5","mt":"isolated","iw":"300","ps":"BOTH"},
+			{"s":"BNBUSDT","pa":"0","ep":"0","up":"0","mt":"cross","iw":"0","ps":"LONG"}]}}`
+	var update AccountUpdate
+	if err := json.Unmarshal([]byte(msg), &update); err != nil {
+		t.Fatal(err)
+	}
+
+	usdt := update.ToAssets().Assets["USDT"]
+	if usdt.Total != 122624.12345678 || usdt.Free != 100.12345678 || usdt.Frozen != usdt.Total-usdt.Free {
+		t.Fatalf("unexpected asset %+v", usdt)
+	}
+
+	positions := update.ToPositions()
+	if positions[0].Side != "" || positions[0].Position != 0 {
+		t.Fatalf("flat one-way position %+v", positions[0])
+	}
+	if positions[1].Side != constant.Short.Name() || positions[1].Position != 2 {
+		t.Fatalf("short position %+v", positions[1])
+	}
+	if positions[2].Side != constant.Long.Name() {
+		t.Fatalf("hedge long position %+v", positions[2])
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	imp := &BinanceImp{}
+	tests := []struct {
+		symbol string
+		topic  string
+		stream string
+	}{
+		{"BTC_USDT", "bookTicker", "btcusdt@bookTicker"},
+		{"ETH_USDT", DepthStream, "ethusdt@" + DepthStream},
+		{"", AllForceOrderStream, AllForceOrderStream},
+	}
+	for _, tt := range tests {
+		params := imp.Subscribe(tt.symbol, tt.topic)
+		streams := params["params"].([]string)
+		if params["method"] != "SUBSCRIBE" || len(streams) != 1 || streams[0] != tt.stream {
+			t.Fatalf("subscribe %s %s: unexpected %v", tt.symbol, tt.topic, params)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/binancecommon"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+// 强平订单频道，每个交易对每秒最多推送一条最新的强平单
+const (
+	ForceOrderStream    = "forceOrder"
+	AllForceOrderStream = "!forceOrder@arr"
+)
+
+func (binance *BinanceImp) onForceOrder(data json.RawMessage) {
+	var evt binancecommon.ForceOrder
+	if err := sonic.Unmarshal(data, &evt); err != nil {
+		log.WithError(err).Error("unmarshal binance forceOrder failed")
+		return
+	}
+	binance.rspHandle(evt.ToLiquidation(constant.BinanceUFutures, Binance2Symbol(evt.Order.Symbol)))
+}
@@ -0,0 +1,40 @@
+package binanceufutures
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestOnForceOrder(t *testing.T) {
+	tests := []struct {
+		msg        string
+		symbol     string
+		side       constant.OrderSide
+		price      float64
+		size       float64
+		exchangeTs int64
+	}{
+		{
+			msg: `{"e":"forceOrder","E":1568014460893,"o":{"s":"BTCUSDT","S":"SELL","o":"LIMIT","f":"IOC","q":"0.014",
+				"p":"9910","ap":"9910.5","X":"FILLED","l":"0.014","z":"0.012","T":1568014460890}}`,
+			symbol: "BTC_USDT", side: constant.OrderSell, price: 9910.5, size: 0.012, exchangeTs: 1568014460890000,
+		},
+		// 未成交时使用委托价和委托数量
+		{
+			msg:    `{"e":"forceOrder","E":1,"o":{"s":"ETHUSDT","S":"BUY","q":"1.5","p":"2000","ap":"0","z":"0","T":2}}`,
+			symbol: "ETH_USDT", side: constant.OrderBuy, price: 2000, size: 1.5, exchangeTs: 2000,
+		},
+	}
+	for _, tt := range tests {
+		var liquidation *types.Liquidation
+		imp := &BinanceImp{rspHandle: func(data interface{}) { liquidation = data.(*types.Liquidation) }}
+		imp.onForceOrder([]byte(tt.msg))
+		if liquidation == nil || liquidation.Symbol != tt.symbol || liquidation.Exchange != constant.BinanceUFutures ||
+			liquidation.Side != tt.side || liquidation.Price != tt.price || liquidation.Size != tt.size ||
+			liquidation.ExchangeTs != tt.exchangeTs {
+			t.Fatalf("unexpected liquidation %+v", liquidation)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package binanceufutures
+
+import (
+	"encoding/json"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/binancecommon"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+// MarkPriceStream 每秒推送标记价格、指数价格和资金费率
+const MarkPriceStream = "markPrice@1s"
+
+// onMarkPrice U本位合约的交易对与指数名称相同
+func (binance *BinanceImp) onMarkPrice(data json.RawMessage) {
+	var price binancecommon.MarkPrice
+	if err := sonic.Unmarshal(data, &price); err != nil {
+		log.WithError(err).Error("unmarshal binance markPrice failed")
+		return
+	}
+	symbol := Binance2Symbol(price.Symbol)
+	binance.rspHandle(price.ToUpdate(constant.BinanceUFutures, symbol, symbol))
+}
@@ -0,0 +1,39 @@
+package binanceufutures
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+func TestOnMarkPrice(t *testing.T) {
+	tests := []struct {
+		msg         string
+		symbol      string
+		markPrice   float64
+		indexPrice  float64
+		fundingRate float64
+		exchangeTs  int64
+	}{
+		{
+			msg: `{"e":"markPriceUpdate","E":1562305380000,"s":"BTCUSDT","p":"11794.15000000","ap":"11794.15000000",
+				"P":"11784.62659091","i":"11784.25641265","r":"0.00038167","T":1562306400000}`,
+			symbol: "BTC_USDT", markPrice: 11794.15, indexPrice: 11784.25641265, fundingRate: 0.00038167, exchangeTs: 1562305380000000,
+		},
+		{
+			msg:    `{"e":"markPriceUpdate","E":1562305381000,"s":"ETHUSDC","p":"2000.5","P":"2001","i":"2000.1","r":"-0.0001","T":1562306400000}`,
+			symbol: "ETH_USDC", markPrice: 2000.5, indexPrice: 2000.1, fundingRate: -0.0001, exchangeTs: 1562305381000000,
+		},
+	}
+	for _, tt := range tests {
+		var update *base.MarkPriceUpdate
+		imp := &BinanceImp{rspHandle: func(data interface{}) { update = data.(*base.MarkPriceUpdate) }}
+		imp.onMarkPrice([]byte(tt.msg))
+		if update == nil || update.Symbol != tt.symbol || update.IndexSymbol != tt.symbol || update.Exchange != constant.BinanceUFutures ||
+			update.MarkPrice != tt.markPrice || update.IndexPrice != tt.indexPrice || update.FundingRate != tt.fundingRate ||
+			update.NextFundingTime != 1562306400000 || update.ExchangeTs != tt.exchangeTs {
+			t.Fatalf("unexpected update %+v", update)
+		}
+	}
+}
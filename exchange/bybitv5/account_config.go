@@ -0,0 +1,82 @@
+package bybitv5
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+const positionModeNotModified = 110025 // 持仓模式未变化
+
+var (
+	// 统一账户的保证金模式是账户级别的
+	MarginMode2Bybit = map[constant.MarginMode]string{
+		constant.MarginCross:    "REGULAR_MARGIN",
+		constant.MarginIsolated: "ISOLATED_MARGIN",
+	}
+
+	// 0:单向持仓 3:双向持仓
+	PositionMode2Bybit = map[constant.PositionMode]int{
+		constant.OneWayMode: 0,
+		constant.HedgeMode:  3,
+	}
+)
+
+// SetLeverage 设置合约杠杆，多空使用相同杠杆
+// 统一账户不支持按交易对设置逐仓，需要逐仓时使用SetMarginMode修改账户的保证金模式
+func (client *RestClient) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	if client.exchangeType != constant.BybitV5Linear {
+		return fmt.Errorf("not impl")
+	}
+	if marginMode != constant.MarginCross {
+		return fmt.Errorf("bybit set leverage not support margin mode %s", marginMode.Name())
+	}
+	param := map[string]interface{}{
+		"category":     client.category(),
+		"symbol":       Symbol2Bybit(symbol, client.exchangeType),
+		"buyLeverage":  fmt.Sprintf("%d", leverage),
+		"sellLeverage": fmt.Sprintf("%d", leverage),
+	}
+	return client.postAccountConfig(SetLeverageUri, param, leverageNotModified)
+}
+
+// SetMarginMode 修改统一账户的保证金模式，对所有交易对生效
+func (client *RestClient) SetMarginMode(marginMode constant.MarginMode) error {
+	mode, ok := MarginMode2Bybit[marginMode]
+	if !ok {
+		return fmt.Errorf("bybit not support margin mode %s", marginMode.Name())
+	}
+	param := map[string]interface{}{
+		"setMarginMode": mode,
+	}
+	return client.postAccountConfig(SetMarginModeUri, param, 0)
+}
+
+// SetPositionMode 按结算币种修改USDT和USDC永续的持仓模式，有持仓或挂单时不能修改
+func (client *RestClient) SetPositionMode(mode constant.PositionMode) error {
+	if client.exchangeType != constant.BybitV5Linear {
+		return fmt.Errorf("not impl")
+	}
+	for _, settleCoin := range settleCoins {
+		param := map[string]interface{}{
+			"category": client.category(),
+			"coin":     settleCoin,
+			"mode":     PositionMode2Bybit[mode],
+		}
+		if err := client.postAccountConfig(SwitchPositionModeUri, param, positionModeNotModified); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postAccountConfig ignoreCode为配置未变化时返回的错误码，视为成功
+func (client *RestClient) postAccountConfig(uri string, param map[string]interface{}, ignoreCode int) error {
+	response := new(BaseBybitRsp)
+	err := client.request(http.MethodPost, uri, param, response)
+	if err != nil && ignoreCode != 0 && response.RetCode == ignoreCode {
+		return nil
+	}
+	return err
+}
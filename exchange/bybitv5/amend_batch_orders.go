@@ -0,0 +1,26 @@
+package bybitv5
+
+import (
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// AmendBatchOrders 原生改单，Order.Price/OrigQty为改单后的值
+func (client *RestClient) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	results, err := client.batchOrders(AmendBatchOrderUri, orders, client.formAmendRequest)
+	if err != nil {
+		return nil, err
+	}
+	return base.NativeAmendResults(orders, results), nil
+}
+
+func (client *RestClient) formAmendRequest(order *types.Order) map[string]interface{} {
+	result := client.formCancelRequest(order)
+	if order.Price != "" {
+		result["price"] = order.Price
+	}
+	if order.OrigQty != "" {
+		result["qty"] = order.OrigQty
+	}
+	return result
+}
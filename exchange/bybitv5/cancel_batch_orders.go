@@ -0,0 +1,21 @@
+package bybitv5
+
+import (
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func (client *RestClient) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return client.batchOrders(CancelBatchOrderUri, orders, client.formCancelRequest)
+}
+
+func (client *RestClient) formCancelRequest(order *types.Order) map[string]interface{} {
+	result := map[string]interface{}{
+		"symbol": Symbol2Bybit(order.Symbol, client.exchangeType),
+	}
+	if order.OrderID != "" {
+		result["orderId"] = order.OrderID
+	} else {
+		result["orderLinkId"] = order.ClientID
+	}
+	return result
+}
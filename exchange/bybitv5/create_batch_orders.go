@@ -0,0 +1,139 @@
+package bybitv5
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type BatchOrderResult struct {
+	Symbol      string `json:"symbol"`
+	OrderId     string `json:"orderId"`
+	OrderLinkId string `json:"orderLinkId"`
+}
+
+// BatchOrderRsp 每笔订单的结果在retExtInfo中，与result.list按顺序对应
+type BatchOrderRsp struct {
+	BaseBybitRsp
+	Result struct {
+		List []*BatchOrderResult `json:"list"`
+	} `json:"result"`
+	RetExtInfo struct {
+		List []struct {
+			Code int32  `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"list"`
+	} `json:"retExtInfo"`
+}
+
+func (client *RestClient) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return client.batchOrders(CreateBatchOrderUri, orders, client.formRequest)
+}
+
+// batchOrders 超过单次上限时拆分成多次请求
+func (client *RestClient) batchOrders(uri string, orders []*types.Order, form func(*types.Order) map[string]interface{}) ([]*types.OrderResult, error) {
+	startTime := time.Now()
+	result := make([]*types.OrderResult, 0, len(orders))
+	for start := 0; start < len(orders); start += maxBatchOrders {
+		end := start + maxBatchOrders
+		if end > len(orders) {
+			end = len(orders)
+		}
+		request := make([]map[string]interface{}, 0, end-start)
+		for _, order := range orders[start:end] {
+			request = append(request, form(order))
+		}
+		param := map[string]interface{}{
+			"category": client.category(),
+			"request":  request,
+		}
+		response := new(BatchOrderRsp)
+		if err := client.request(http.MethodPost, uri, param, response); err != nil {
+			if start == 0 {
+				return nil, err
+			}
+			// 之前的批次已经生效，返回已有结果，失败批次的订单逐个返回错误
+			for _, order := range orders[start:end] {
+				result = append(result, orderErrTransform(order, err.Error()))
+			}
+			return result, nil
+		}
+		result = append(result, batchOrderTransform(response)...)
+	}
+	log.Infof("HTTP cost time: %v", time.Since(startTime))
+	return result, nil
+}
+
+func batchOrderTransform(response *BatchOrderRsp) []*types.OrderResult {
+	result := make([]*types.OrderResult, 0, len(response.Result.List))
+	for i, item := range response.Result.List {
+		info := &types.OrderResult{
+			IsSuccess: true,
+			OrderId:   item.OrderId,
+			ClientId:  item.OrderLinkId,
+		}
+		if i < len(response.RetExtInfo.List) {
+			ext := response.RetExtInfo.List[i]
+			info.IsSuccess = ext.Code == 0
+			info.ErrCode = ext.Code
+			info.ErrMsg = ext.Msg
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+func orderErrTransform(order *types.Order, msg string) *types.OrderResult {
+	return &types.OrderResult{
+		IsSuccess: false,
+		OrderId:   order.OrderID,
+		ClientId:  order.ClientID,
+		ErrCode:   -1,
+		ErrMsg:    msg,
+	}
+}
+
+func (client *RestClient) formRequest(order *types.Order) map[string]interface{} {
+	result := map[string]interface{}{
+		"symbol": Symbol2Bybit(order.Symbol, client.exchangeType),
+		"side":   Side2Bybit[order.Side],
+		"qty":    order.OrigQty,
+	}
+	switch order.Type {
+	case constant.Market:
+		result["orderType"] = "Market"
+		if client.exchangeType == constant.BybitV5Spot {
+			// 现货市价单默认按quote数量，统一使用base数量
+			result["marketUnit"] = "baseCoin"
+		}
+	case constant.IOC:
+		result["orderType"] = "Limit"
+		result["timeInForce"] = "IOC"
+	case constant.FOK:
+		result["orderType"] = "Limit"
+		result["timeInForce"] = "FOK"
+	case constant.PostOnly:
+		result["orderType"] = "Limit"
+		result["timeInForce"] = "PostOnly"
+	default:
+		result["orderType"] = "Limit"
+		result["timeInForce"] = "GTC"
+	}
+	if order.Type != constant.Market {
+		result["price"] = order.Price
+	}
+	if order.ClientID != "" {
+		result["orderLinkId"] = order.ClientID
+	}
+	if client.exchangeType == constant.BybitV5Linear {
+		if idx, ok := Side2PositionIdx[order.Side]; ok {
+			result["positionIdx"] = idx
+		}
+		if order.ReduceOnly || order.Side == constant.CloseLong || order.Side == constant.CloseShort {
+			result["reduceOnly"] = true
+		}
+	}
+	return result
+}
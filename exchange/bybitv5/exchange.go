@@ -0,0 +1,266 @@
+package bybitv5
+
+import (
+	"fmt"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type BybitV5Exchange struct {
+	exchangeType constant.ExchangeType
+
+	restClient  *RestClient
+	pubWsClient *ws.WsClient
+	priWsClient *ws.WsClient
+
+	// callbacks
+	onBooktickerCallback func(*types.BookTicker)
+	onTradeCallback      func([]*types.Trade)
+	onOrderBookCallback  func(*types.OrderBook)
+	onOrderCallback      func([]*types.Order)
+	onBalanceCallback    func(*types.Assets)
+	onPositionCallback   func([]*types.Position)
+}
+
+func NewBybitV5Spot(params *types.ExchangeParameters) *BybitV5Exchange {
+	return newBybitV5Exchange(params, constant.BybitV5Spot)
+}
+
+// NewBybitV5Linear USDT/USDC永续合约，symbol格式为 BTC_USDT
+func NewBybitV5Linear(params *types.ExchangeParameters) *BybitV5Exchange {
+	return newBybitV5Exchange(params, constant.BybitV5Linear)
+}
+
+func newBybitV5Exchange(params *types.ExchangeParameters, exchangeType constant.ExchangeType) *BybitV5Exchange {
+	apiKey := params.AccessKey
+	secretKey := params.SecretKey
+	endpoints := base.MustResolveEndpoints(params, environments(exchangeType))
+	transport := base.MustNewTransport(params)
+
+	// new client
+	client := NewRestClient(apiKey, secretKey, exchangeType)
+	client.SetEndpoints(endpoints)
+	client.SetHttpClient(transport.HttpClient)
+	exchange := &BybitV5Exchange{
+		exchangeType: exchangeType,
+		restClient:   client,
+	}
+
+	// pubWsClient
+	pubWsClient := NewBybitPubWsClient(endpoints.PubWsUrl, exchangeType, exchange.OnPubWsHandle)
+	pubWsClient.SetDialer(transport.WsDialer)
+	if err := pubWsClient.Dial(ws.Connect); err != nil {
+		log.Errorf("pubWsClient.Dial err %s", err)
+	} else {
+		exchange.pubWsClient = pubWsClient
+		log.Infof("pubWsClient.Dial success")
+	}
+
+	// priWsClient
+	if len(apiKey) > 0 {
+		priWsClient := NewBybitPriWsClient(endpoints.PriWsUrl, apiKey, secretKey, exchangeType, exchange.OnPriWsHandle)
+		priWsClient.SetDialer(transport.WsDialer)
+		if err := priWsClient.Dial(ws.Connect); err != nil {
+			log.Errorf("priWsClient.Dial err %s", err)
+		} else {
+			exchange.priWsClient = priWsClient
+			log.Infof("priWsClient.Dial success")
+		}
+	}
+	return exchange
+}
+
+func (bybit *BybitV5Exchange) GetName() (name string) {
+	return bybit.exchangeType.Name()
+}
+
+func (bybit *BybitV5Exchange) GetType() (typ constant.ExchangeType) {
+	return bybit.exchangeType
+}
+
+func (bybit *BybitV5Exchange) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
+	return bybit.restClient.FetchKline(symbol, interval, limit)
+}
+
+func (bybit *BybitV5Exchange) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return bybit.restClient.FetchHistoryKline(param)
+}
+
+func (bybit *BybitV5Exchange) FetchFundingRate(symbol string) (*types.FundingRate, error) {
+	return bybit.restClient.FetchFundingRate(symbol)
+}
+
+func (bybit *BybitV5Exchange) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
+	return bybit.restClient.FetchFundingRateHistory(symbol, limit)
+}
+
+func (bybit *BybitV5Exchange) FetchSymbols() ([]*types.SymbolInfo, error) {
+	return bybit.restClient.FetchSymbols()
+}
+
+func (bybit *BybitV5Exchange) FetchTickers() ([]*types.Ticker, error) {
+	return bybit.restClient.FetchTickers()
+}
+
+func (bybit *BybitV5Exchange) FetchBalance() (*types.Assets, error) {
+	return bybit.restClient.FetchBalance()
+}
+
+func (bybit *BybitV5Exchange) FetchAssetBalance() (*types.Assets, error) {
+	return bybit.restClient.FetchAssetBalance()
+}
+
+func (bybit *BybitV5Exchange) FetchPositons() ([]*types.Position, error) {
+	return bybit.restClient.FetchPositons()
+}
+
+func (bybit *BybitV5Exchange) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	return bybit.restClient.FetchOpenOrders(symbol)
+}
+
+func (bybit *BybitV5Exchange) FetchOrder(order *types.Order) (*types.Order, error) {
+	return bybit.restClient.FetchOrder(order)
+}
+
+func (bybit *BybitV5Exchange) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return bybit.restClient.FetchUserTrades(param)
+}
+
+func (bybit *BybitV5Exchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return bybit.restClient.CreateBatchOrders(orders)
+}
+
+func (bybit *BybitV5Exchange) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return bybit.restClient.CancelBatchOrders(orders)
+}
+
+func (bybit *BybitV5Exchange) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	return bybit.restClient.AmendBatchOrders(orders)
+}
+
+func (bybit *BybitV5Exchange) PrivateTransfer(transfer base.TransferParam) (string, error) {
+	return "", fmt.Errorf("not impl")
+}
+
+func (bybit *BybitV5Exchange) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	return bybit.restClient.SetLeverage(symbol, leverage, marginMode)
+}
+
+// SetMarginMode 修改统一账户的保证金模式，对所有交易对生效
+func (bybit *BybitV5Exchange) SetMarginMode(marginMode constant.MarginMode) error {
+	return bybit.restClient.SetMarginMode(marginMode)
+}
+
+func (bybit *BybitV5Exchange) SetPositionMode(mode constant.PositionMode) error {
+	return bybit.restClient.SetPositionMode(mode)
+}
+
+func (bybit *BybitV5Exchange) Subscribe(params map[string]interface{}) error {
+	if bybit.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	if err := bybit.pubWsClient.Write(params); err != nil {
+		return fmt.Errorf("Subscribe err: %s", err)
+	}
+	return nil
+}
+
+// SubscribeBookTicker 订阅一档深度，本地合并增量后推送
+func (bybit *BybitV5Exchange) SubscribeBookTicker(symbols []string, callback func(*types.BookTicker)) error {
+	return bybit.subscribePublic(symbols, BookTickerTopic, func() { bybit.onBooktickerCallback = callback })
+}
+
+func (bybit *BybitV5Exchange) SubscribeTrades(symbols []string, callback func([]*types.Trade)) error {
+	return bybit.subscribePublic(symbols, TradeTopic, func() { bybit.onTradeCallback = callback })
+}
+
+// SubscribeOrderBook 订阅50档增量深度，回调为本地合并后的完整订单簿
+func (bybit *BybitV5Exchange) SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) error {
+	return bybit.subscribePublic(symbols, OrderBookTopic, func() { bybit.onOrderBookCallback = callback })
+}
+
+func (bybit *BybitV5Exchange) subscribePublic(symbols []string, topic string, setCallback func()) error {
+	if bybit.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	setCallback()
+	for _, symbol := range symbols {
+		bybit.pubWsClient.Subscribe(symbol, topic)
+	}
+	return nil
+}
+
+// SubscribeOrders order频道推送所有交易对的订单，symbols仅用于保持接口一致
+func (bybit *BybitV5Exchange) SubscribeOrders(symbols []string, callback func(orders []*types.Order)) error {
+	return bybit.subscribePrivate(OrderTopic, func() { bybit.onOrderCallback = callback })
+}
+
+func (bybit *BybitV5Exchange) SubscribeBalance(callback func(*types.Assets)) error {
+	return bybit.subscribePrivate(WalletTopic, func() { bybit.onBalanceCallback = callback })
+}
+
+func (bybit *BybitV5Exchange) SubscribePositions(callback func([]*types.Position)) error {
+	return bybit.subscribePrivate(PositionTopic, func() { bybit.onPositionCallback = callback })
+}
+
+func (bybit *BybitV5Exchange) subscribePrivate(topic string, setCallback func()) error {
+	if bybit.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	setCallback()
+	bybit.priWsClient.Subscribe("", topic)
+	return nil
+}
+
+func (bybit *BybitV5Exchange) OnPubWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case *types.BookTicker:
+		if bybit.onBooktickerCallback != nil {
+			bybit.onBooktickerCallback(v)
+		} else {
+			log.Errorf("OnBookTicker Callback not set")
+		}
+	case *types.OrderBook:
+		if bybit.onOrderBookCallback != nil {
+			bybit.onOrderBookCallback(v)
+		} else {
+			log.Errorf("onOrderBook Callback not set")
+		}
+	case []*types.Trade:
+		if bybit.onTradeCallback != nil {
+			bybit.onTradeCallback(v)
+		} else {
+			log.Errorf("onTrade Callback not set")
+		}
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
+
+func (bybit *BybitV5Exchange) OnPriWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case []*types.Order:
+		if bybit.onOrderCallback != nil {
+			bybit.onOrderCallback(v)
+		} else {
+			log.Errorf("onOrder Callback not set")
+		}
+	case *types.Assets:
+		if bybit.onBalanceCallback != nil {
+			bybit.onBalanceCallback(v)
+		} else {
+			log.Errorf("onBalance Callback not set")
+		}
+	case []*types.Position:
+		if bybit.onPositionCallback != nil {
+			bybit.onPositionCallback(v)
+		} else {
+			log.Errorf("onPosition Callback not set")
+		}
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
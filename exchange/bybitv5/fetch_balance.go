@@ -0,0 +1,122 @@
+package bybitv5
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// BybitWallet 统一账户余额，rest和ws wallet频道共用
+type BybitWallet struct {
+	AccountType            string             `json:"accountType"`
+	TotalEquity            string             `json:"totalEquity"`
+	TotalMarginBalance     string             `json:"totalMarginBalance"`
+	TotalAvailableBalance  string             `json:"totalAvailableBalance"`
+	TotalInitialMargin     string             `json:"totalInitialMargin"`
+	TotalMaintenanceMargin string             `json:"totalMaintenanceMargin"`
+	AccountMMRate          string             `json:"accountMMRate"` // 维持保证金率，越小越安全
+	Coin                   []*BybitWalletCoin `json:"coin"`
+}
+
+type BybitWalletCoin struct {
+	Coin          string `json:"coin"`
+	Equity        string `json:"equity"`
+	UsdValue      string `json:"usdValue"`
+	WalletBalance string `json:"walletBalance"`
+	Locked        string `json:"locked"`       // 现货挂单冻结
+	TotalOrderIM  string `json:"totalOrderIM"` // 合约挂单占用
+	BorrowAmount  string `json:"borrowAmount"`
+}
+
+type BalanceRsp struct {
+	BaseBybitRsp
+	Result struct {
+		List []*BybitWallet `json:"list"`
+	} `json:"result"`
+}
+
+type FundBalance struct {
+	Coin            string `json:"coin"`
+	WalletBalance   string `json:"walletBalance"`
+	TransferBalance string `json:"transferBalance"`
+}
+
+type FundBalanceRsp struct {
+	BaseBybitRsp
+	Result struct {
+		Balance []*FundBalance `json:"balance"`
+	} `json:"result"`
+}
+
+// FetchBalance 统一账户余额
+func (client *RestClient) FetchBalance() (*types.Assets, error) {
+	queryDict := map[string]interface{}{
+		"accountType": "UNIFIED",
+	}
+	response := new(BalanceRsp)
+	if err := client.request(http.MethodGet, FetchBalanceUri, queryDict, response); err != nil {
+		return nil, err
+	}
+	if len(response.Result.List) == 0 {
+		return nil, fmt.Errorf("bybit get /v5/account/wallet-balance empty")
+	}
+	return walletTransform(response.Result.List[0]), nil
+}
+
+func walletTransform(wallet *BybitWallet) *types.Assets {
+	assets := make(map[string]types.Asset, len(wallet.Coin))
+	for _, item := range wallet.Coin {
+		total, _ := utils.ParseFloat(item.WalletBalance)
+		locked, _ := utils.ParseFloat(item.Locked)
+		orderIM, _ := utils.ParseFloat(item.TotalOrderIM)
+		eqUsd, _ := utils.ParseFloat(item.UsdValue)
+		assets[item.Coin] = types.Asset{
+			Coin:   item.Coin,
+			Free:   total - locked - orderIM,
+			Frozen: locked + orderIM,
+			Total:  total,
+			EqUsd:  eqUsd,
+		}
+	}
+
+	totalEq, _ := utils.ParseFloat(wallet.TotalEquity)
+	freeEq, _ := utils.ParseFloat(wallet.TotalAvailableBalance)
+	mmRate, _ := utils.ParseFloat(wallet.AccountMMRate)
+	// 转换为 权益/维持保证金，与其他交易所保持一致，没有仓位时为20
+	uniMMR := 20.0
+	if mmRate > 0 {
+		uniMMR = 1 / mmRate
+	}
+	return &types.Assets{
+		Assets:     assets,
+		TotalUsdEq: totalEq,
+		FreeUsdEq:  freeEq,
+		UniMMR:     uniMMR,
+	}
+}
+
+// FetchAssetBalance 资金账户余额
+func (client *RestClient) FetchAssetBalance() (*types.Assets, error) {
+	queryDict := map[string]interface{}{
+		"accountType": "FUND",
+	}
+	response := new(FundBalanceRsp)
+	if err := client.request(http.MethodGet, FetchAssetBalanceUri, queryDict, response); err != nil {
+		return nil, err
+	}
+
+	assets := make(map[string]types.Asset, len(response.Result.Balance))
+	for _, item := range response.Result.Balance {
+		total, _ := utils.ParseFloat(item.WalletBalance)
+		free, _ := utils.ParseFloat(item.TransferBalance)
+		assets[item.Coin] = types.Asset{
+			Coin:   item.Coin,
+			Free:   free,
+			Frozen: total - free,
+			Total:  total,
+		}
+	}
+	return &types.Assets{Assets: assets}, nil
+}
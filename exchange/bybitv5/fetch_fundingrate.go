@@ -0,0 +1,92 @@
+package bybitv5
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type FundingRateHistory struct {
+	Symbol               string `json:"symbol"`
+	FundingRate          string `json:"fundingRate"`
+	FundingRateTimestamp string `json:"fundingRateTimestamp"`
+}
+
+type FundingRateHistoryRsp struct {
+	BaseBybitRsp
+	Result struct {
+		List []*FundingRateHistory `json:"list"`
+	} `json:"result"`
+}
+
+// FetchFundingRate 从ticker获取当前资金费率和下次结算时间
+func (client *RestClient) FetchFundingRate(symbol string) (*types.FundingRate, error) {
+	if client.exchangeType != constant.BybitV5Linear {
+		return nil, fmt.Errorf("not impl")
+	}
+	queryDict := map[string]interface{}{
+		"category": client.category(),
+		"symbol":   Symbol2Bybit(symbol, client.exchangeType),
+	}
+	response := new(TickerRsp)
+	if err := client.publicGet(FetchTickersUri, queryDict, response); err != nil {
+		return nil, err
+	}
+	if len(response.Result.List) == 0 {
+		return nil, fmt.Errorf("bybit get /v5/market/tickers empty")
+	}
+
+	ticker := response.Result.List[0]
+	rate, err := strconv.ParseFloat(ticker.FundingRate, 64)
+	if err != nil {
+		return nil, err
+	}
+	nextTime, _ := utils.ParseInt(ticker.NextFundingTime)
+	return &types.FundingRate{
+		Symbol:      ticker.Symbol,
+		FundingRate: rate,
+		FundingTime: nextTime,
+	}, nil
+}
+
+// FetchFundingRateHistory 单次最多200条，按时间倒序返回
+func (client *RestClient) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
+	if client.exchangeType != constant.BybitV5Linear {
+		return nil, fmt.Errorf("not impl")
+	}
+	queryDict := map[string]interface{}{
+		"category": client.category(),
+		"symbol":   Symbol2Bybit(symbol, client.exchangeType),
+		"limit":    limit,
+	}
+	response := new(FundingRateHistoryRsp)
+	if err := client.publicGet(FetchFundingRateHistoryUri, queryDict, response); err != nil {
+		return nil, err
+	}
+	if len(response.Result.List) == 0 {
+		return nil, fmt.Errorf("bybit get /v5/market/funding/history empty")
+	}
+
+	result := make([]*types.FundingRate, 0, len(response.Result.List))
+	for _, fr := range response.Result.List {
+		rate, err := strconv.ParseFloat(fr.FundingRate, 64)
+		if err != nil {
+			log.Errorf("parser FundingRateHistory FundingRate err %s", err)
+			continue
+		}
+		t, err := strconv.ParseInt(fr.FundingRateTimestamp, 10, 64)
+		if err != nil {
+			log.Errorf("parser FundingRateHistory FundingTime err %s", err)
+			continue
+		}
+		result = append(result, &types.FundingRate{
+			Symbol:      fr.Symbol,
+			FundingRate: rate,
+			FundingTime: t,
+		})
+	}
+	return result, nil
+}
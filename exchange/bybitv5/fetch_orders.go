@@ -0,0 +1,168 @@
+package bybitv5
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 订单查询接口单页最多返回的条数
+const maxOrderPageLimit = 50
+
+// BybitOrder rest查询和ws order频道共用
+type BybitOrder struct {
+	Category     string `json:"category"` // 仅ws推送
+	Symbol       string `json:"symbol"`
+	OrderId      string `json:"orderId"`
+	OrderLinkId  string `json:"orderLinkId"`
+	Side         string `json:"side"`
+	PositionIdx  int    `json:"positionIdx"`
+	OrderType    string `json:"orderType"`
+	TimeInForce  string `json:"timeInForce"`
+	Price        string `json:"price"`
+	Qty          string `json:"qty"`
+	OrderStatus  string `json:"orderStatus"`
+	CumExecQty   string `json:"cumExecQty"`
+	CumExecValue string `json:"cumExecValue"`
+	CumExecFee   string `json:"cumExecFee"`
+	AvgPrice     string `json:"avgPrice"`
+	ReduceOnly   bool   `json:"reduceOnly"`
+	CreatedTime  string `json:"createdTime"`
+	UpdatedTime  string `json:"updatedTime"`
+}
+
+type OrdersRsp struct {
+	BaseBybitRsp
+	Result struct {
+		List           []*BybitOrder `json:"list"`
+		NextPageCursor string        `json:"nextPageCursor"`
+	} `json:"result"`
+}
+
+var (
+	BybitTimeInForce2Type = map[string]constant.OrderType{
+		"GTC":      constant.Limit,
+		"IOC":      constant.IOC,
+		"FOK":      constant.FOK,
+		"PostOnly": constant.PostOnly,
+	}
+)
+
+// FetchOpenOrders symbol为空时查询全部，合约按结算币种分别查询
+func (client *RestClient) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	queries := make([]map[string]interface{}, 0)
+	switch {
+	case symbol != "":
+		queries = append(queries, map[string]interface{}{"symbol": Symbol2Bybit(symbol, client.exchangeType)})
+	case client.exchangeType == constant.BybitV5Linear:
+		for _, settleCoin := range settleCoins {
+			queries = append(queries, map[string]interface{}{"settleCoin": settleCoin})
+		}
+	default:
+		queries = append(queries, map[string]interface{}{})
+	}
+
+	result := make([]*types.Order, 0)
+	for _, queryDict := range queries {
+		queryDict["category"] = client.category()
+		queryDict["openOnly"] = 0
+		queryDict["limit"] = maxOrderPageLimit
+		for {
+			response := new(OrdersRsp)
+			if err := client.request(http.MethodGet, FetchOpenOrderUri, queryDict, response); err != nil {
+				return nil, err
+			}
+			for _, item := range response.Result.List {
+				result = append(result, client.orderTransform(item))
+			}
+			cursor := response.Result.NextPageCursor
+			if cursor == "" || len(response.Result.List) == 0 {
+				break
+			}
+			queryDict["cursor"] = cursor
+		}
+	}
+	return result, nil
+}
+
+// FetchOrder 先查询活动订单，查不到时再查历史订单
+func (client *RestClient) FetchOrder(order *types.Order) (*types.Order, error) {
+	queryDict := map[string]interface{}{
+		"category": client.category(),
+		"symbol":   Symbol2Bybit(order.Symbol, client.exchangeType),
+	}
+	if order.OrderID != "" {
+		queryDict["orderId"] = order.OrderID
+	} else if order.ClientID != "" {
+		queryDict["orderLinkId"] = order.ClientID
+	} else {
+		return nil, fmt.Errorf("bybit fetch order need orderId or clientId")
+	}
+
+	for _, uri := range []string{FetchOpenOrderUri, FetchOrderHistoryUri} {
+		response := new(OrdersRsp)
+		if err := client.request(http.MethodGet, uri, queryDict, response); err != nil {
+			return nil, err
+		}
+		if len(response.Result.List) > 0 {
+			return client.orderTransform(response.Result.List[0]), nil
+		}
+	}
+	return nil, fmt.Errorf("bybit order not found, orderId:%s, clientId:%s", order.OrderID, order.ClientID)
+}
+
+func (client *RestClient) orderTransform(item *BybitOrder) *types.Order {
+	exchangeType := client.exchangeType
+	if item.Category != "" {
+		exchangeType = bybitCategory2ExchangeType(item.Category)
+	}
+	return bybitOrderTransform(item, exchangeType)
+}
+
+func bybitOrderTransform(item *BybitOrder, exchangeType constant.ExchangeType) *types.Order {
+	createAt, _ := utils.ParseInt(item.CreatedTime)
+	updateAt, _ := utils.ParseInt(item.UpdatedTime)
+	orderType := constant.Market
+	if item.OrderType != "Market" {
+		orderType = BybitTimeInForce2Type[item.TimeInForce]
+	}
+	return &types.Order{
+		Symbol:      Bybit2Symbol(item.Symbol),
+		Exchange:    exchangeType,
+		Type:        orderType,
+		OrderID:     item.OrderId,
+		ClientID:    item.OrderLinkId,
+		Side:        bybitOrderSide(item.Side, item.PositionIdx),
+		Price:       item.Price,
+		OrigQty:     item.Qty,
+		ExecutedQty: item.CumExecQty,
+		ExecutedAmt: item.CumExecValue,
+		AvgPrice:    item.AvgPrice,
+		Fee:         item.CumExecFee,
+		Status:      Bybit2Status[item.OrderStatus],
+		ReduceOnly:  item.ReduceOnly,
+		CreateAt:    createAt,
+		UpdateAt:    updateAt,
+	}
+}
+
+// bybitOrderSide 双向持仓模式下结合positionIdx还原开平方向
+func bybitOrderSide(side string, positionIdx int) constant.OrderSide {
+	switch positionIdx {
+	case 1:
+		if side == "Buy" {
+			return constant.Long
+		}
+		return constant.CloseLong
+	case 2:
+		if side == "Sell" {
+			return constant.Short
+		}
+		return constant.CloseShort
+	default:
+		return Bybit2Side[side]
+	}
+}
@@ -0,0 +1,110 @@
+package bybitv5
+
+import (
+	"net/http"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 永续合约的结算币种，不指定symbol时需要按结算币种查询
+var settleCoins = []string{"USDT", "USDC"}
+
+type BybitPosition struct {
+	Category      string `json:"category"` // 仅ws推送
+	PositionIdx   int    `json:"positionIdx"`
+	Symbol        string `json:"symbol"`
+	Side          string `json:"side"` // Buy/Sell，无仓位时为空
+	Size          string `json:"size"`
+	AvgPrice      string `json:"avgPrice"`
+	PositionValue string `json:"positionValue"`
+	TradeMode     int    `json:"tradeMode"`
+	Leverage      string `json:"leverage"`
+	MarkPrice     string `json:"markPrice"`
+	LiqPrice      string `json:"liqPrice"`
+	PositionIM    string `json:"positionIM"`
+	PositionMM    string `json:"positionMM"`
+	UnrealisedPnl string `json:"unrealisedPnl"`
+}
+
+type PositionRsp struct {
+	BaseBybitRsp
+	Result struct {
+		List           []*BybitPosition `json:"list"`
+		NextPageCursor string           `json:"nextPageCursor"`
+	} `json:"result"`
+}
+
+// FetchPositons 查询USDT和USDC永续的全部持仓，现货没有持仓
+func (client *RestClient) FetchPositons() ([]*types.Position, error) {
+	if client.exchangeType != constant.BybitV5Linear {
+		return []*types.Position{}, nil
+	}
+	result := make([]*types.Position, 0)
+	for _, settleCoin := range settleCoins {
+		cursor := ""
+		for {
+			queryDict := map[string]interface{}{
+				"category":   client.category(),
+				"settleCoin": settleCoin,
+				"limit":      200,
+			}
+			if cursor != "" {
+				queryDict["cursor"] = cursor
+			}
+			response := new(PositionRsp)
+			if err := client.request(http.MethodGet, FetchPositionsUri, queryDict, response); err != nil {
+				return nil, err
+			}
+			result = append(result, positionsTransform(response.Result.List, false)...)
+			cursor = response.Result.NextPageCursor
+			if cursor == "" || len(response.Result.List) == 0 {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// positionsTransform keepEmpty为true时保留持仓为0的仓位，ws推送用来表示已平仓
+func positionsTransform(list []*BybitPosition, keepEmpty bool) []*types.Position {
+	result := make([]*types.Position, 0, len(list))
+	for _, item := range list {
+		position, _ := utils.ParseFloat(item.Size)
+		if position == 0 && !keepEmpty {
+			continue
+		}
+		liquidationPx, _ := utils.ParseFloat(item.LiqPrice)
+		avgCost, _ := utils.ParseFloat(item.AvgPrice)
+		unrealisedPnl, _ := utils.ParseFloat(item.UnrealisedPnl)
+		last, _ := utils.ParseFloat(item.MarkPrice)
+		margin, _ := utils.ParseFloat(item.PositionIM)
+		leverage, _ := utils.ParseFloat(item.Leverage)
+		info := &types.Position{
+			MarginMode:    Bybit2MarginMode[item.TradeMode],
+			Symbol:        Bybit2Symbol(item.Symbol),
+			LiquidationPx: liquidationPx,
+			Side:          getPositionSide(item),
+			Position:      position,
+			AvgCost:       avgCost,
+			UnrealisedPnl: unrealisedPnl,
+			Last:          last,
+			Margin:        margin,
+			Leverage:      leverage,
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// getPositionSide 平仓后side为空，双向持仓按positionIdx判断
+func getPositionSide(item *BybitPosition) string {
+	switch {
+	case item.Side == "Buy" || item.PositionIdx == 1:
+		return constant.Long.Name()
+	case item.Side == "Sell" || item.PositionIdx == 2:
+		return constant.Short.Name()
+	}
+	return ""
+}
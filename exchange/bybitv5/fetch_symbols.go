@@ -0,0 +1,93 @@
+package bybitv5
+
+import (
+	"strings"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type Instrument struct {
+	Symbol        string `json:"symbol"`
+	BaseCoin      string `json:"baseCoin"`
+	QuoteCoin     string `json:"quoteCoin"`
+	Status        string `json:"status"`
+	ContractType  string `json:"contractType"` // LinearPerpetual/LinearFutures
+	DeliveryTime  string `json:"deliveryTime"`
+	LotSizeFilter struct {
+		BasePrecision string `json:"basePrecision"` // 现货数量精度
+		QtyStep       string `json:"qtyStep"`       // 合约数量步长
+		MinOrderQty   string `json:"minOrderQty"`
+		MaxOrderQty   string `json:"maxOrderQty"`
+	} `json:"lotSizeFilter"`
+	PriceFilter struct {
+		TickSize string `json:"tickSize"`
+	} `json:"priceFilter"`
+}
+
+type SymbolsRsp struct {
+	BaseBybitRsp
+	Result struct {
+		List           []*Instrument `json:"list"`
+		NextPageCursor string        `json:"nextPageCursor"`
+	} `json:"result"`
+}
+
+// FetchSymbols 合约只返回永续，交割合约暂不支持
+func (client *RestClient) FetchSymbols() ([]*types.SymbolInfo, error) {
+	result := make([]*types.SymbolInfo, 0)
+	cursor := ""
+	for {
+		queryDict := map[string]interface{}{
+			"category": client.category(),
+			"limit":    1000,
+		}
+		if cursor != "" {
+			queryDict["cursor"] = cursor
+		}
+		response := new(SymbolsRsp)
+		if err := client.publicGet(FetchSymbolsUri, queryDict, response); err != nil {
+			return nil, err
+		}
+		result = append(result, symbolTransform(response.Result.List)...)
+		cursor = response.Result.NextPageCursor
+		if cursor == "" || len(response.Result.List) == 0 {
+			break
+		}
+	}
+	return result, nil
+}
+
+func symbolTransform(list []*Instrument) []*types.SymbolInfo {
+	result := make([]*types.SymbolInfo, 0, len(list))
+	for _, item := range list {
+		if item.Status != "Trading" {
+			continue
+		}
+		if item.ContractType != "" && item.ContractType != "LinearPerpetual" {
+			continue
+		}
+		qtyStep := item.LotSizeFilter.QtyStep
+		if qtyStep == "" {
+			qtyStep = item.LotSizeFilter.BasePrecision
+		}
+		minCnt, _ := utils.ParseFloat(item.LotSizeFilter.MinOrderQty)
+		maxCnt, _ := utils.ParseFloat(item.LotSizeFilter.MaxOrderQty)
+		baseCoin := strings.ToLower(item.BaseCoin)
+		quoteCoin := strings.ToLower(item.QuoteCoin)
+		info := &types.SymbolInfo{
+			Base:       baseCoin,
+			Quote:      quoteCoin,
+			Symbol:     item.BaseCoin + "_" + item.QuoteCoin,
+			FaceVal:    1,
+			Multiplier: 1,
+			PxPrec:     utils.DecimalMath(item.PriceFilter.TickSize),
+			QtyPrec:    utils.DecimalMath(qtyStep),
+			MinCnt:     minCnt,
+			MaxCnt:     maxCnt,
+			Name:       baseCoin + "_" + quoteCoin,
+		}
+		result = append(result, info)
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package bybitv5
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type Ticker struct {
+	Symbol          string `json:"symbol"`
+	LastPrice       string `json:"lastPrice"`
+	Bid1Price       string `json:"bid1Price"`
+	Bid1Size        string `json:"bid1Size"`
+	Ask1Price       string `json:"ask1Price"`
+	Ask1Size        string `json:"ask1Size"`
+	PrevPrice24h    string `json:"prevPrice24h"`
+	HighPrice24h    string `json:"highPrice24h"`
+	LowPrice24h     string `json:"lowPrice24h"`
+	Volume24h       string `json:"volume24h"`
+	FundingRate     string `json:"fundingRate"`     // 仅合约
+	NextFundingTime string `json:"nextFundingTime"` // 仅合约
+}
+
+type TickerRsp struct {
+	BaseBybitRsp
+	Result struct {
+		Category string    `json:"category"`
+		List     []*Ticker `json:"list"`
+	} `json:"result"`
+}
+
+func (client *RestClient) FetchTickers() ([]*types.Ticker, error) {
+	queryDict := map[string]interface{}{
+		"category": client.category(),
+	}
+	response := new(TickerRsp)
+	if err := client.publicGet(FetchTickersUri, queryDict, response); err != nil {
+		return nil, err
+	}
+	if len(response.Result.List) == 0 {
+		return nil, fmt.Errorf("bybit get /v5/market/tickers empty")
+	}
+	return tickersTransform(response), nil
+}
+
+func tickersTransform(response *TickerRsp) []*types.Ticker {
+	result := make([]*types.Ticker, 0, len(response.Result.List))
+	for _, item := range response.Result.List {
+		open, _ := utils.ParseFloat(item.PrevPrice24h)
+		high, _ := utils.ParseFloat(item.HighPrice24h)
+		low, _ := utils.ParseFloat(item.LowPrice24h)
+		vol, _ := utils.ParseFloat(item.Volume24h)
+		lastPrice, _ := utils.ParseFloat(item.LastPrice)
+		askPrice, _ := utils.ParseFloat(item.Ask1Price)
+		askSize, _ := utils.ParseFloat(item.Ask1Size)
+		bidPrice, _ := utils.ParseFloat(item.Bid1Price)
+		bidSize, _ := utils.ParseFloat(item.Bid1Size)
+		ticker := &types.Ticker{
+			Symbol:     Bybit2Symbol(item.Symbol),
+			MarketType: response.Result.Category,
+			Open:       open,
+			High:       high,
+			Low:        low,
+			Vol:        vol,
+			LastPrice:  lastPrice,
+			AskPrice:   askPrice,
+			AskSize:    askSize,
+			BidPrice:   bidPrice,
+			BidSize:    bidSize,
+			ExchangeTs: response.Time,
+			Ts:         utils.Millisec(time.Now()),
+		}
+		result = append(result, ticker)
+	}
+	return result
+}
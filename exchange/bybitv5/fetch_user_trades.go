@@ -0,0 +1,107 @@
+package bybitv5
+
+import (
+	"net/http"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 成交明细单页最多返回的条数
+const maxExecutionLimit = 100
+
+type Execution struct {
+	Symbol      string `json:"symbol"`
+	OrderId     string `json:"orderId"`
+	OrderLinkId string `json:"orderLinkId"`
+	Side        string `json:"side"`
+	ExecId      string `json:"execId"`
+	ExecPrice   string `json:"execPrice"`
+	ExecQty     string `json:"execQty"`
+	ExecFee     string `json:"execFee"` // 负数为返佣
+	FeeCurrency string `json:"feeCurrency"`
+	IsMaker     bool   `json:"isMaker"`
+	ExecTime    string `json:"execTime"`
+}
+
+type ExecutionRsp struct {
+	BaseBybitRsp
+	Result struct {
+		List           []*Execution `json:"list"`
+		NextPageCursor string       `json:"nextPageCursor"`
+	} `json:"result"`
+}
+
+// FetchUserTrades 查询成交明细，时间区间最长7天，不指定时为最近7天，结果按时间正序
+// 超过Limit时返回区间内最近的Limit条
+func (client *RestClient) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	queryDict := map[string]interface{}{
+		"category": client.category(),
+		"execType": "Trade",
+		"limit":    maxExecutionLimit,
+	}
+	if param.Symbol != "" {
+		queryDict["symbol"] = Symbol2Bybit(param.Symbol, client.exchangeType)
+	}
+	if param.OrderID != "" {
+		queryDict["orderId"] = param.OrderID
+	}
+	if param.StartTime > 0 {
+		queryDict["startTime"] = param.StartTime
+	}
+	if param.EndTime > 0 {
+		queryDict["endTime"] = param.EndTime
+	}
+
+	result := make([]*types.Fill, 0)
+	for {
+		response := new(ExecutionRsp)
+		if err := client.request(http.MethodGet, FetchUserTradesUri, queryDict, response); err != nil {
+			return nil, err
+		}
+		for _, item := range response.Result.List {
+			result = append(result, client.fillTransform(item))
+			if param.Limit > 0 && int64(len(result)) >= param.Limit {
+				break
+			}
+		}
+		cursor := response.Result.NextPageCursor
+		if cursor == "" || len(response.Result.List) == 0 || (param.Limit > 0 && int64(len(result)) >= param.Limit) {
+			break
+		}
+		queryDict["cursor"] = cursor
+	}
+
+	// 接口按时间倒序返回
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+func (client *RestClient) fillTransform(item *Execution) *types.Fill {
+	price, _ := utils.ParseFloat(item.ExecPrice)
+	qty, _ := utils.ParseFloat(item.ExecQty)
+	fee, _ := utils.ParseFloat(item.ExecFee)
+	ts, _ := utils.ParseInt(item.ExecTime)
+	fill := &types.Fill{
+		Symbol:   Bybit2Symbol(item.Symbol),
+		Exchange: client.exchangeType,
+		TradeID:  item.ExecId,
+		OrderID:  item.OrderId,
+		ClientID: item.OrderLinkId,
+		Side:     Bybit2Side[item.Side],
+		Price:    price,
+		Qty:      qty,
+		Fee:      fee,
+		FeeCoin:  item.FeeCurrency,
+		Role:     constant.Taker,
+		Ts:       ts,
+	}
+	if item.IsMaker {
+		fill.Role = constant.Maker
+	}
+	return fill
+}
@@ -0,0 +1,5 @@
+package bybitv5
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("package", "bybit")
@@ -0,0 +1,106 @@
+package bybitv5
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// BybitBookData 深度推送，b/a为 [价格, 数量]，数量为0表示删除该档位
+type BybitBookData struct {
+	Symbol   string     `json:"s"`
+	Bids     [][]string `json:"b"`
+	Asks     [][]string `json:"a"`
+	UpdateId int64      `json:"u"` // 为1表示服务重启，需要当作快照处理
+	Seq      int64      `json:"seq"`
+}
+
+// localBook 单个交易对的本地订单簿，asks价格升序，bids价格降序
+type localBook struct {
+	symbol   string
+	asks     []types.OrderBookItem
+	bids     []types.OrderBookItem
+	updateId int64
+}
+
+func newLocalBook(data *BybitBookData) *localBook {
+	book := &localBook{symbol: data.Symbol}
+	book.update(data)
+	return book
+}
+
+func (b *localBook) update(data *BybitBookData) {
+	b.asks = updateLevels(b.asks, data.Asks, true)
+	b.bids = updateLevels(b.bids, data.Bids, false)
+	b.updateId = data.UpdateId
+}
+
+// updateLevels 数量为0表示删除该档位，否则替换或按顺序插入
+func updateLevels(levels []types.OrderBookItem, updates [][]string, asc bool) []types.OrderBookItem {
+	for _, item := range updates {
+		if len(item) < 2 {
+			continue
+		}
+		price, err := strconv.ParseFloat(item[0], 64)
+		if err != nil {
+			continue
+		}
+		qty, _ := strconv.ParseFloat(item[1], 64)
+
+		i := 0
+		for i < len(levels) && (asc && levels[i].Price < price || !asc && levels[i].Price > price) {
+			i++
+		}
+		found := i < len(levels) && levels[i].Price == price
+		switch {
+		case qty == 0 && found:
+			levels = append(levels[:i], levels[i+1:]...)
+		case qty == 0:
+		case found:
+			levels[i].Qty = qty
+		default:
+			levels = append(levels, types.OrderBookItem{})
+			copy(levels[i+1:], levels[i:])
+			levels[i] = types.OrderBookItem{Price: price, Qty: qty}
+		}
+	}
+	return levels
+}
+
+func (b *localBook) toOrderBook(exchangeType constant.ExchangeType, ts int64) *types.OrderBook {
+	asks := make([]types.OrderBookItem, len(b.asks))
+	copy(asks, b.asks)
+	bids := make([]types.OrderBookItem, len(b.bids))
+	copy(bids, b.bids)
+	return &types.OrderBook{
+		Symbol:     Bybit2Symbol(b.symbol),
+		Exchange:   exchangeType,
+		Asks:       asks,
+		Bids:       bids,
+		ExchangeTs: ts * 1000,
+		Ts:         utils.Microsec(time.Now()),
+		TraceId:    utils.RandomString(8),
+	}
+}
+
+// toBookTicker 一档深度转为BookTicker，某一侧为空时价格和数量为0
+func (b *localBook) toBookTicker(exchangeType constant.ExchangeType, ts int64, localTs int64) *types.BookTicker {
+	evt := &types.BookTicker{
+		Symbol:     Bybit2Symbol(b.symbol),
+		Exchange:   exchangeType,
+		ExchangeTs: ts * 1000,
+		TraceId:    utils.RandomString(8),
+		LocalTs:    localTs,
+	}
+	if len(b.asks) > 0 {
+		evt.AskPrice, evt.AskQty = b.asks[0].Price, b.asks[0].Qty
+	}
+	if len(b.bids) > 0 {
+		evt.BidPrice, evt.BidQty = b.bids[0].Price, b.bids[0].Qty
+	}
+	evt.EventTs = utils.Microsec(time.Now())
+	return evt
+}
@@ -0,0 +1,33 @@
+package bybitv5
+
+import (
+	"testing"
+)
+
+func TestLocalBookUpdate(t *testing.T) {
+	book := newLocalBook(&BybitBookData{
+		Symbol:   "BTCUSDT",
+		Asks:     [][]string{{"101", "1"}, {"102", "2"}},
+		Bids:     [][]string{{"100", "1"}, {"99", "2"}},
+		UpdateId: 10,
+	})
+
+	// 删除101，新增100.5，修改99
+	book.update(&BybitBookData{
+		Asks:     [][]string{{"101", "0"}, {"100.5", "3"}},
+		Bids:     [][]string{{"99", "5"}, {"98", "0"}},
+		UpdateId: 11,
+	})
+
+	if len(book.asks) != 2 || book.asks[0].Price != 100.5 || book.asks[1].Price != 102 {
+		t.Fatalf("unexpected asks %+v", book.asks)
+	}
+	if len(book.bids) != 2 || book.bids[0].Price != 100 || book.bids[1].Qty != 5 {
+		t.Fatalf("unexpected bids %+v", book.bids)
+	}
+
+	ticker := book.toBookTicker(0, 1, 1)
+	if ticker.Symbol != "BTC_USDT" || ticker.AskPrice != 100.5 || ticker.BidPrice != 100 {
+		t.Fatalf("unexpected ticker %+v", ticker)
+	}
+}
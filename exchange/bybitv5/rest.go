@@ -0,0 +1,236 @@
+package bybitv5
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/httpx"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+var httpClient = httpx.NewClient()
+
+type RestClient struct {
+	apiKey       string
+	secretKey    string
+	exchangeType constant.ExchangeType
+	restUrl      string
+	httpClient   httpx.Client
+}
+
+// BaseBybitRsp v5接口统一的返回结构，retCode为0表示成功
+type BaseBybitRsp struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Time    int64  `json:"time"`
+}
+
+func (t *BaseBybitRsp) base() *BaseBybitRsp {
+	return t
+}
+
+type bybitRsp interface {
+	base() *BaseBybitRsp
+}
+
+func NewRestClient(apiKey, secretKey string, exchangeType constant.ExchangeType) *RestClient {
+	client := &RestClient{
+		apiKey:       apiKey,
+		secretKey:    secretKey,
+		exchangeType: exchangeType,
+		restUrl:      RestUrl,
+		httpClient:   httpClient,
+	}
+	return client
+}
+
+// SetEndpoints 使用指定环境的rest地址
+func (client *RestClient) SetEndpoints(endpoints base.Endpoints) {
+	client.restUrl = endpoints.RestUrl
+}
+
+// SetHttpClient 使用交易所实例独立的http客户端(代理、超时、出口IP)
+func (client *RestClient) SetHttpClient(httpClient httpx.Client) {
+	client.httpClient = httpClient
+}
+
+func (client *RestClient) category() string {
+	return category(client.exchangeType)
+}
+
+// HttpRequest GET请求参数放在query中，POST请求参数为json body，两者都参与签名
+func (client *RestClient) HttpRequest(method string, uri string, param map[string]interface{}) ([]byte, *http.Response, error) {
+	var payload string
+	var body []byte
+	url := client.restUrl + uri
+	if method == http.MethodGet {
+		payload = utils.UrlEncodeParams(param)
+		if payload != "" {
+			url = fmt.Sprintf("%s?%s", url, payload)
+		}
+	} else {
+		body, _ = sonic.Marshal(param)
+		payload = string(body)
+	}
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	head := map[string]string{
+		"Content-Type":       "application/json",
+		"X-BAPI-API-KEY":     client.apiKey,
+		"X-BAPI-SIGN":        sign(timestamp, client.apiKey, payload, client.secretKey),
+		"X-BAPI-SIGN-TYPE":   "2",
+		"X-BAPI-TIMESTAMP":   timestamp,
+		"X-BAPI-RECV-WINDOW": recvWindow,
+	}
+	args := &httpx.Request{
+		Url:    url,
+		Head:   head,
+		Method: method,
+		Body:   body,
+	}
+	res, httpRes, err := client.httpClient.Request(args)
+	if err != nil {
+		return nil, httpRes, err
+	}
+	return *res, httpRes, err
+}
+
+func (client *RestClient) HttpGet(url string) ([]byte, *http.Response, error) {
+	body, res, err := client.httpClient.Get(url)
+	if err != nil {
+		return nil, res, err
+	}
+	return *body, res, nil
+}
+
+// publicGet 行情类接口不需要签名
+func (client *RestClient) publicGet(uri string, param map[string]interface{}, response bybitRsp) error {
+	url := fmt.Sprintf("%s%s?%s", client.restUrl, uri, utils.UrlEncodeParams(param))
+	body, _, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("bybit get %s err:%v", uri, err)
+		return err
+	}
+	return parseResponse(http.MethodGet, uri, body, response)
+}
+
+func (client *RestClient) request(method string, uri string, param map[string]interface{}, response bybitRsp) error {
+	body, _, err := client.HttpRequest(method, uri, param)
+	if err != nil {
+		log.Errorf("bybit %s %s err:%v", method, uri, err)
+		return err
+	}
+	return parseResponse(method, uri, body, response)
+}
+
+func parseResponse(method string, uri string, body []byte, response bybitRsp) error {
+	if err := sonic.Unmarshal(body, response); err != nil {
+		log.Errorf("bybit %s %s parser err:%v", method, uri, err)
+		return err
+	}
+	if rsp := response.base(); rsp.RetCode != 0 {
+		return fmt.Errorf("bybit %s %s fail, code:%d, msg:%s", method, uri, rsp.RetCode, rsp.RetMsg)
+	}
+	return nil
+}
+
+type KlineRsp struct {
+	BaseBybitRsp
+	Result struct {
+		Symbol string     `json:"symbol"`
+		List   [][]string `json:"list"`
+	} `json:"result"`
+}
+
+// FetchKline interval支持bybit原始周期(1/60/D)和通用周期(1m/1h/1d)，按时间倒序返回
+func (client *RestClient) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
+	queryDict := map[string]interface{}{
+		"category": client.category(),
+		"symbol":   Symbol2Bybit(symbol, client.exchangeType),
+		"interval": bybitInterval(interval),
+		"limit":    limit,
+	}
+	response := new(KlineRsp)
+	if err := client.publicGet(FetchKlineUri, queryDict, response); err != nil {
+		return nil, err
+	}
+	if len(response.Result.List) == 0 {
+		return nil, fmt.Errorf("bybit get /v5/market/kline empty")
+	}
+	return klineTransform(response.Result.List, bybitInterval(interval)), nil
+}
+
+// 历史K线单页最多返回的数量
+const maxHistoryKlineLimit = 1000
+
+// FetchHistoryKline 指定StartTime时向后翻页拉取区间内的K线，否则从EndTime向前翻页直到取满Limit根，结果按时间正序返回
+func (client *RestClient) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	interval := bybitInterval(param.Interval)
+	return base.PageKlines(param, maxHistoryKlineLimit, func(startTime, endTime, limit int64) ([]types.Kline, error) {
+		queryDict := map[string]interface{}{
+			"category": client.category(),
+			"symbol":   Symbol2Bybit(param.Symbol, client.exchangeType),
+			"interval": interval,
+			"end":      base.ForwardPageEnd(startTime, endTime, limit, intervalMillis(interval)),
+			"limit":    limit,
+		}
+		// bybit返回区间内最近的limit根
+		if startTime > 0 {
+			queryDict["start"] = startTime
+		}
+		response := new(KlineRsp)
+		if err := client.publicGet(FetchKlineUri, queryDict, response); err != nil {
+			return nil, err
+		}
+		// bybit按时间倒序返回
+		klines := klineTransform(response.Result.List, interval)
+		for i, j := 0, len(klines)-1; i < j; i, j = i+1, j-1 {
+			klines[i], klines[j] = klines[j], klines[i]
+		}
+		return klines, nil
+	})
+}
+
+func bybitInterval(interval string) string {
+	if v, ok := Interval2Bybit[interval]; ok {
+		return v
+	}
+	return interval
+}
+
+// klineTransform 格式 [startTime, open, high, low, close, volume, turnover]，未收盘的K线Confirm为0
+func klineTransform(list [][]string, interval string) []types.Kline {
+	now := time.Now().UnixMilli()
+	period := intervalMillis(interval)
+	result := make([]types.Kline, 0, len(list))
+	for _, dat := range list {
+		if len(dat) < 6 {
+			log.Errorf("data len less 6 %v", len(dat))
+			continue
+		}
+		ts, _ := strconv.ParseInt(dat[0], 10, 64)
+		open, _ := strconv.ParseFloat(dat[1], 64)
+		high, _ := strconv.ParseFloat(dat[2], 64)
+		low, _ := strconv.ParseFloat(dat[3], 64)
+		close, _ := strconv.ParseFloat(dat[4], 64)
+		vol, _ := strconv.ParseFloat(dat[5], 64)
+		k := types.Kline{
+			Ts:    ts,
+			Open:  open,
+			High:  high,
+			Low:   low,
+			Close: close,
+			Vol:   vol,
+		}
+		if period > 0 && ts+period <= now {
+			k.Confirm = 1
+		}
+		result = append(result, k)
+	}
+	return result
+}
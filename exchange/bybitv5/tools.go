@@ -0,0 +1,203 @@
+package bybitv5
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+var (
+	RestUrl        = "https://api.bybit.com"
+	PubSpotWsUrl   = "wss://stream.bybit.com/v5/public/spot"
+	PubLinearWsUrl = "wss://stream.bybit.com/v5/public/linear"
+	PriWsUrl       = "wss://stream.bybit.com/v5/private"
+
+	Side2Bybit = map[constant.OrderSide]string{
+		constant.OrderBuy:   "Buy",
+		constant.OrderSell:  "Sell",
+		constant.Long:       "Buy",
+		constant.Short:      "Sell",
+		constant.CloseLong:  "Sell",
+		constant.CloseShort: "Buy",
+	}
+
+	Bybit2Side = map[string]constant.OrderSide{
+		"Buy":  constant.OrderBuy,
+		"Sell": constant.OrderSell,
+	}
+
+	// 双向持仓模式下的positionIdx 1:多仓 2:空仓，单向持仓为0
+	Side2PositionIdx = map[constant.OrderSide]int{
+		constant.Long:       1,
+		constant.CloseLong:  1,
+		constant.Short:      2,
+		constant.CloseShort: 2,
+	}
+
+	Bybit2Status = map[string]constant.OrderStatus{
+		"New":                     constant.OrderOpen,
+		"PartiallyFilled":         constant.OrderPartialFilled,
+		"Untriggered":             constant.OrderOpen,
+		"Triggered":               constant.OrderTriggered,
+		"Filled":                  constant.OrderFilled,
+		"Cancelled":               constant.OrderCanceled,
+		"PartiallyFilledCanceled": constant.OrderCanceled, // 没有单独的部分成交后撤单状态，已成交数量见ExecutedQty
+		"Deactivated":             constant.OrderCanceled,
+		"Rejected":                constant.OrderRejected,
+	}
+
+	// tradeMode 0:全仓 1:逐仓
+	Bybit2MarginMode = map[int]string{
+		0: "CROSSED",
+		1: "FIXED",
+	}
+
+	// 通用周期转为bybit的周期，其他值原样传入
+	Interval2Bybit = map[string]string{
+		"1m":  "1",
+		"3m":  "3",
+		"5m":  "5",
+		"15m": "15",
+		"30m": "30",
+		"1h":  "60",
+		"2h":  "120",
+		"4h":  "240",
+		"6h":  "360",
+		"12h": "720",
+		"1d":  "D",
+		"1w":  "W",
+		"1M":  "M",
+	}
+)
+
+const (
+	FetchKlineUri              = "/v5/market/kline"
+	FetchSymbolsUri            = "/v5/market/instruments-info"
+	FetchTickersUri            = "/v5/market/tickers"
+	FetchOrderBookUri          = "/v5/market/orderbook"
+	FetchFundingRateHistoryUri = "/v5/market/funding/history"
+	FetchBalanceUri            = "/v5/account/wallet-balance"
+	FetchAssetBalanceUri       = "/v5/asset/transfer/query-account-coins-balance"
+	FetchPositionsUri          = "/v5/position/list"
+	CreateBatchOrderUri        = "/v5/order/create-batch"
+	CancelBatchOrderUri        = "/v5/order/cancel-batch"
+	AmendBatchOrderUri         = "/v5/order/amend-batch"
+	FetchOpenOrderUri          = "/v5/order/realtime"
+	FetchOrderHistoryUri       = "/v5/order/history"
+	FetchUserTradesUri         = "/v5/execution/list"
+	SetLeverageUri             = "/v5/position/set-leverage"
+	SetMarginModeUri           = "/v5/account/set-margin-mode"
+	SwitchPositionModeUri      = "/v5/position/switch-mode"
+
+	recvWindow = "5000"
+
+	// 批量下单/撤单/改单单次最多的订单数，现货10笔，合约20笔
+	maxBatchOrders = 10
+
+	leverageNotModified = 110043 // 杠杆未变化
+)
+
+// bybit交易对常见的计价币种，按长度优先匹配
+var quoteCoins = []string{"USDT", "USDC", "USDE", "EUR", "BTC", "ETH", "DAI", "BRZ"}
+
+// category 产品类型，现货spot，USDT/USDC永续linear
+// environments 各环境的地址，现货和合约的公共连接地址不同
+// 模拟交易没有单独的行情，公共连接使用实盘地址
+func environments(exchangeType constant.ExchangeType) map[constant.Environment]base.Endpoints {
+	category := "spot"
+	pubWsUrl := PubSpotWsUrl
+	if exchangeType == constant.BybitV5Linear {
+		category = "linear"
+		pubWsUrl = PubLinearWsUrl
+	}
+	return map[constant.Environment]base.Endpoints{
+		constant.EnvProduction: {
+			RestUrl:  RestUrl,
+			PubWsUrl: pubWsUrl,
+			PriWsUrl: PriWsUrl,
+		},
+		constant.EnvTestnet: {
+			RestUrl:  "https://api-testnet.bybit.com",
+			PubWsUrl: "wss://stream-testnet.bybit.com/v5/public/" + category,
+			PriWsUrl: "wss://stream-testnet.bybit.com/v5/private",
+		},
+		constant.EnvDemo: {
+			RestUrl:  "https://api-demo.bybit.com",
+			PubWsUrl: pubWsUrl,
+			PriWsUrl: "wss://stream-demo.bybit.com/v5/private",
+		},
+	}
+}
+
+func category(exchangeType constant.ExchangeType) string {
+	if exchangeType == constant.BybitV5Linear {
+		return "linear"
+	}
+	return "spot"
+}
+
+// Symbol2Bybit BTC_USDT => BTCUSDT，USDC永续合约为 BTCPERP
+func Symbol2Bybit(symbol string, exchangeType constant.ExchangeType) string {
+	tmp := strings.Split(symbol, "_")
+	if len(tmp) != 2 {
+		panic("bad symbol:" + symbol)
+	}
+	if exchangeType == constant.BybitV5Linear && tmp[1] == "USDC" {
+		return tmp[0] + "PERP"
+	}
+	return tmp[0] + tmp[1]
+}
+
+// Bybit2Symbol BTCUSDT => BTC_USDT, BTCPERP => BTC_USDC
+func Bybit2Symbol(symbol string) string {
+	if strings.HasSuffix(symbol, "PERP") && len(symbol) > 4 {
+		return symbol[:len(symbol)-4] + "_USDC"
+	}
+	for _, quote := range quoteCoins {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return symbol[:len(symbol)-len(quote)] + "_" + quote
+		}
+	}
+	return symbol
+}
+
+func bybitCategory2ExchangeType(category string) constant.ExchangeType {
+	if category == "spot" {
+		return constant.BybitV5Spot
+	}
+	return constant.BybitV5Linear
+}
+
+// matchAccountType 统一账户的钱包现货和合约共用，经典账户按SPOT/CONTRACT区分
+func matchAccountType(accountType string, exchangeType constant.ExchangeType) bool {
+	switch accountType {
+	case "SPOT":
+		return exchangeType == constant.BybitV5Spot
+	case "CONTRACT":
+		return exchangeType == constant.BybitV5Linear
+	}
+	return true
+}
+
+// intervalMillis K线周期的毫秒数，月线按31天计算
+func intervalMillis(interval string) int64 {
+	switch interval {
+	case "D":
+		return int64(24 * time.Hour / time.Millisecond)
+	case "W":
+		return int64(7 * 24 * time.Hour / time.Millisecond)
+	case "M":
+		return int64(31 * 24 * time.Hour / time.Millisecond)
+	}
+	minutes, _ := strconv.ParseInt(interval, 10, 64)
+	return minutes * int64(time.Minute/time.Millisecond)
+}
+
+// sign 签名字符串为 timestamp + apiKey + recvWindow + (query string 或 json body)
+func sign(timestamp, apiKey, payload, secretKey string) string {
+	return utils.GenHexDigest(utils.HmacSha256(timestamp+apiKey+recvWindow+payload, secretKey))
+}
@@ -0,0 +1,50 @@
+package bybitv5
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+func TestSymbolConvert(t *testing.T) {
+	cases := []struct {
+		symbol       string
+		exchangeType constant.ExchangeType
+		bybit        string
+	}{
+		{"BTC_USDT", constant.BybitV5Spot, "BTCUSDT"},
+		{"BTC_USDC", constant.BybitV5Spot, "BTCUSDC"},
+		{"BTC_USDT", constant.BybitV5Linear, "BTCUSDT"},
+		{"BTC_USDC", constant.BybitV5Linear, "BTCPERP"},
+		{"1000PEPE_USDT", constant.BybitV5Linear, "1000PEPEUSDT"},
+	}
+	for _, c := range cases {
+		if got := Symbol2Bybit(c.symbol, c.exchangeType); got != c.bybit {
+			t.Errorf("Symbol2Bybit(%s) = %s, want %s", c.symbol, got, c.bybit)
+		}
+		if got := Bybit2Symbol(c.bybit); got != c.symbol {
+			t.Errorf("Bybit2Symbol(%s) = %s, want %s", c.bybit, got, c.symbol)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	// timestamp+apiKey+recvWindow+payload的HMAC-SHA256
+	expect := "ccfb83a4990aaab72c1049cfd1dcf888f713c1fb105568d4526c6ced2f2973d8"
+	if got := sign("1658384314791", "key", "category=linear&symbol=BTCUSDT", "secret"); got != expect {
+		t.Fatalf("sign = %s, want %s", got, expect)
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	tests := map[string]constant.OrderStatus{
+		"Untriggered":             constant.OrderOpen,
+		"Triggered":               constant.OrderTriggered,
+		"PartiallyFilledCanceled": constant.OrderCanceled,
+	}
+	for status, want := range tests {
+		if got := Bybit2Status[status]; got != want {
+			t.Fatalf("%s: expect %s, got %s", status, want.Name(), got.Name())
+		}
+	}
+}
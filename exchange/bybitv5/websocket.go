@@ -0,0 +1,257 @@
+package bybitv5
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+const (
+	BookTickerTopic = "orderbook.1"
+	OrderBookTopic  = "orderbook.50"
+	TradeTopic      = "publicTrade"
+	OrderTopic      = "order"
+	WalletTopic     = "wallet"
+	PositionTopic   = "position"
+)
+
+type BybitWsData struct {
+	Op      string          `json:"op"`
+	Success bool            `json:"success"`
+	RetMsg  string          `json:"ret_msg"`
+	Topic   string          `json:"topic"`
+	Type    string          `json:"type"` // 深度频道 snapshot/delta
+	Ts      int64           `json:"ts"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type BybitImp struct {
+	accessKey    string
+	secretKey    string
+	isPrivate    bool
+	exchangeType constant.ExchangeType // 公共连接对应的产品类型
+	rspHandle    func(interface{})
+
+	books map[string]*localBook // key为topic
+}
+
+// NewBybitPubWsClient 现货和合约使用不同的公共连接
+func NewBybitPubWsClient(url string, exchangeType constant.ExchangeType, rspHandle func(interface{})) *ws.WsClient {
+	imp := &BybitImp{
+		exchangeType: exchangeType,
+		rspHandle:    rspHandle,
+		books:        make(map[string]*localBook),
+	}
+	return ws.NewWsClient(url, imp, exchangeType, 20*time.Second, 30*time.Second)
+}
+
+// NewBybitPriWsClient 私有连接推送所有产品类型的数据
+func NewBybitPriWsClient(url, accessKey, secretKey string, exchangeType constant.ExchangeType, rspHandle func(interface{})) *ws.WsClient {
+	imp := &BybitImp{
+		accessKey:    accessKey,
+		secretKey:    secretKey,
+		isPrivate:    true,
+		exchangeType: exchangeType,
+		rspHandle:    rspHandle,
+	}
+	return ws.NewWsClient(url, imp, exchangeType, 20*time.Second, 30*time.Second)
+}
+
+func (bybit *BybitImp) Ping(cli *ws.WsClient) {
+	cli.Write(map[string]interface{}{"op": "ping"})
+}
+
+func (bybit *BybitImp) OnConnected(cli *ws.WsClient, typ ws.ConnectType) {
+	if !bybit.isPrivate {
+		log.Info("bybit public ws connected")
+		// 重连后会重新推送快照
+		bybit.books = make(map[string]*localBook)
+		return
+	}
+	log.Info("bybit private ws connected")
+	bybit.Login(cli)
+}
+
+// Subscribe 私有频道symbol为空
+func (bybit *BybitImp) Subscribe(symbol string, topic string) map[string]interface{} {
+	arg := topic
+	if symbol != "" {
+		arg = topic + "." + Symbol2Bybit(symbol, bybit.exchangeType)
+	}
+	return map[string]interface{}{
+		"op":   "subscribe",
+		"args": []string{arg},
+	}
+}
+
+// Login 签名为 hex(hmac_sha256("GET/realtime" + expires))
+func (bybit *BybitImp) Login(cli *ws.WsClient) {
+	expires := strconv.FormatInt(time.Now().Add(10*time.Second).UnixMilli(), 10)
+	signature := utils.GenHexDigest(utils.HmacSha256("GET/realtime"+expires, bybit.secretKey))
+	cli.Write(map[string]interface{}{
+		"op":   "auth",
+		"args": []string{bybit.accessKey, expires, signature},
+	})
+	log.Infof("bybit login")
+}
+
+func (bybit *BybitImp) Handle(cli *ws.WsClient, bs []byte) {
+	var dat BybitWsData
+	if err := sonic.Unmarshal(bs, &dat); err != nil {
+		log.WithError(err).Error("unmarshal bybit ws data failed")
+		return
+	}
+
+	switch dat.Op {
+	case "ping", "pong":
+		cli.SetRecvPongTime(time.Now())
+		return
+	case "auth", "subscribe":
+		if !dat.Success {
+			log.WithField("op", dat.Op).Errorf("bybit ws error: %s", dat.RetMsg)
+		} else {
+			log.WithField("op", dat.Op).Info("bybit ws success")
+		}
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(dat.Topic, "orderbook."):
+		bybit.onOrderBook(&dat)
+	case strings.HasPrefix(dat.Topic, TradeTopic+"."):
+		bybit.onTrades(dat.Data)
+	case dat.Topic == OrderTopic:
+		bybit.onOrders(dat.Data)
+	case dat.Topic == WalletTopic:
+		bybit.onWallet(dat.Data)
+	case dat.Topic == PositionTopic:
+		bybit.onPositions(dat.Data)
+	default:
+		log.WithField("dat", string(bs)).Warn("unknown bybit message")
+	}
+}
+
+// onOrderBook 快照重建本地订单簿，增量合并，一档深度转为BookTicker
+func (bybit *BybitImp) onOrderBook(dat *BybitWsData) {
+	localTs := utils.Microsec(time.Now())
+	var data BybitBookData
+	if err := sonic.Unmarshal(dat.Data, &data); err != nil {
+		log.WithError(err).Error("unmarshal bybit orderbook failed")
+		return
+	}
+
+	book, exist := bybit.books[dat.Topic]
+	if dat.Type == "snapshot" || data.UpdateId == 1 {
+		book = newLocalBook(&data)
+		bybit.books[dat.Topic] = book
+	} else {
+		if !exist {
+			log.Warnf("bybit %s update before snapshot", dat.Topic)
+			return
+		}
+		book.update(&data)
+	}
+
+	if strings.HasPrefix(dat.Topic, BookTickerTopic+".") {
+		bybit.rspHandle(book.toBookTicker(bybit.exchangeType, dat.Ts, localTs))
+		return
+	}
+	bybit.rspHandle(book.toOrderBook(bybit.exchangeType, dat.Ts))
+}
+
+func (bybit *BybitImp) onTrades(dat json.RawMessage) {
+	curTs := utils.Microsec(time.Now())
+
+	type Trade struct {
+		Ts      int64  `json:"T"`
+		Symbol  string `json:"s"`
+		Side    string `json:"S"`
+		Size    string `json:"v"`
+		Price   string `json:"p"`
+		TradeID string `json:"i"`
+	}
+
+	var trades []Trade
+	if err := sonic.Unmarshal(dat, &trades); err != nil {
+		log.WithError(err).Error("unmarshal bybit trades failed")
+		return
+	}
+
+	result := make([]*types.Trade, 0, len(trades))
+	for _, trade := range trades {
+		price, _ := strconv.ParseFloat(trade.Price, 64)
+		size, _ := strconv.ParseFloat(trade.Size, 64)
+		evt := &types.Trade{
+			Symbol:     Bybit2Symbol(trade.Symbol),
+			MarketType: bybit.exchangeType,
+			TradeID:    trade.TradeID,
+			Side:       Bybit2Side[trade.Side],
+			Price:      price,
+			Size:       size,
+			Count:      1,
+			ExchangeTs: trade.Ts * 1000,
+			LocalTs:    curTs,
+			EventTs:    utils.Microsec(time.Now()),
+		}
+		result = append(result, evt)
+	}
+	bybit.rspHandle(result)
+}
+
+func (bybit *BybitImp) onOrders(dat json.RawMessage) {
+	var orders []*BybitOrder
+	if err := sonic.Unmarshal(dat, &orders); err != nil {
+		log.WithError(err).Error("unmarshal bybit orders failed")
+		return
+	}
+
+	// 私有连接推送所有产品类型，只保留本实例的category
+	result := make([]*types.Order, 0, len(orders))
+	for _, item := range orders {
+		if item.Category != category(bybit.exchangeType) {
+			continue
+		}
+		result = append(result, bybitOrderTransform(item, bybit.exchangeType))
+	}
+	if len(result) > 0 {
+		bybit.rspHandle(result)
+	}
+}
+
+func (bybit *BybitImp) onWallet(dat json.RawMessage) {
+	var wallets []*BybitWallet
+	if err := sonic.Unmarshal(dat, &wallets); err != nil {
+		log.WithError(err).Error("unmarshal bybit wallet failed")
+		return
+	}
+	for _, wallet := range wallets {
+		if !matchAccountType(wallet.AccountType, bybit.exchangeType) {
+			continue
+		}
+		bybit.rspHandle(walletTransform(wallet))
+	}
+}
+
+func (bybit *BybitImp) onPositions(dat json.RawMessage) {
+	var positions []*BybitPosition
+	if err := sonic.Unmarshal(dat, &positions); err != nil {
+		log.WithError(err).Error("unmarshal bybit positions failed")
+		return
+	}
+	result := make([]*BybitPosition, 0, len(positions))
+	for _, item := range positions {
+		if item.Category == category(bybit.exchangeType) {
+			result = append(result, item)
+		}
+	}
+	if len(result) > 0 {
+		bybit.rspHandle(positionsTransform(result, true))
+	}
+}
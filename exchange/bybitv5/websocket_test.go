@@ -0,0 +1,45 @@
+package bybitv5
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestPrivatePushFilterByCategory(t *testing.T) {
+	var pushed []interface{}
+	imp := &BybitImp{
+		isPrivate:    true,
+		exchangeType: constant.BybitV5Linear,
+		rspHandle:    func(v interface{}) { pushed = append(pushed, v) },
+	}
+
+	imp.onOrders([]byte(`[
+		{"category":"spot","symbol":"BTCUSDT","orderId":"1","side":"Buy","orderType":"Limit","price":"100","qty":"1","orderStatus":"New"},
+		{"category":"linear","symbol":"BTCUSDT","orderId":"2","side":"Buy","orderType":"Limit","price":"100","qty":"1","orderStatus":"New"}
+	]`))
+	if len(pushed) != 1 {
+		t.Fatalf("expect 1 push, got %d", len(pushed))
+	}
+	orders := pushed[0].([]*types.Order)
+	if len(orders) != 1 || orders[0].OrderID != "2" {
+		t.Fatalf("expect only linear order, got %+v", orders)
+	}
+
+	pushed = nil
+	imp.onPositions([]byte(`[{"category":"option","symbol":"BTC-28JUN24-60000-C","side":"Buy","size":"1"}]`))
+	if len(pushed) != 0 {
+		t.Fatalf("option position should be filtered, got %d", len(pushed))
+	}
+	imp.onPositions([]byte(`[{"category":"linear","symbol":"BTCUSDT","side":"Sell","size":"1"}]`))
+	if len(pushed) != 1 || pushed[0].([]*types.Position)[0].Side != constant.Short.Name() {
+		t.Fatalf("unexpected positions %+v", pushed)
+	}
+
+	pushed = nil
+	imp.onWallet([]byte(`[{"accountType":"SPOT","coin":[]},{"accountType":"UNIFIED","coin":[]}]`))
+	if len(pushed) != 1 {
+		t.Fatalf("expect only unified wallet, got %d", len(pushed))
+	}
+}
@@ -3,10 +3,13 @@ package exchange
 import (
 	"fmt"
 
+	"github.com/cybernonce/gotrader/exchange/binancecfutures"
 	"github.com/cybernonce/gotrader/exchange/binanceportfolio"
 	"github.com/cybernonce/gotrader/exchange/binancespot"
 	"github.com/cybernonce/gotrader/exchange/binanceufutures"
+	"github.com/cybernonce/gotrader/exchange/bybitv5"
 	"github.com/cybernonce/gotrader/exchange/okxv5"
+	"github.com/cybernonce/gotrader/exchange/pionex"
 	"github.com/cybernonce/gotrader/trader"
 	"github.com/cybernonce/gotrader/trader/constant"
 	"github.com/cybernonce/gotrader/trader/types"
@@ -18,13 +21,32 @@ func NewExchange(exchangeType constant.ExchangeType, params *types.ExchangeParam
 		return okxv5.NewOkxV5Swap(params)
 	case constant.OkxV5Spot:
 		return okxv5.NewOkxV5Spot(params)
+	case constant.OkxV5Future:
+		return okxv5.NewOkxV5Future(params)
 	case constant.BinanceSpot:
 		return binancespot.NewBinanceSpot(params)
 	case constant.BinanceUFutures:
 		return binanceufutures.NewBinanceUFutures(params)
+	case constant.BinanceCFutures:
+		return binancecfutures.NewBinanceCFutures(params)
 	case constant.BinancePortfolio:
 		return binanceportfolio.NewBinancePortfoli(params)
+	case constant.BybitV5Spot:
+		return bybitv5.NewBybitV5Spot(params)
+	case constant.BybitV5Linear:
+		return bybitv5.NewBybitV5Linear(params)
+	case constant.PionexSpot:
+		return pionex.NewPionexSpot(params)
 	default:
 		panic(fmt.Sprintf("new exchange error [%v]", exchangeType))
 	}
 }
+
+var (
+	_ trader.Wallet = (*okxv5.OkxV5Exchange)(nil)
+	_ trader.Wallet = (*binancespot.BinanceSpotExchange)(nil)
+
+	_ trader.DerivativesStream = (*okxv5.OkxV5Exchange)(nil)
+	_ trader.DerivativesStream = (*binanceufutures.BinanceUFuturesExchange)(nil)
+	_ trader.DerivativesStream = (*binancecfutures.BinanceCFuturesExchange)(nil)
+)
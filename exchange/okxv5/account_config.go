@@ -0,0 +1,96 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type OkAccountConfig struct {
+	Uid     string `json:"uid"`
+	AcctLv  string `json:"acctLv"`
+	PosMode string `json:"posMode"` // long_short_mode/net_mode
+}
+
+type AccountConfigRsp struct {
+	BaseOkRsp
+	Data []*OkAccountConfig `json:"data"`
+}
+
+var (
+	PositionMode2Okx = map[constant.PositionMode]string{
+		constant.OneWayMode: "net_mode",
+		constant.HedgeMode:  "long_short_mode",
+	}
+)
+
+// SetLeverage 设置交易对的杠杆倍数和保证金模式，逐仓双向持仓模式需要带posSide，暂不支持
+func (client *RestClient) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	if marginMode == constant.MarginCash {
+		return fmt.Errorf("okx set leverage not support margin mode %s", marginMode.Name())
+	}
+	param := map[string]interface{}{
+		"instId":  Symbol2OkInstId(symbol),
+		"lever":   fmt.Sprintf("%d", leverage),
+		"mgnMode": marginMode.Name(),
+	}
+	return client.postAccountConfig(SetLeverageUri, param)
+}
+
+// SetPositionMode 设置持仓模式，有仓位或挂单时不能修改
+func (client *RestClient) SetPositionMode(mode constant.PositionMode) error {
+	param := map[string]interface{}{
+		"posMode": PositionMode2Okx[mode],
+	}
+	return client.postAccountConfig(SetPositionModeUri, param)
+}
+
+func (client *RestClient) postAccountConfig(uri string, param map[string]interface{}) error {
+	payload, _ := sonic.Marshal(param)
+	body, _, err := client.HttpRequest(http.MethodPost, uri, payload)
+	if err != nil {
+		log.Errorf("okx post %s err: %v", uri, err)
+		return err
+	}
+	response := new(BaseOkRsp)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("okx post %s parser err:%v", uri, err)
+		return err
+	}
+	if response.Code != "0" {
+		return fmt.Errorf("okx post %s fail, code:%s, msg:%s", uri, response.Code, response.Msg)
+	}
+	return nil
+}
+
+func (client *RestClient) FetchAccountConfig() (*types.AccountConfig, error) {
+	body, _, err := client.HttpRequest(http.MethodGet, FetchAccountConfigUri, nil)
+	if err != nil {
+		log.Errorf("ok get /api/v5/account/config err:%v", err)
+		return nil, err
+	}
+	response := new(AccountConfigRsp)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("ok get /api/v5/account/config parser err:%v", err)
+		return nil, err
+	}
+	if response.Code != "0" || len(response.Data) == 0 {
+		err := fmt.Errorf("ok get /api/v5/account/config fail, code:%s, msg:%s", response.Code, response.Msg)
+		return nil, err
+	}
+
+	config := response.Data[0]
+	result := &types.AccountConfig{
+		Uid:          config.Uid,
+		PositionMode: constant.OneWayMode,
+		AccountLevel: config.AcctLv,
+	}
+	if config.PosMode == "long_short_mode" {
+		result.PositionMode = constant.HedgeMode
+	}
+	return result, nil
+}
@@ -0,0 +1,296 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// OkAlgoOrder rest查询和orders-algo频道共用
+type OkAlgoOrder struct {
+	InstId         string `json:"instId"`
+	OrdType        string `json:"ordType"`
+	AlgoId         string `json:"algoId"`
+	AlgoClOrdId    string `json:"algoClOrdId"`
+	Side           string `json:"side"`
+	Sz             string `json:"sz"`
+	ReduceOnly     string `json:"reduceOnly"`
+	TriggerPx      string `json:"triggerPx"`
+	OrdPx          string `json:"ordPx"`
+	TpTriggerPx    string `json:"tpTriggerPx"`
+	TpOrdPx        string `json:"tpOrdPx"`
+	SlTriggerPx    string `json:"slTriggerPx"`
+	SlOrdPx        string `json:"slOrdPx"`
+	CallbackRatio  string `json:"callbackRatio"`
+	CallbackSpread string `json:"callbackSpread"`
+	ActivePx       string `json:"activePx"`
+	State          string `json:"state"`
+	OrdId          string `json:"ordId"`
+	CTime          string `json:"cTime"`
+	UTime          string `json:"uTime"`
+}
+
+type AlgoOrderResult struct {
+	AlgoId      string `json:"algoId"`
+	AlgoClOrdId string `json:"algoClOrdId"`
+	SCode       string `json:"sCode"`
+	SMsg        string `json:"sMsg"`
+}
+
+type AlgoOrderResponse struct {
+	BaseOkRsp
+	Data []*AlgoOrderResult `json:"data"`
+}
+
+type FetchAlgoOrderResponse struct {
+	BaseOkRsp
+	Data []*OkAlgoOrder `json:"data"`
+}
+
+// 查询未完成策略委托时ordType必填，只有conditional和oco可以同时查询
+var pendingAlgoOrdTypes = []string{"conditional,oco", "trigger", "move_order_stop"}
+
+// CreateAlgoOrders 策略委托没有批量接口，逐个调用 /api/v5/trade/order-algo
+func (client *RestClient) CreateAlgoOrders(orders []*types.AlgoOrder) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		param, err := formAlgoRequest(order)
+		if err != nil {
+			result = append(result, algoErrTransform(order, err.Error()))
+			continue
+		}
+		payload, _ := sonic.Marshal(param)
+		uri := CreateAlgoOrderUri
+		body, _, err := client.HttpRequest(http.MethodPost, uri, payload)
+		if err != nil {
+			log.Errorf("okx post /api/v5/trade/order-algo err: %v", err)
+			result = append(result, algoErrTransform(order, err.Error()))
+			continue
+		}
+		response := new(AlgoOrderResponse)
+		if err = sonic.Unmarshal(body, response); err != nil {
+			log.Errorf("okx post /api/v5/trade/order-algo err 数据解析失败:%v", err)
+			result = append(result, algoErrTransform(order, err.Error()))
+			continue
+		}
+		if len(response.Data) == 0 {
+			log.Errorf("okx post /api/v5/trade/order-algo err: %s", body)
+			result = append(result, algoErrTransform(order, response.Msg))
+			continue
+		}
+		result = append(result, algoResultTransform(response.Data[0]))
+	}
+	return result, nil
+}
+
+// CancelAlgoOrders 批量撤销策略委托，需要指定AlgoID
+func (client *RestClient) CancelAlgoOrders(orders []*types.AlgoOrder) ([]*types.OrderResult, error) {
+	param := make([]map[string]interface{}, 0, len(orders))
+	for _, order := range orders {
+		param = append(param, map[string]interface{}{
+			"instId": Symbol2OkInstId(order.Symbol),
+			"algoId": order.AlgoID,
+		})
+	}
+	payload, _ := sonic.Marshal(param)
+	uri := CancelAlgoOrderUri
+	body, _, err := client.HttpRequest(http.MethodPost, uri, payload)
+	if err != nil {
+		log.Errorf("okx post /api/v5/trade/cancel-algos err: %v", err)
+		return nil, err
+	}
+	response := new(AlgoOrderResponse)
+	if err = sonic.Unmarshal(body, response); err != nil {
+		log.Errorf("okx post /api/v5/trade/cancel-algos err 数据解析失败:%v", err)
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		err := fmt.Errorf("ok post /api/v5/trade/cancel-algos err: %v", response)
+		return nil, err
+	}
+
+	result := make([]*types.OrderResult, 0, len(response.Data))
+	for _, item := range response.Data {
+		result = append(result, algoResultTransform(item))
+	}
+	return result, nil
+}
+
+// FetchOpenAlgoOrders 查询未完成的策略委托，symbol为空时查询全部
+func (client *RestClient) FetchOpenAlgoOrders(symbol string) ([]*types.AlgoOrder, error) {
+	result := make([]*types.AlgoOrder, 0)
+	for _, ordType := range pendingAlgoOrdTypes {
+		queryDict := map[string]interface{}{
+			"ordType": ordType,
+		}
+		if symbol != "" {
+			queryDict["instId"] = Symbol2OkInstId(symbol)
+		}
+		url := fmt.Sprintf("%s?%s", FetchOpenAlgoOrderUri, utils.UrlEncodeParams(queryDict))
+		body, _, err := client.HttpRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Errorf("ok get /api/v5/trade/orders-algo-pending err:%v", err)
+			return nil, err
+		}
+		response := new(FetchAlgoOrderResponse)
+		if err = sonic.Unmarshal(body, response); err != nil {
+			log.Errorf("ok get /api/v5/trade/orders-algo-pending parser err:%v", err)
+			return nil, err
+		}
+		if response.Code != "0" {
+			err := fmt.Errorf("ok get /api/v5/trade/orders-algo-pending fail, code:%s, msg:%s", response.Code, response.Msg)
+			return nil, err
+		}
+		for _, item := range response.Data {
+			result = append(result, algoOrderTransform(item))
+		}
+	}
+	return result, nil
+}
+
+func formAlgoRequest(order *types.AlgoOrder) (map[string]interface{}, error) {
+	ordType, ok := AlgoType2Okx[order.Type.Name()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algo order type %s", order.Type.Name())
+	}
+	result := map[string]interface{}{
+		"instId":  Symbol2OkInstId(order.Symbol),
+		"tdMode":  order.MarginMode.Name(),
+		"side":    Side2Okx[order.Side.Name()],
+		"ordType": ordType,
+		"sz":      order.OrigQty,
+	}
+	if order.ClientID != "" {
+		result["algoClOrdId"] = order.ClientID
+	}
+	if order.ReduceOnly {
+		result["reduceOnly"] = true
+	}
+
+	switch order.Type {
+	case constant.StopLoss:
+		result["slTriggerPx"] = order.SlTriggerPrice
+		result["slOrdPx"] = algoOrderPrice(order.SlOrderPrice)
+	case constant.TakeProfit:
+		result["tpTriggerPx"] = order.TpTriggerPrice
+		result["tpOrdPx"] = algoOrderPrice(order.TpOrderPrice)
+	case constant.OCO:
+		result["tpTriggerPx"] = order.TpTriggerPrice
+		result["tpOrdPx"] = algoOrderPrice(order.TpOrderPrice)
+		result["slTriggerPx"] = order.SlTriggerPrice
+		result["slOrdPx"] = algoOrderPrice(order.SlOrderPrice)
+	case constant.Trigger:
+		result["triggerPx"] = order.TriggerPrice
+		result["orderPx"] = algoOrderPrice(order.OrderPrice)
+	case constant.TrailingStop:
+		if order.CallbackSpread != "" {
+			result["callbackSpread"] = order.CallbackSpread
+		} else {
+			result["callbackRatio"] = order.CallbackRatio
+		}
+		if order.ActivePrice != "" {
+			result["activePx"] = order.ActivePrice
+		}
+	}
+	return result, nil
+}
+
+// algoOrderPrice 未指定委托价时触发后按市价委托
+func algoOrderPrice(price string) string {
+	if price == "" {
+		return "-1"
+	}
+	return price
+}
+
+// okAlgoType conditional类型根据止盈止损参数还原
+func okAlgoType(item *OkAlgoOrder) constant.OrderType {
+	switch item.OrdType {
+	case "oco":
+		return constant.OCO
+	case "trigger":
+		return constant.Trigger
+	case "move_order_stop":
+		return constant.TrailingStop
+	}
+	if item.TpTriggerPx != "" && item.SlTriggerPx != "" {
+		return constant.OCO
+	}
+	if item.TpTriggerPx != "" {
+		return constant.TakeProfit
+	}
+	return constant.StopLoss
+}
+
+func algoOrderTransform(item *OkAlgoOrder) *types.AlgoOrder {
+	createAt, _ := strconv.ParseInt(item.CTime, 10, 64)
+	updateAt, _ := strconv.ParseInt(item.UTime, 10, 64)
+	side := constant.OrderBuy
+	if item.Side == "sell" {
+		side = constant.OrderSell
+	}
+	return &types.AlgoOrder{
+		Symbol:         OkInstId2Symbol(item.InstId),
+		Exchange:       instId2ExchangeType(item.InstId),
+		Type:           okAlgoType(item),
+		AlgoID:         item.AlgoId,
+		ClientID:       item.AlgoClOrdId,
+		Side:           side,
+		OrigQty:        item.Sz,
+		ReduceOnly:     strings.EqualFold(item.ReduceOnly, "true"),
+		TriggerPrice:   item.TriggerPx,
+		OrderPrice:     item.OrdPx,
+		TpTriggerPrice: item.TpTriggerPx,
+		TpOrderPrice:   item.TpOrdPx,
+		SlTriggerPrice: item.SlTriggerPx,
+		SlOrderPrice:   item.SlOrdPx,
+		CallbackRatio:  item.CallbackRatio,
+		CallbackSpread: item.CallbackSpread,
+		ActivePrice:    item.ActivePx,
+		Status:         Okex2AlgoStatus[item.State],
+		OrderID:        item.OrdId,
+		CreateAt:       createAt,
+		UpdateAt:       updateAt,
+	}
+}
+
+func algoResultTransform(info *AlgoOrderResult) *types.OrderResult {
+	var result types.OrderResult
+	if info.SCode == "0" {
+		result.IsSuccess = true
+	}
+	result.OrderId = info.AlgoId
+	result.ClientId = info.AlgoClOrdId
+	result.ErrMsg = info.SMsg
+	return &result
+}
+
+func algoErrTransform(order *types.AlgoOrder, msg string) *types.OrderResult {
+	return &types.OrderResult{
+		IsSuccess: false,
+		OrderId:   order.AlgoID,
+		ClientId:  order.ClientID,
+		ErrCode:   -1,
+		ErrMsg:    msg,
+	}
+}
+
+func (ok *OkImp) onAlgoOrders(dat json.RawMessage) {
+	var orders []*OkAlgoOrder
+	if err := sonic.Unmarshal(dat, &orders); err != nil {
+		log.WithError(err).Error("unmarshal ok orders-algo failed")
+		return
+	}
+	result := make([]*types.AlgoOrder, 0, len(orders))
+	for _, item := range orders {
+		result = append(result, algoOrderTransform(item))
+	}
+	ok.rspHandle(result)
+}
@@ -0,0 +1,63 @@
+package okxv5
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestFormAlgoRequest(t *testing.T) {
+	param, err := formAlgoRequest(&types.AlgoOrder{
+		Symbol:         "BTC_USDT_SWAP",
+		Type:           constant.StopLoss,
+		Side:           constant.OrderSell,
+		OrigQty:        "1",
+		SlTriggerPrice: "25000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if param["ordType"] != "conditional" || param["side"] != "sell" || param["slOrdPx"] != "-1" {
+		t.Fatalf("unexpected param %v", param)
+	}
+	if _, ok := param["tpTriggerPx"]; ok {
+		t.Fatalf("stop loss should not contain tp params %v", param)
+	}
+
+	if _, err = formAlgoRequest(&types.AlgoOrder{Symbol: "BTC_USDT", Type: constant.Limit}); err == nil {
+		t.Fatal("expect error for non algo order type")
+	}
+}
+
+func TestOkAlgoType(t *testing.T) {
+	cases := []struct {
+		item   OkAlgoOrder
+		expect constant.OrderType
+	}{
+		{OkAlgoOrder{OrdType: "conditional", SlTriggerPx: "1"}, constant.StopLoss},
+		{OkAlgoOrder{OrdType: "conditional", TpTriggerPx: "1"}, constant.TakeProfit},
+		{OkAlgoOrder{OrdType: "conditional", TpTriggerPx: "1", SlTriggerPx: "1"}, constant.OCO},
+		{OkAlgoOrder{OrdType: "oco"}, constant.OCO},
+		{OkAlgoOrder{OrdType: "move_order_stop"}, constant.TrailingStop},
+	}
+	for _, c := range cases {
+		if typ := okAlgoType(&c.item); typ != c.expect {
+			t.Errorf("%+v expect %s got %s", c.item, c.expect.Name(), typ.Name())
+		}
+	}
+}
+
+func TestAlgoOrderStatus(t *testing.T) {
+	cases := map[string]constant.OrderStatus{
+		"live":      constant.OrderOpen,
+		"effective": constant.OrderTriggered,
+		"canceled":  constant.OrderCanceled,
+	}
+	for state, expect := range cases {
+		order := algoOrderTransform(&OkAlgoOrder{InstId: "BTC-USDT-SWAP", OrdType: "trigger", State: state})
+		if order.Status != expect {
+			t.Errorf("%s expect %s got %s", state, expect.Name(), order.Status.Name())
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package okxv5
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// AmendBatchOrders 批量改单，Price/OrigQty为新的价格和数量，为空表示不修改
+func (client *RestClient) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	param := make([]map[string]interface{}, 0, len(orders))
+	for _, item := range orders {
+		param = append(param, formAmendRequest(item))
+	}
+	payload, _ := sonic.Marshal(param)
+	uri := AmendBatchOrderUri
+	body, _, err := client.HttpRequest(http.MethodPost, uri, payload)
+	if err != nil {
+		log.Errorf("okx post /api/v5/trade/amend-batch-orders err: %v", err)
+		return nil, err
+	}
+	response := new(CreateOrderResponse)
+	if err = sonic.Unmarshal(body, response); err != nil {
+		log.Errorf("okx post /api/v5/trade/amend-batch-orders err 数据解析失败:%v", err)
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		err := fmt.Errorf("ok post /api/v5/trade/amend-batch-orders err: %v", response)
+		return nil, err
+	}
+
+	result := make([]*types.OrderResult, 0, len(response.Data))
+	for _, item := range response.Data {
+		result = append(result, orderTransform("", item))
+	}
+	return base.NativeAmendResults(orders, result), nil
+}
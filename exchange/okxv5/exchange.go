@@ -3,6 +3,7 @@ package okxv5
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cybernonce/gotrader/exchange/base"
 	"github.com/cybernonce/gotrader/pkg/ws"
@@ -12,74 +13,84 @@ import (
 
 type OkxV5Exchange struct {
 	exchangeType constant.ExchangeType
+	endpoints    base.Endpoints
+	transport    *base.Transport
 
 	restClient   *RestClient
 	pubWsClients []*ws.WsClient // 修改为WebSocket客户端数组
 	pubWsMutex   sync.RWMutex   // 添加互斥锁以保护并发访问
 	pubWsIndex   int            // 当前使用的WebSocket索引
 	priWsClient  *ws.WsClient // 私有WebSocket客户端
+	bookWsClient *ws.WsClient // 深度频道WebSocket客户端
+	wsGateway    *WsOrderGateway // 私有ws下单
 
 	// callbacks
 	onBooktickerCallback func(*types.BookTicker)
 	onOrderCallback      func([]*types.Order)
 	onTradeCallback      func([]*types.Trade)
+	onOrderBookCallback  func(*types.OrderBook)
+	onBalanceCallback    func(*types.Assets)
+	onPositionCallback   func([]*types.Position)
+	onAlgoOrderCallback  func([]*types.AlgoOrder)
+
+	onFundingRateCallback  func(*types.FundingRate)
+	onMarkPriceCallback    func(*types.MarkPrice)
+	onIndexPriceCallback   func(*types.IndexPrice)
+	onOpenInterestCallback func(*types.OpenInterest)
+
+	// 强平频道按产品类型推送，按订阅的交易对过滤
+	liquidations *base.LiquidationFilter
 }
 
 // 最大WebSocket连接数
 const maxWsConnections = 20
 
 func NewOkxV5Swap(params *types.ExchangeParameters) *OkxV5Exchange {
-	apiKey := params.AccessKey
-	secretKey := params.SecretKey
-	passPhrase := params.Passphrase
-
-	// new client
-	client := NewRestClient(apiKey, secretKey, passPhrase, constant.OkxV5Swap)
-	exchange := &OkxV5Exchange{
-		exchangeType: constant.OkxV5Swap,
-		restClient:   client,
-		pubWsClients: make([]*ws.WsClient, 0, maxWsConnections), // 初始化WebSocket客户端数组
-	}
-	
-	// 创建第一个公共WebSocket连接
-	exchange.ensurePubWsClient()
-	
-	// priWsClient
-	if len(apiKey) > 0 {
-		priWsClient := NewOkPriWsClient(apiKey, secretKey, passPhrase, exchange.OnPriWsHandle)
-		if err := priWsClient.Dial(ws.Connect); err != nil {
-			log.Errorf("priWsClient.Dial err %s", err)
-		} else {
-			exchange.priWsClient = priWsClient
-			log.Infof("priWsClient.Dial success")
-		}
-	}
-	return exchange
+	return newOkxV5Exchange(params, constant.OkxV5Swap)
 }
 
 func NewOkxV5Spot(params *types.ExchangeParameters) *OkxV5Exchange {
+	return newOkxV5Exchange(params, constant.OkxV5Spot)
+}
+
+// NewOkxV5Future 交割合约，symbol格式为 BTC_USD_240329
+func NewOkxV5Future(params *types.ExchangeParameters) *OkxV5Exchange {
+	return newOkxV5Exchange(params, constant.OkxV5Future)
+}
+
+func newOkxV5Exchange(params *types.ExchangeParameters, exchangeType constant.ExchangeType) *OkxV5Exchange {
 	apiKey := params.AccessKey
 	secretKey := params.SecretKey
 	passPhrase := params.Passphrase
 
+	endpoints := base.MustResolveEndpoints(params, Environments)
+	transport := base.MustNewTransport(params)
+
 	// new client
-	client := NewRestClient(apiKey, secretKey, passPhrase, constant.OkxV5Spot)
+	client := NewRestClient(apiKey, secretKey, passPhrase, exchangeType)
+	client.SetEndpoints(endpoints)
+	client.SetHttpClient(transport.HttpClient)
 	exchange := &OkxV5Exchange{
-		exchangeType: constant.OkxV5Spot,
+		exchangeType: exchangeType,
+		endpoints:    endpoints,
+		transport:    transport,
 		restClient:   client,
 		pubWsClients: make([]*ws.WsClient, 0, maxWsConnections), // 初始化WebSocket客户端数组
+		liquidations: base.NewLiquidationFilter(),
 	}
-	
+
 	// 创建第一个公共WebSocket连接
 	exchange.ensurePubWsClient()
 
+	// priWsClient
 	if len(apiKey) > 0 {
-		priWsClient := NewOkPriWsClient(apiKey, secretKey, passPhrase, exchange.OnPriWsHandle)
+		priWsClient := NewOkPriWsClient(endpoints.PriWsUrl, apiKey, secretKey, passPhrase, exchange.OnPriWsHandle)
+		priWsClient.SetDialer(transport.WsDialer)
 		if err := priWsClient.Dial(ws.Connect); err != nil {
 			log.Errorf("priWsClient.Dial err %s", err)
-
 		} else {
 			exchange.priWsClient = priWsClient
+			exchange.wsGateway = NewWsOrderGateway(priWsClient)
 			log.Infof("priWsClient.Dial success")
 		}
 	}
@@ -92,7 +103,8 @@ func (okx *OkxV5Exchange) ensurePubWsClient() *ws.WsClient {
 	defer okx.pubWsMutex.Unlock()
 	
 	if len(okx.pubWsClients) == 0 {
-		pubWsClient := NewOkPubWsClient(okx.OnPubWsHandle)
+		pubWsClient := NewOkPubWsClient(okx.endpoints.PubWsUrl, okx.OnPubWsHandle)
+		pubWsClient.SetDialer(okx.transport.WsDialer)
 		if err := pubWsClient.Dial(ws.Connect); err != nil {
 			log.Errorf("pubWsClient.Dial err %s", err)
 			return nil
@@ -113,7 +125,8 @@ func (okx *OkxV5Exchange) getNextPubWsClient() *ws.WsClient {
 	
 	// 如果还可以创建更多连接
 	if len(okx.pubWsClients) < maxWsConnections {
-		pubWsClient := NewOkPubWsClient(okx.OnPubWsHandle)
+		pubWsClient := NewOkPubWsClient(okx.endpoints.PubWsUrl, okx.OnPubWsHandle)
+		pubWsClient.SetDialer(okx.transport.WsDialer)
 		if err := pubWsClient.Dial(ws.Connect); err != nil {
 			log.Errorf("pubWsClient.Dial err %s", err)
 			// 如果创建新连接失败，使用现有连接
@@ -157,6 +170,10 @@ func (okx *OkxV5Exchange) FetchKline(symbol string, interval string, limit int64
 	return okx.restClient.FetchKline(symbol, interval, limit)
 }
 
+func (okx *OkxV5Exchange) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return okx.restClient.FetchHistoryKline(param)
+}
+
 func (okx *OkxV5Exchange) FetchFundingRate(symbol string) (*types.FundingRate, error) {
 	return okx.restClient.FetchFundingRate(symbol)
 }
@@ -181,6 +198,38 @@ func (okx *OkxV5Exchange) FetchPositons() ([]*types.Position, error) {
 	return okx.restClient.FetchPositons()
 }
 
+func (okx *OkxV5Exchange) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	return okx.restClient.FetchOpenOrders(symbol)
+}
+
+func (okx *OkxV5Exchange) FetchOrder(order *types.Order) (*types.Order, error) {
+	return okx.restClient.FetchOrder(order)
+}
+
+func (okx *OkxV5Exchange) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return okx.restClient.FetchUserTrades(param)
+}
+
+func (okx *OkxV5Exchange) FetchCurrencies() ([]*types.Currency, error) {
+	return okx.restClient.FetchCurrencies()
+}
+
+func (okx *OkxV5Exchange) FetchDepositAddress(coin string, chain string) (*types.DepositAddress, error) {
+	return okx.restClient.FetchDepositAddress(coin, chain)
+}
+
+func (okx *OkxV5Exchange) Withdraw(param base.WithdrawParam) (string, error) {
+	return okx.restClient.Withdraw(param)
+}
+
+func (okx *OkxV5Exchange) FetchDepositHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	return okx.restClient.FetchDepositHistory(param)
+}
+
+func (okx *OkxV5Exchange) FetchWithdrawHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	return okx.restClient.FetchWithdrawHistory(param)
+}
+
 func (okx *OkxV5Exchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
 	return okx.restClient.CreateBatchOrders(orders)
 }
@@ -189,6 +238,96 @@ func (okx *OkxV5Exchange) CancelBatchOrders(orders []*types.Order) ([]*types.Ord
 	return okx.restClient.CancelBatchOrders(orders)
 }
 
+func (okx *OkxV5Exchange) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	return okx.restClient.AmendBatchOrders(orders)
+}
+
+// CreateAlgoOrders 止盈止损、计划委托、移动止盈止损等策略委托，返回的OrderId为algoId
+func (okx *OkxV5Exchange) CreateAlgoOrders(orders []*types.AlgoOrder) ([]*types.OrderResult, error) {
+	return okx.restClient.CreateAlgoOrders(orders)
+}
+
+func (okx *OkxV5Exchange) CancelAlgoOrders(orders []*types.AlgoOrder) ([]*types.OrderResult, error) {
+	return okx.restClient.CancelAlgoOrders(orders)
+}
+
+func (okx *OkxV5Exchange) FetchOpenAlgoOrders(symbol string) ([]*types.AlgoOrder, error) {
+	return okx.restClient.FetchOpenAlgoOrders(symbol)
+}
+
+func (okx *OkxV5Exchange) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	return okx.restClient.SetLeverage(symbol, leverage, marginMode)
+}
+
+func (okx *OkxV5Exchange) SetPositionMode(mode constant.PositionMode) error {
+	return okx.restClient.SetPositionMode(mode)
+}
+
+func (okx *OkxV5Exchange) FetchAccountConfig() (*types.AccountConfig, error) {
+	return okx.restClient.FetchAccountConfig()
+}
+
+func (okx *OkxV5Exchange) FetchOrderBook(symbol string, depth int64) (*types.OrderBook, error) {
+	return okx.restClient.FetchOrderBook(symbol, depth)
+}
+
+// WsCreateBatchOrders 通过私有ws下单，阻塞等待响应
+func (okx *OkxV5Exchange) WsCreateBatchOrders(orders []*types.Order, timeout time.Duration) ([]*types.OrderResult, error) {
+	op, args := wsOrderArgs(orders, WsOpOrder, WsOpBatchOrders, formRequest)
+	return okx.wsCall(op, args, timeout)
+}
+
+func (okx *OkxV5Exchange) WsCancelBatchOrders(orders []*types.Order, timeout time.Duration) ([]*types.OrderResult, error) {
+	op, args := wsOrderArgs(orders, WsOpCancelOrder, WsOpBatchCancelOrders, formCancelRequest)
+	return okx.wsCall(op, args, timeout)
+}
+
+// WsAmendBatchOrders 修改订单价格和数量，Order.Price/OrigQty为新的价格和数量
+func (okx *OkxV5Exchange) WsAmendBatchOrders(orders []*types.Order, timeout time.Duration) ([]*types.OrderResult, error) {
+	op, args := wsOrderArgs(orders, WsOpAmendOrder, WsOpBatchAmendOrders, formAmendRequest)
+	return okx.wsCall(op, args, timeout)
+}
+
+// WsCreateBatchOrdersAsync 通过私有ws下单，不等待响应，收到响应或超时后回调
+func (okx *OkxV5Exchange) WsCreateBatchOrdersAsync(orders []*types.Order, timeout time.Duration, callback func([]*types.OrderResult, error)) error {
+	op, args := wsOrderArgs(orders, WsOpOrder, WsOpBatchOrders, formRequest)
+	return okx.wsSend(op, args, timeout, callback)
+}
+
+func (okx *OkxV5Exchange) WsCancelBatchOrdersAsync(orders []*types.Order, timeout time.Duration, callback func([]*types.OrderResult, error)) error {
+	op, args := wsOrderArgs(orders, WsOpCancelOrder, WsOpBatchCancelOrders, formCancelRequest)
+	return okx.wsSend(op, args, timeout, callback)
+}
+
+func (okx *OkxV5Exchange) WsAmendBatchOrdersAsync(orders []*types.Order, timeout time.Duration, callback func([]*types.OrderResult, error)) error {
+	op, args := wsOrderArgs(orders, WsOpAmendOrder, WsOpBatchAmendOrders, formAmendRequest)
+	return okx.wsSend(op, args, timeout, callback)
+}
+
+func (okx *OkxV5Exchange) wsCall(op string, args []map[string]interface{}, timeout time.Duration) ([]*types.OrderResult, error) {
+	if okx.wsGateway == nil {
+		return nil, fmt.Errorf("priWsClient is nil")
+	}
+	result, err := okx.wsGateway.Call(op, args, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
+func (okx *OkxV5Exchange) wsSend(op string, args []map[string]interface{}, timeout time.Duration, callback func([]*types.OrderResult, error)) error {
+	if okx.wsGateway == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	return okx.wsGateway.Send(op, args, timeout, func(result *OkWsOpResult, err error) {
+		if err != nil {
+			callback(nil, err)
+			return
+		}
+		callback(result.Results, nil)
+	})
+}
+
 func (okx *OkxV5Exchange) PrivateTransfer(transfer base.TransferParam) (string, error) {
 	return okx.restClient.PrivateTransfer(transfer)
 }
@@ -238,6 +377,48 @@ func (okx *OkxV5Exchange) SubscribeTrades(symbols []string, callback func([]*typ
 	return nil
 }
 
+// SubscribeOrderBook 订阅400档增量深度，回调为校验过的完整本地订单簿
+func (okx *OkxV5Exchange) SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) error {
+	return okx.SubscribeOrderBookChannel(symbols, "books", callback)
+}
+
+// SubscribeOrderBookChannel 支持 books/books5/books-l2-tbt/books50-l2-tbt，tbt频道需要apiKey
+func (okx *OkxV5Exchange) SubscribeOrderBookChannel(symbols []string, channel string, callback func(*types.OrderBook)) error {
+	if _, ok := incrementalBookChannels[channel]; !ok && channel != "books5" {
+		return fmt.Errorf("unsupported order book channel %s", channel)
+	}
+
+	wsClient := okx.getBookWsClient()
+	if wsClient == nil {
+		return fmt.Errorf("no available bookWsClient")
+	}
+
+	okx.onOrderBookCallback = callback
+	for _, symbol := range symbols {
+		wsClient.Subscribe(symbol, channel)
+	}
+	return nil
+}
+
+func (okx *OkxV5Exchange) getBookWsClient() *ws.WsClient {
+	okx.pubWsMutex.Lock()
+	defer okx.pubWsMutex.Unlock()
+
+	if okx.bookWsClient != nil {
+		return okx.bookWsClient
+	}
+	client := okx.restClient
+	bookWsClient := NewOkBookWsClient(okx.endpoints.PubWsUrl, client.apiKey, client.secretKey, client.passPhrase, client, okx.OnPubWsHandle)
+	bookWsClient.SetDialer(okx.transport.WsDialer)
+	if err := bookWsClient.Dial(ws.Connect); err != nil {
+		log.Errorf("bookWsClient.Dial err %s", err)
+		return nil
+	}
+	okx.bookWsClient = bookWsClient
+	log.Infof("bookWsClient.Dial success")
+	return bookWsClient
+}
+
 // SubscribeOrder 订阅订单频道
 func (okx *OkxV5Exchange) SubscribeOrders(symbols []string, callback func(orders []*types.Order)) error {
 	/***
@@ -259,6 +440,106 @@ func (okx *OkxV5Exchange) SubscribeOrders(symbols []string, callback func(orders
 	return nil
 }
 
+// SubscribeAlgoOrders 订阅策略委托频道
+func (okx *OkxV5Exchange) SubscribeAlgoOrders(symbols []string, callback func([]*types.AlgoOrder)) error {
+	if okx.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	okx.onAlgoOrderCallback = callback
+	for _, symbol := range symbols {
+		okx.priWsClient.Subscribe(symbol, "orders-algo")
+	}
+	return nil
+}
+
+// SubscribeBalance 订阅account频道，首次推送全量，之后只推送发生变化的币种
+func (okx *OkxV5Exchange) SubscribeBalance(callback func(*types.Assets)) (err error) {
+	if okx.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	okx.onBalanceCallback = callback
+	okx.priWsClient.Subscribe("", AccountChannel)
+	return nil
+}
+
+// SubscribePositions 订阅所有产品类型的positions频道
+func (okx *OkxV5Exchange) SubscribePositions(callback func([]*types.Position)) (err error) {
+	if okx.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	okx.onPositionCallback = callback
+	okx.priWsClient.Subscribe("", PositionsChannel)
+	return nil
+}
+
+// SubscribeBalanceAndPosition 订阅balance_and_position频道，成交等事件发生时同时推送余额和仓位变化，
+// 比account/positions频道更快，但只有现金余额和持仓数量、均价
+func (okx *OkxV5Exchange) SubscribeBalanceAndPosition(balanceCallback func(*types.Assets), positionCallback func([]*types.Position)) error {
+	if okx.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	okx.onBalanceCallback = balanceCallback
+	okx.onPositionCallback = positionCallback
+	okx.priWsClient.Subscribe("", BalanceAndPositionChannel)
+	return nil
+}
+
+// SubscribeFundingRates 资金费率约每分钟推送一次
+func (okx *OkxV5Exchange) SubscribeFundingRates(symbols []string, callback func(*types.FundingRate)) error {
+	okx.onFundingRateCallback = callback
+	return okx.subscribePublic(symbols, FundingRateChannel)
+}
+
+func (okx *OkxV5Exchange) SubscribeMarkPrices(symbols []string, callback func(*types.MarkPrice)) error {
+	okx.onMarkPriceCallback = callback
+	return okx.subscribePublic(symbols, MarkPriceChannel)
+}
+
+// SubscribeIndexPrices 合约symbol订阅其标的指数，推送的Symbol为 BTC_USDT
+func (okx *OkxV5Exchange) SubscribeIndexPrices(symbols []string, callback func(*types.IndexPrice)) error {
+	okx.onIndexPriceCallback = callback
+	return okx.subscribePublic(symbols, IndexTickersChannel)
+}
+
+func (okx *OkxV5Exchange) SubscribeOpenInterest(symbols []string, callback func(*types.OpenInterest)) error {
+	okx.onOpenInterestCallback = callback
+	return okx.subscribePublic(symbols, OpenInterestChannel)
+}
+
+// SubscribeLiquidations symbols为空时订阅当前产品类型的全部交易对
+func (okx *OkxV5Exchange) SubscribeLiquidations(symbols []string, callback func(*types.Liquidation)) error {
+	okx.liquidations.Add(symbols, callback)
+
+	instTypes := make(map[string]bool)
+	if len(symbols) == 0 {
+		instTypes[liquidationInstTypes[okx.exchangeType]] = true
+	}
+	for _, symbol := range symbols {
+		instTypes[liquidationInstType(symbol)] = true
+	}
+
+	for instType := range instTypes {
+		wsClient := okx.getNextPubWsClient()
+		if wsClient == nil {
+			return fmt.Errorf("no available pubWsClient")
+		}
+		wsClient.Subscribe(instType, LiquidationOrdersChannel)
+	}
+	return nil
+}
+
+func (okx *OkxV5Exchange) subscribePublic(symbols []string, channel string) error {
+	for _, symbol := range symbols {
+		// 使用负载均衡获取一个WebSocket客户端
+		wsClient := okx.getNextPubWsClient()
+		if wsClient == nil {
+			return fmt.Errorf("no available pubWsClient")
+		}
+		wsClient.Subscribe(symbol, channel)
+	}
+	return nil
+}
+
 func (okx *OkxV5Exchange) OnPubWsHandle(data interface{}) {
 	switch v := data.(type) {
 	case *types.BookTicker:
@@ -269,13 +550,44 @@ func (okx *OkxV5Exchange) OnPubWsHandle(data interface{}) {
 			log.Errorf("OnBookTicker Callback not set")
 		}
 	case *types.OrderBook:
-		fmt.Println("OrderBook type", v)
+		if okx.onOrderBookCallback != nil {
+			okx.onOrderBookCallback(v)
+		} else {
+			log.Errorf("onOrderBook Callback not set")
+		}
 	case []*types.Trade:
 		if okx.onTradeCallback != nil {
 			okx.onTradeCallback(v)
 		} else {
 			log.Errorf("onTrade Callback not set")
 		}
+	case *types.FundingRate:
+		if okx.onFundingRateCallback != nil {
+			okx.onFundingRateCallback(v)
+		} else {
+			log.Errorf("onFundingRate Callback not set")
+		}
+	case *types.MarkPrice:
+		if okx.onMarkPriceCallback != nil {
+			okx.onMarkPriceCallback(v)
+		} else {
+			log.Errorf("onMarkPrice Callback not set")
+		}
+	case *types.IndexPrice:
+		v.Exchange = okx.exchangeType
+		if okx.onIndexPriceCallback != nil {
+			okx.onIndexPriceCallback(v)
+		} else {
+			log.Errorf("onIndexPrice Callback not set")
+		}
+	case *types.OpenInterest:
+		if okx.onOpenInterestCallback != nil {
+			okx.onOpenInterestCallback(v)
+		} else {
+			log.Errorf("onOpenInterest Callback not set")
+		}
+	case *types.Liquidation:
+		okx.liquidations.Dispatch(v)
 	default:
 		log.Errorf("Unknown type %s", v)
 	}
@@ -289,6 +601,28 @@ func (okx *OkxV5Exchange) OnPriWsHandle(data interface{}) {
 		} else {
 			log.Errorf("onOrder Callback not set")
 		}
+	case []*types.AlgoOrder:
+		if okx.onAlgoOrderCallback != nil {
+			okx.onAlgoOrderCallback(v)
+		} else {
+			log.Errorf("onAlgoOrder Callback not set")
+		}
+	case *types.Assets:
+		if okx.onBalanceCallback != nil {
+			okx.onBalanceCallback(v)
+		} else {
+			log.Errorf("onBalance Callback not set")
+		}
+	case []*types.Position:
+		if okx.onPositionCallback != nil {
+			okx.onPositionCallback(v)
+		} else {
+			log.Errorf("onPosition Callback not set")
+		}
+	case *OkWsOpResult:
+		if okx.wsGateway != nil {
+			okx.wsGateway.OnResponse(v)
+		}
 	default:
 		log.Errorf("Unknown type %s", v)
 	}
@@ -102,7 +102,11 @@ func (client *RestClient) FetchBalance() (*types.Assets, error) {
 }
 
 func balanceTransform(response *BalanceRsp) (*types.Assets, error) {
-	bal := response.Data[0]
+	return okBalanceTransform(response.Data[0]), nil
+}
+
+// okBalanceTransform rest和ws account频道共用
+func okBalanceTransform(bal *OkBalance) *types.Assets {
 	assets := make(map[string]types.Asset, len(bal.Details))
 	for _, a := range bal.Details {
 		assets[a.Ccy] = a.ToAssets()
@@ -128,5 +132,5 @@ func balanceTransform(response *BalanceRsp) (*types.Assets, error) {
 		UniMMR:        uniMMr,
 		AccountMargin: accountMargin,
 		Borrowed:      borrowed,
-	}, nil
+	}
 }
@@ -32,7 +32,7 @@ func (client *RestClient) FetchFundingRate(symbol string) (*types.FundingRate, e
 	queryDict := map[string]interface{}{}
 	queryDict["instId"] = Symbol2OkInstId(symbol)
 	payload := utils.UrlEncodeParams(queryDict)
-	url := RestUrl + fmt.Sprintf("%s?%s", FetchFundingRateUri, payload)
+	url := client.restUrl + fmt.Sprintf("%s?%s", FetchFundingRateUri, payload)
 
 	body, _, err := client.HttpGet(url)
 	if err != nil {
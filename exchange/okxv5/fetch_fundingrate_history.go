@@ -31,7 +31,7 @@ func (client *RestClient) FetchFundingRateHistory(symbol string, limit int64) ([
 	queryDict["instId"] = Symbol2OkInstId(symbol)
 	queryDict["limit"] = limit
 	payload := utils.UrlEncodeParams(queryDict)
-	url := RestUrl + fmt.Sprintf("%s?%s", FetchFundingRateHistoryUri, payload)
+	url := client.restUrl + fmt.Sprintf("%s?%s", FetchFundingRateHistoryUri, payload)
 
 	body, _, err := client.HttpGet(url)
 	if err != nil {
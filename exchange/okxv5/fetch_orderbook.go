@@ -0,0 +1,102 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// rest深度接口单次最多返回400档
+const maxOrderBookDepth = 400
+
+type OkBookData struct {
+	Asks      [][]string `json:"asks"`
+	Bids      [][]string `json:"bids"`
+	Ts        string     `json:"ts"`
+	Checksum  int32      `json:"checksum"`
+	PrevSeqID int64      `json:"prevSeqId"`
+	SeqID     int64      `json:"seqId"`
+}
+
+type OrderBookRsp struct {
+	BaseOkRsp
+	Data []OkBookData `json:"data"`
+}
+
+func (t *OrderBookRsp) valid() bool {
+	return t.Code == "0" && len(t.Data) > 0
+}
+
+func (client *RestClient) FetchOrderBook(symbol string, depth int64) (*types.OrderBook, error) {
+	instId := Symbol2OkInstId(symbol)
+	data, err := client.fetchOrderBook(instId, depth)
+	if err != nil {
+		return nil, err
+	}
+	return orderBookTransform(instId, data), nil
+}
+
+func (client *RestClient) fetchOrderBook(instId string, depth int64) (*OkBookData, error) {
+	if depth <= 0 || depth > maxOrderBookDepth {
+		depth = maxOrderBookDepth
+	}
+	queryDict := map[string]interface{}{}
+	queryDict["instId"] = instId
+	queryDict["sz"] = depth
+
+	payload := utils.UrlEncodeParams(queryDict)
+	url := client.restUrl + fmt.Sprintf(OrderBookRest, payload)
+
+	body, _, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("ok get /api/v5/market/books err:%v", err)
+		return nil, err
+	}
+
+	response := new(OrderBookRsp)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("ok get /api/v5/market/books parser err:%v", err)
+		return nil, err
+	}
+
+	if !response.valid() {
+		err := fmt.Errorf("ok get /api/v5/market/books fail, code:%s, msg:%s", response.Code, response.Msg)
+		return nil, err
+	}
+	return &response.Data[0], nil
+}
+
+func orderBookTransform(instId string, data *OkBookData) *types.OrderBook {
+	return newOrderBook(instId, data.Ts, bookItemsTransform(data.Asks), bookItemsTransform(data.Bids))
+}
+
+func newOrderBook(instId string, ts string, asks, bids []types.OrderBookItem) *types.OrderBook {
+	exchangeTs, _ := strconv.ParseInt(ts, 10, 64)
+	return &types.OrderBook{
+		Symbol:     OkInstId2Symbol(instId),
+		Exchange:   instId2ExchangeType(instId),
+		Asks:       asks,
+		Bids:       bids,
+		ExchangeTs: exchangeTs * 1000,
+		Ts:         utils.Microsec(time.Now()),
+		TraceId:    utils.RandomString(8),
+	}
+}
+
+// bookItemsTransform 深度档位格式 [价格, 数量, 废弃字段, 订单数]
+func bookItemsTransform(levels [][]string) []types.OrderBookItem {
+	result := make([]types.OrderBookItem, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		price, _ := strconv.ParseFloat(level[0], 64)
+		qty, _ := strconv.ParseFloat(level[1], 64)
+		result = append(result, types.OrderBookItem{Price: price, Qty: qty})
+	}
+	return result
+}
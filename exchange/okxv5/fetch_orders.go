@@ -0,0 +1,175 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 查询接口单页最多返回的条数
+const maxPageLimit = 100
+
+type OkOrder struct {
+	InstId     string `json:"instId"`
+	OrdId      string `json:"ordId"`
+	ClOrdId    string `json:"clOrdId"`
+	Px         string `json:"px"`
+	Sz         string `json:"sz"`
+	OrdType    string `json:"ordType"`
+	Side       string `json:"side"`
+	PosSide    string `json:"posSide"`
+	TdMode     string `json:"tdMode"`
+	AccFillSz  string `json:"accFillSz"`
+	AvgPx      string `json:"avgPx"`
+	State      string `json:"state"`
+	Fee        string `json:"fee"`
+	FeeCcy     string `json:"feeCcy"`
+	ReduceOnly string `json:"reduceOnly"`
+	CTime      string `json:"cTime"`
+	UTime      string `json:"uTime"`
+}
+
+type OrdersRsp struct {
+	BaseOkRsp
+	Data []*OkOrder `json:"data"`
+}
+
+var (
+	Okx2OrderType = map[string]constant.OrderType{
+		"limit":     constant.Limit,
+		"market":    constant.Market,
+		"post_only": constant.PostOnly,
+		"fok":       constant.FOK,
+		"ioc":       constant.IOC,
+	}
+	Okx2MarginMode = map[string]constant.MarginMode{
+		"cross":    constant.MarginCross,
+		"isolated": constant.MarginIsolated,
+		"cash":     constant.MarginCash,
+	}
+)
+
+// FetchOpenOrders 查询当前产品类型的未成交订单，symbol为空时查询全部
+func (client *RestClient) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	result := make([]*types.Order, 0)
+	after := ""
+	for {
+		queryDict := map[string]interface{}{
+			"limit": maxPageLimit,
+		}
+		if symbol != "" {
+			queryDict["instId"] = Symbol2OkInstId(symbol)
+		} else {
+			queryDict["instType"] = client.instType()
+		}
+		if after != "" {
+			queryDict["after"] = after
+		}
+		url := fmt.Sprintf("%s?%s", FetchOpenOrderUri, utils.UrlEncodeParams(queryDict))
+		response, err := client.fetchOrders(url)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range response.Data {
+			result = append(result, okOrderTransform(item))
+		}
+		if len(response.Data) < maxPageLimit {
+			return result, nil
+		}
+		after = response.Data[len(response.Data)-1].OrdId
+	}
+}
+
+// FetchOrder 按OrderID或ClientID查询订单，未成交的撤单只能查到2小时内的
+func (client *RestClient) FetchOrder(order *types.Order) (*types.Order, error) {
+	queryDict := map[string]interface{}{
+		"instId": Symbol2OkInstId(order.Symbol),
+	}
+	if order.OrderID != "" {
+		queryDict["ordId"] = order.OrderID
+	} else {
+		queryDict["clOrdId"] = order.ClientID
+	}
+	url := fmt.Sprintf("%s?%s", FetchOrderWithIdUri, utils.UrlEncodeParams(queryDict))
+	response, err := client.fetchOrders(url)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("ok get /api/v5/trade/order empty")
+	}
+	return okOrderTransform(response.Data[0]), nil
+}
+
+func (client *RestClient) fetchOrders(url string) (*OrdersRsp, error) {
+	body, _, err := client.HttpRequest(http.MethodGet, url, nil)
+	if err != nil {
+		log.Errorf("ok get %s err:%v", url, err)
+		return nil, err
+	}
+	response := new(OrdersRsp)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("ok get %s parser err:%v", url, err)
+		return nil, err
+	}
+	if response.Code != "0" {
+		err := fmt.Errorf("ok get %s fail, code:%s, msg:%s", url, response.Code, response.Msg)
+		return nil, err
+	}
+	return response, nil
+}
+
+func okOrderTransform(item *OkOrder) *types.Order {
+	createAt, _ := strconv.ParseInt(item.CTime, 10, 64)
+	updateAt, _ := strconv.ParseInt(item.UTime, 10, 64)
+
+	var executedAmt string
+	accFillSz, _ := utils.ParseFloat(item.AccFillSz)
+	avgPx, _ := utils.ParseFloat(item.AvgPx)
+	if accFillSz > 0 {
+		executedAmt = strconv.FormatFloat(accFillSz*avgPx, 'f', -1, 64)
+	}
+
+	return &types.Order{
+		Symbol:      OkInstId2Symbol(item.InstId),
+		Exchange:    instId2ExchangeType(item.InstId),
+		Type:        Okx2OrderType[item.OrdType],
+		OrderID:     item.OrdId,
+		ClientID:    item.ClOrdId,
+		Side:        okOrderSide(item.Side, item.PosSide),
+		Price:       item.Px,
+		OrigQty:     item.Sz,
+		ExecutedQty: item.AccFillSz,
+		ExecutedAmt: executedAmt,
+		AvgPrice:    item.AvgPx,
+		Fee:         item.Fee,
+		Status:      Okex2Status[item.State],
+		ReduceOnly:  item.ReduceOnly == "true",
+		MarginMode:  Okx2MarginMode[item.TdMode],
+		CreateAt:    createAt,
+		UpdateAt:    updateAt,
+	}
+}
+
+// okOrderSide 双向持仓模式下结合posSide还原开平方向
+func okOrderSide(side, posSide string) constant.OrderSide {
+	switch {
+	case side == "buy" && posSide == "long":
+		return constant.Long
+	case side == "sell" && posSide == "long":
+		return constant.CloseLong
+	case side == "sell" && posSide == "short":
+		return constant.Short
+	case side == "buy" && posSide == "short":
+		return constant.CloseShort
+	case side == "buy":
+		return constant.OrderBuy
+	default:
+		return constant.OrderSell
+	}
+}
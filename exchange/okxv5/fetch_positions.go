@@ -64,12 +64,7 @@ func (o *PositionResponse) valid() bool {
 
 func (client *RestClient) FetchPositons() ([]*types.Position, error) {
 	queryDict := map[string]interface{}{}
-	if client.exchangeType == constant.OkxV5Swap {
-		queryDict["instType"] = "SWAP"
-	} else {
-		queryDict["instType"] = "SPOT"
-
-	}
+	queryDict["instType"] = client.instType()
 	payload := utils.UrlEncodeParams(queryDict)
 	url := fmt.Sprintf("%s?%s", FetchPositionsUri, payload)
 	body, _, err := client.HttpRequest(http.MethodGet, url, nil)
@@ -103,8 +98,13 @@ func (client *RestClient) FetchPositons() ([]*types.Position, error) {
 }
 
 func positionTransform(response *PositionResponse) ([]*types.Position, error) {
-	result := make([]*types.Position, 0, len(response.Data))
-	for _, item := range response.Data {
+	return positionsTransform(response.Data, false), nil
+}
+
+// positionsTransform keepEmpty为true时保留持仓为0的仓位，ws推送用来表示已平仓
+func positionsTransform(data []PositionData, keepEmpty bool) []*types.Position {
+	result := make([]*types.Position, 0, len(data))
+	for _, item := range data {
 		liquidationPx, err := utils.ParseFloat(item.LiqPx)
 		if err != nil {
 			liquidationPx = 0
@@ -137,7 +137,7 @@ func positionTransform(response *PositionResponse) ([]*types.Position, error) {
 		if err != nil {
 			leverage = 0
 		}
-		if math.Abs(position) == 0 {
+		if math.Abs(position) == 0 && !keepEmpty {
 			continue
 		}
 		info := &types.Position{
@@ -156,7 +156,7 @@ func positionTransform(response *PositionResponse) ([]*types.Position, error) {
 		info.Side = getPositionSide(item.PosSide, position)
 		result = append(result, info)
 	}
-	return result, nil
+	return result
 }
 
 func getPositionMargin(p PositionData) string {
@@ -166,13 +166,17 @@ func getPositionMargin(p PositionData) string {
 	return p.Imr
 }
 
+// getPositionSide 买卖模式(net)按数量正负判断方向，持仓为0时为空表示已平仓
 func getPositionSide(side string, pos float64) string {
 	switch side {
 	case "net":
 		if pos > 0 {
 			return constant.Long.Name()
 		}
-		return constant.Short.Name()
+		if pos < 0 {
+			return constant.Short.Name()
+		}
+		return ""
 	case "long":
 		return constant.Long.Name()
 	default:
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/cybernonce/gotrader/pkg/utils"
-	"github.com/cybernonce/gotrader/trader/constant"
 	"github.com/cybernonce/gotrader/trader/types"
 )
 
@@ -51,14 +50,9 @@ func (o *SymbolsResponse) valid() bool {
 
 func (client *RestClient) FetchSymbols() ([]*types.SymbolInfo, error) {
 	queryDict := map[string]interface{}{}
-	if client.exchangeType == constant.OkxV5Swap {
-		queryDict["instType"] = "SWAP"
-	} else {
-		queryDict["instType"] = "SPOT"
-
-	}
+	queryDict["instType"] = client.instType()
 	payload := utils.UrlEncodeParams(queryDict)
-	url := RestUrl + fmt.Sprintf("%s?%s", SymbolsRest, payload)
+	url := client.restUrl + fmt.Sprintf("%s?%s", SymbolsRest, payload)
 
 	body, _, err := client.HttpGet(url)
 	if err != nil {
@@ -119,6 +113,11 @@ func symbolTransform(response *SymbolsResponse) ([]*types.SymbolInfo, error) {
 		}
 		baseCoin := strings.ToLower(item.BaseCcy)
 		QuoteCoin := strings.ToLower(item.QuoteCcy)
+		// 合约没有baseCcy/quoteCcy，从标的指数获取
+		if baseCoin == "" && item.Uly != "" {
+			baseCoin, QuoteCoin = BaseQuote(strings.ToLower(strings.Replace(item.Uly, "-", "_", 1)))
+		}
+		expiry, _ := parseStringToInt(item.ExpTime)
 		info := &types.SymbolInfo{
 			Base:       baseCoin,
 			Quote:      QuoteCoin,
@@ -130,6 +129,7 @@ func symbolTransform(response *SymbolsResponse) ([]*types.SymbolInfo, error) {
 			MinCnt:     minCnt,
 			MaxCnt:     maxCnt,
 			Name:       baseCoin + "_" + QuoteCoin,
+			Expiry:     expiry,
 		}
 
 		result = append(result, info)
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/cybernonce/gotrader/pkg/utils"
-	"github.com/cybernonce/gotrader/trader/constant"
 	"github.com/cybernonce/gotrader/trader/types"
 )
 
@@ -41,12 +40,9 @@ type Ticker struct {
 
 func (client *RestClient) FetchTickers() ([]*types.Ticker, error) {
 	queryDict := map[string]interface{}{}
-	queryDict["instType"] = "SPOT"
-	if client.exchangeType == constant.OkxV5Swap {
-		queryDict["instType"] = "SWAP"
-	}
+	queryDict["instType"] = client.instType()
 	payload := utils.UrlEncodeParams(queryDict)
-	url := RestUrl + fmt.Sprintf(TickersRest, payload)
+	url := client.restUrl + fmt.Sprintf(TickersRest, payload)
 
 	body, _, err := client.HttpGet(url)
 	if err != nil {
@@ -0,0 +1,135 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type OkFill struct {
+	InstId   string `json:"instId"`
+	TradeId  string `json:"tradeId"`
+	OrdId    string `json:"ordId"`
+	ClOrdId  string `json:"clOrdId"`
+	BillId   string `json:"billId"`
+	FillPx   string `json:"fillPx"`
+	FillSz   string `json:"fillSz"`
+	Side     string `json:"side"`
+	PosSide  string `json:"posSide"`
+	ExecType string `json:"execType"` // T:taker M:maker
+	Fee      string `json:"fee"`      // 负数表示扣除手续费
+	FeeCcy   string `json:"feeCcy"`
+	Ts       string `json:"ts"`
+}
+
+type FillsRsp struct {
+	BaseOkRsp
+	Data []*OkFill `json:"data"`
+}
+
+// FetchUserTrades 查询近3个月的成交明细，结果按时间正序，Limit语义见base.UserTradeParam
+func (client *RestClient) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return pageUserTrades(param, func(query map[string]interface{}) ([]*OkFill, error) {
+		query["instType"] = client.instType()
+		if param.Symbol != "" {
+			instId := Symbol2OkInstId(param.Symbol)
+			query["instId"] = instId
+			query["instType"] = InstType(instId)
+		}
+		if param.OrderID != "" {
+			query["ordId"] = param.OrderID
+		}
+
+		url := fmt.Sprintf("%s?%s", FetchUserTradesUri, utils.UrlEncodeParams(query))
+		body, _, err := client.HttpRequest(http.MethodGet, url, nil)
+		if err != nil {
+			log.Errorf("ok get /api/v5/trade/fills-history err:%v", err)
+			return nil, err
+		}
+		response := new(FillsRsp)
+		if err = json.Unmarshal(body, response); err != nil {
+			log.Errorf("ok get /api/v5/trade/fills-history parser err:%v", err)
+			return nil, err
+		}
+		if response.Code != "0" {
+			err := fmt.Errorf("ok get /api/v5/trade/fills-history fail, code:%s, msg:%s", response.Code, response.Msg)
+			return nil, err
+		}
+		return response.Data, nil
+	})
+}
+
+// pageUserTrades 接口按时间倒序返回，按billId向前翻页
+// StartTime为0时拿到最近的Limit条即停止，否则需要翻到StartTime再取最早的Limit条
+func pageUserTrades(param base.UserTradeParam, fetch func(query map[string]interface{}) ([]*OkFill, error)) ([]*types.Fill, error) {
+	result := make([]*types.Fill, 0)
+	after := ""
+	for {
+		query := map[string]interface{}{
+			"limit": maxPageLimit,
+		}
+		if param.StartTime > 0 {
+			query["begin"] = param.StartTime
+		}
+		if param.EndTime > 0 {
+			query["end"] = param.EndTime
+		}
+		if after != "" {
+			query["after"] = after
+		}
+		data, err := fetch(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range data {
+			result = append(result, okFillTransform(item))
+		}
+		latestDone := param.StartTime == 0 && param.Limit > 0 && int64(len(result)) >= param.Limit
+		if len(data) < maxPageLimit || latestDone {
+			break
+		}
+		after = data[len(data)-1].BillId
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	if param.Limit > 0 && int64(len(result)) > param.Limit {
+		if param.StartTime > 0 {
+			return result[:param.Limit], nil
+		}
+		return result[int64(len(result))-param.Limit:], nil
+	}
+	return result, nil
+}
+
+func okFillTransform(item *OkFill) *types.Fill {
+	price, _ := utils.ParseFloat(item.FillPx)
+	qty, _ := utils.ParseFloat(item.FillSz)
+	fee, _ := utils.ParseFloat(item.Fee)
+	ts, _ := strconv.ParseInt(item.Ts, 10, 64)
+	fill := &types.Fill{
+		Symbol:   OkInstId2Symbol(item.InstId),
+		Exchange: instId2ExchangeType(item.InstId),
+		TradeID:  item.TradeId,
+		OrderID:  item.OrdId,
+		ClientID: item.ClOrdId,
+		Side:     okOrderSide(item.Side, item.PosSide),
+		Price:    price,
+		Qty:      qty,
+		Fee:      -fee,
+		FeeCoin:  item.FeeCcy,
+		Role:     constant.Taker,
+		Ts:       ts,
+	}
+	if item.ExecType == "M" {
+		fill.Role = constant.Maker
+	}
+	return fill
+}
@@ -0,0 +1,60 @@
+package okxv5
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+)
+
+// fakeFillsHistory 模拟fills-history，成交ID 1-250，billId与成交ID相同，时间为ID*1000，按时间倒序返回
+func fakeFillsHistory(calls *int) func(map[string]interface{}) ([]*OkFill, error) {
+	return func(query map[string]interface{}) ([]*OkFill, error) {
+		*calls++
+		first, last := int64(1), int64(250)
+		if v, ok := query["begin"]; ok {
+			first = v.(int64) / 1000
+		}
+		if v, ok := query["end"]; ok {
+			last = v.(int64) / 1000
+		}
+		if v, ok := query["after"]; ok {
+			after, _ := strconv.ParseInt(v.(string), 10, 64)
+			if after-1 < last {
+				last = after - 1
+			}
+		}
+		data := make([]*OkFill, 0, maxPageLimit)
+		for id := last; id >= first && len(data) < maxPageLimit; id-- {
+			s := strconv.FormatInt(id, 10)
+			data = append(data, &OkFill{InstId: "BTC-USDT", TradeId: s, BillId: s, Ts: strconv.FormatInt(id*1000, 10)})
+		}
+		return data, nil
+	}
+}
+
+func TestPageUserTradesLimit(t *testing.T) {
+	cases := []struct {
+		param       base.UserTradeParam
+		first, last string
+		count       int
+	}{
+		// 不指定StartTime时返回最近的Limit条
+		{base.UserTradeParam{Limit: 150}, "101", "250", 150},
+		{base.UserTradeParam{EndTime: 200 * 1000, Limit: 50}, "151", "200", 50},
+		// 指定StartTime时返回最早的Limit条
+		{base.UserTradeParam{StartTime: 10 * 1000, Limit: 150}, "10", "159", 150},
+		{base.UserTradeParam{StartTime: 10 * 1000, EndTime: 20 * 1000}, "10", "20", 11},
+		{base.UserTradeParam{}, "1", "250", 250},
+	}
+	for _, c := range cases {
+		calls := 0
+		fills, err := pageUserTrades(c.param, fakeFillsHistory(&calls))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fills) != c.count || fills[0].TradeID != c.first || fills[len(fills)-1].TradeID != c.last {
+			t.Fatalf("%+v expect %s-%s(%d), got %d fills", c.param, c.first, c.last, c.count, len(fills))
+		}
+	}
+}
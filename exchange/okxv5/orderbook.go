@@ -0,0 +1,198 @@
+package okxv5
+
+import (
+	"hash/crc32"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 校验和只计算买卖各前25档
+const checksumDepth = 25
+
+// 增量深度频道，需要在本地维护订单簿
+var incrementalBookChannels = map[string]int64{
+	"books":          400,
+	"books-l2-tbt":   400,
+	"books50-l2-tbt": 50,
+}
+
+type bookLevel struct {
+	px    string // 保留原始字符串用于计算校验和
+	sz    string
+	price float64
+}
+
+// localBook 单个instId的本地订单簿，asks价格升序，bids价格降序
+type localBook struct {
+	instId string
+	asks   []bookLevel
+	bids   []bookLevel
+	seqId  int64
+	ts     string
+
+	fromRest bool // rest快照没有seqId，在下一次校验和通过前不校验序号
+}
+
+func newLocalBook(instId string, data *OkBookData) *localBook {
+	book := &localBook{instId: instId, seqId: data.SeqID, ts: data.Ts}
+	book.asks = updateLevels(nil, data.Asks, true)
+	book.bids = updateLevels(nil, data.Bids, false)
+	return book
+}
+
+func (b *localBook) update(data *OkBookData) {
+	b.asks = updateLevels(b.asks, data.Asks, true)
+	b.bids = updateLevels(b.bids, data.Bids, false)
+	b.seqId = data.SeqID
+	b.ts = data.Ts
+}
+
+// updateLevels 数量为0表示删除该档位，否则替换或按顺序插入
+func updateLevels(levels []bookLevel, updates [][]string, asc bool) []bookLevel {
+	for _, item := range updates {
+		if len(item) < 2 {
+			continue
+		}
+		price, err := strconv.ParseFloat(item[0], 64)
+		if err != nil {
+			continue
+		}
+		qty, _ := strconv.ParseFloat(item[1], 64)
+
+		i := 0
+		for i < len(levels) && (asc && levels[i].price < price || !asc && levels[i].price > price) {
+			i++
+		}
+		found := i < len(levels) && levels[i].price == price
+		switch {
+		case qty == 0 && found:
+			levels = append(levels[:i], levels[i+1:]...)
+		case qty == 0:
+		case found:
+			levels[i].px, levels[i].sz = item[0], item[1]
+		default:
+			levels = append(levels, bookLevel{})
+			copy(levels[i+1:], levels[i:])
+			levels[i] = bookLevel{px: item[0], sz: item[1], price: price}
+		}
+	}
+	return levels
+}
+
+// checksum 按 bid1价:bid1量:ask1价:ask1量:bid2价... 拼接后计算crc32，结果为有符号整数
+func (b *localBook) checksum() int32 {
+	parts := make([]string, 0, checksumDepth*4)
+	for i := 0; i < checksumDepth; i++ {
+		if i < len(b.bids) {
+			parts = append(parts, b.bids[i].px, b.bids[i].sz)
+		}
+		if i < len(b.asks) {
+			parts = append(parts, b.asks[i].px, b.asks[i].sz)
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(strings.Join(parts, ":"))))
+}
+
+func (b *localBook) toOrderBook() *types.OrderBook {
+	asks := make([]types.OrderBookItem, 0, len(b.asks))
+	for _, level := range b.asks {
+		qty, _ := strconv.ParseFloat(level.sz, 64)
+		asks = append(asks, types.OrderBookItem{Price: level.price, Qty: qty})
+	}
+	bids := make([]types.OrderBookItem, 0, len(b.bids))
+	for _, level := range b.bids {
+		qty, _ := strconv.ParseFloat(level.sz, 64)
+		bids = append(bids, types.OrderBookItem{Price: level.price, Qty: qty})
+	}
+	return newOrderBook(b.instId, b.ts, asks, bids)
+}
+
+// onBooks 处理增量深度，快照重建本地订单簿，增量校验seqId和checksum后合并
+func (ok *OkImp) onBooks(cli *ws.WsClient, channel, instId, action string, dat []byte) {
+	var datas []OkBookData
+	if err := sonic.Unmarshal(dat, &datas); err != nil {
+		log.WithError(err).Error("unmarshal ok books failed")
+		return
+	}
+
+	for i := range datas {
+		data := &datas[i]
+		book, exist := ok.books[instId]
+		if action == "snapshot" {
+			book = newLocalBook(instId, data)
+			ok.books[instId] = book
+		} else {
+			if !exist {
+				log.Warnf("ok %s %s update before snapshot", channel, instId)
+				ok.resyncBook(cli, channel, instId)
+				return
+			}
+			// seqId与prevSeqId相同表示深度没有变化
+			if !book.fromRest && data.PrevSeqID != book.seqId {
+				log.Warnf("ok %s %s seq gap, local:%d prevSeqId:%d", channel, instId, book.seqId, data.PrevSeqID)
+				ok.resyncBook(cli, channel, instId)
+				return
+			}
+			book.update(data)
+		}
+
+		if sum := book.checksum(); sum != data.Checksum {
+			log.Warnf("ok %s %s checksum mismatch, local:%d remote:%d", channel, instId, sum, data.Checksum)
+			ok.resyncBook(cli, channel, instId)
+			return
+		}
+		book.fromRest = false
+		ok.rspHandle(book.toOrderBook())
+	}
+}
+
+// resyncBook 先用rest快照恢复本地订单簿，rest失败或刚从rest恢复仍不一致时重新订阅获取推送快照
+func (ok *OkImp) resyncBook(cli *ws.WsClient, channel, instId string) {
+	prev, exist := ok.books[instId]
+	delete(ok.books, instId)
+
+	if ok.restClient == nil || (exist && prev.fromRest) {
+		ok.resubscribe(cli, channel, instId)
+		return
+	}
+
+	data, err := ok.restClient.fetchOrderBook(instId, incrementalBookChannels[channel])
+	if err != nil {
+		log.Errorf("ok resync %s from rest err:%v", instId, err)
+		ok.resubscribe(cli, channel, instId)
+		return
+	}
+	// rest快照没有checksum，等下一次增量校验通过后再推送
+	book := newLocalBook(instId, data)
+	book.fromRest = true
+	ok.books[instId] = book
+	log.Infof("ok resync %s %s from rest", channel, instId)
+}
+
+func (ok *OkImp) resubscribe(cli *ws.WsClient, channel, instId string) {
+	args := []map[string]string{
+		{
+			"channel": channel,
+			"instId":  instId,
+		},
+	}
+	cli.Write(map[string]interface{}{"op": "unsubscribe", "args": args})
+	cli.Write(map[string]interface{}{"op": "subscribe", "args": args})
+	log.Infof("ok resubscribe %s %s", channel, instId)
+}
+
+// onBooks5 books5每次推送完整的5档深度，不需要维护本地订单簿
+func (ok *OkImp) onBooks5(instId string, dat []byte) {
+	var datas []OkBookData
+	if err := sonic.Unmarshal(dat, &datas); err != nil {
+		log.WithError(err).Error("unmarshal ok books5 failed")
+		return
+	}
+	for i := range datas {
+		ok.rspHandle(orderBookTransform(instId, &datas[i]))
+	}
+}
@@ -0,0 +1,100 @@
+package okxv5
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 使用okx文档中的深度示例，checksum为固定值
+func TestLocalBookChecksum(t *testing.T) {
+	cases := []struct {
+		bids [][]string
+		asks [][]string
+		want int32
+	}{
+		{
+			// 3366.1:7:3366.8:9:3366:6:3368:8
+			bids: [][]string{{"3366.1", "7", "0", "3"}, {"3366", "6", "3", "4"}},
+			asks: [][]string{{"3366.8", "9", "10", "3"}, {"3368", "8", "3", "4"}},
+			want: -1881014294,
+		},
+		{
+			// 买卖档位数量不同时，缺少的一侧跳过：3366.1:7:3366.8:9:3366:6
+			bids: [][]string{{"3366.1", "7", "0", "3"}, {"3366", "6", "3", "4"}},
+			asks: [][]string{{"3366.8", "9", "10", "3"}},
+			want: 1164732920,
+		},
+	}
+	for _, c := range cases {
+		book := newLocalBook("BTC-USDT", &OkBookData{Bids: c.bids, Asks: c.asks})
+		if sum := book.checksum(); sum != c.want {
+			t.Errorf("checksum %d, want %d", sum, c.want)
+		}
+	}
+}
+
+// rest恢复的订单簿在增量checksum校验通过后才推送
+func TestRestBookEmittedAfterChecksum(t *testing.T) {
+	var books []*types.OrderBook
+	ok := &OkImp{
+		rspHandle: func(data interface{}) { books = append(books, data.(*types.OrderBook)) },
+		books:     make(map[string]*localBook),
+	}
+	book := newLocalBook("BTC-USDT", &OkBookData{
+		Bids:  [][]string{{"3366.1", "7"}, {"3366", "6"}},
+		Asks:  [][]string{{"3366.8", "9"}, {"3368", "9"}},
+		SeqID: 10,
+	})
+	book.fromRest = true
+	ok.books["BTC-USDT"] = book
+	if len(books) != 0 {
+		t.Fatalf("rest book should not be pushed before checksum")
+	}
+
+	update := `[{"asks":[["3368","8","3","4"]],"bids":[],"ts":"1597026383085","checksum":-1881014294,"prevSeqId":11,"seqId":12}]`
+	ok.onBooks(nil, "books", "BTC-USDT", "update", []byte(update))
+	if len(books) != 1 || book.fromRest {
+		t.Fatalf("got %d books, fromRest %v", len(books), book.fromRest)
+	}
+	if books[0].Asks[1].Qty != 8 {
+		t.Fatalf("unexpected ask %+v", books[0].Asks[1])
+	}
+}
+
+func TestLocalBookUpdate(t *testing.T) {
+	book := newLocalBook("BTC-USDT", &OkBookData{
+		Bids:  [][]string{{"3366.1", "7"}, {"3366", "6"}},
+		Asks:  [][]string{{"3366.8", "9"}, {"3368", "8"}},
+		SeqID: 1,
+	})
+	book.update(&OkBookData{
+		Bids:      [][]string{{"3366.5", "1"}, {"3366", "0"}},
+		Asks:      [][]string{{"3367", "2"}, {"3368", "5"}},
+		PrevSeqID: 1,
+		SeqID:     2,
+	})
+
+	bids := []string{"3366.5", "3366.1"}
+	if len(book.bids) != len(bids) {
+		t.Fatalf("bids len %d, want %d", len(book.bids), len(bids))
+	}
+	for i, px := range bids {
+		if book.bids[i].px != px {
+			t.Errorf("bid %d px %s, want %s", i, book.bids[i].px, px)
+		}
+	}
+
+	asks := []string{"3366.8", "3367", "3368"}
+	if len(book.asks) != len(asks) {
+		t.Fatalf("asks len %d, want %d", len(book.asks), len(asks))
+	}
+	for i, px := range asks {
+		if book.asks[i].px != px {
+			t.Errorf("ask %d px %s, want %s", i, book.asks[i].px, px)
+		}
+	}
+	if book.asks[2].sz != "5" || book.seqId != 2 {
+		t.Errorf("ask 3368 sz %s seqId %d", book.asks[2].sz, book.seqId)
+	}
+}
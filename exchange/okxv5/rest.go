@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/base"
 	"github.com/cybernonce/gotrader/pkg/httpx"
 	"github.com/cybernonce/gotrader/pkg/utils"
 	"github.com/cybernonce/gotrader/trader/constant"
@@ -21,6 +21,10 @@ type RestClient struct {
 	secretKey    string
 	passPhrase   string
 	exchangeType constant.ExchangeType
+
+	restUrl    string
+	httpClient httpx.Client
+	head       map[string]string // 附加的请求头
 }
 
 type BaseOkRsp struct {
@@ -34,10 +38,35 @@ func NewRestClient(apiKey, secretKey, passPhrase string, exchangeType constant.E
 		secretKey:    secretKey,
 		passPhrase:   passPhrase,
 		exchangeType: exchangeType,
+		restUrl:      RestUrl,
+		httpClient:   httpClient,
 	}
 	return client
 }
 
+// SetEndpoints 使用指定环境的rest地址和请求头
+func (client *RestClient) SetEndpoints(endpoints base.Endpoints) {
+	client.restUrl = endpoints.RestUrl
+	client.head = endpoints.Head
+}
+
+// SetHttpClient 使用交易所实例独立的http客户端(代理、超时、出口IP)
+func (client *RestClient) SetHttpClient(httpClient httpx.Client) {
+	client.httpClient = httpClient
+}
+
+// instType 当前客户端对应的产品类型
+func (client *RestClient) instType() string {
+	switch client.exchangeType {
+	case constant.OkxV5Swap:
+		return "SWAP"
+	case constant.OkxV5Future:
+		return "FUTURES"
+	default:
+		return "SPOT"
+	}
+}
+
 func (client *RestClient) HttpRequest(method string, uri string, payload []byte) ([]byte, *http.Response, error) {
 	var param string
 	if payload != nil {
@@ -46,7 +75,7 @@ func (client *RestClient) HttpRequest(method string, uri string, payload []byte)
 	currentTime := IsoTime()
 	toSignStr := currentTime + method + uri + param
 	signature := utils.GenBase64Digest(utils.HmacSha256(toSignStr, client.secretKey))
-	url := RestUrl + uri
+	url := client.restUrl + uri
 	head := map[string]string{
 		"Content-Type":         "application/json",
 		"OK-ACCESS-KEY":        client.apiKey,
@@ -54,13 +83,16 @@ func (client *RestClient) HttpRequest(method string, uri string, payload []byte)
 		"OK-ACCESS-TIMESTAMP":  currentTime,
 		"OK-ACCESS-PASSPHRASE": client.passPhrase,
 	}
+	for key, val := range client.head {
+		head[key] = val
+	}
 	args := &httpx.Request{
 		Url:    url,
 		Head:   head,
 		Method: method,
 		Body:   payload,
 	}
-	body, res, err := httpClient.Request(args)
+	body, res, err := client.httpClient.Request(args)
 	if err != nil {
 		return nil, res, err
 	}
@@ -68,7 +100,24 @@ func (client *RestClient) HttpRequest(method string, uri string, payload []byte)
 }
 
 func (client *RestClient) HttpGet(url string) ([]byte, *http.Response, error) {
-	body, res, err := httpClient.Get(url)
+	if len(client.head) > 0 {
+		return client.httpGetWithHead(url)
+	}
+	body, res, err := client.httpClient.Get(url)
+	if err != nil {
+		return nil, res, err
+	}
+	return *body, res, nil
+}
+
+// httpGetWithHead 模拟盘的公共接口也需要带上x-simulated-trading请求头
+func (client *RestClient) httpGetWithHead(url string) ([]byte, *http.Response, error) {
+	args := &httpx.Request{
+		Url:    url,
+		Head:   client.head,
+		Method: http.MethodGet,
+	}
+	body, res, err := client.httpClient.Request(args)
 	if err != nil {
 		return nil, res, err
 	}
@@ -91,7 +140,7 @@ func (client *RestClient) FetchKline(symbol string, interval string, limit int64
 	queryDict["limit"] = limit
 
 	payload := utils.UrlEncodeParams(queryDict)
-	url := RestUrl + fmt.Sprintf(FetchKlineUri, payload)
+	url := client.restUrl + fmt.Sprintf(FetchKlineUri, payload)
 
 	body, _, err := client.HttpGet(url)
 	if err != nil {
@@ -123,6 +172,90 @@ func (client *RestClient) FetchKline(symbol string, interval string, limit int64
 	return result, nil
 }
 
+// 历史K线单页最多返回的数量
+const maxHistoryKlineLimit = 100
+
+// FetchHistoryKline 使用after参数从EndTime向前翻页，结果按时间正序返回
+// 未指定StartTime时向前翻页直到取满Limit根，Limit也为0时只取一页
+func (client *RestClient) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return pageHistoryKline(param, client.fetchHistoryKline)
+}
+
+func pageHistoryKline(param base.KlineParam, fetch func(map[string]interface{}) ([]types.Kline, error)) ([]types.Kline, error) {
+	result := make([]types.Kline, 0)
+	after := param.EndTime
+	if after > 0 {
+		after += 1 // after为开区间，包含EndTime这根K线
+	}
+	for {
+		queryDict := map[string]interface{}{}
+		queryDict["instId"] = Symbol2OkInstId(param.Symbol)
+		queryDict["bar"] = param.Interval
+		queryDict["limit"] = maxHistoryKlineLimit
+		if after > 0 {
+			queryDict["after"] = after
+		}
+		klines, err := fetch(queryDict)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, k := range klines {
+			if k.Ts < param.StartTime {
+				break
+			}
+			result = append(result, k)
+		}
+		if param.Limit > 0 && int64(len(result)) >= param.Limit {
+			result = result[:param.Limit]
+			break
+		}
+		if (param.StartTime == 0 && param.Limit <= 0) || len(klines) < maxHistoryKlineLimit {
+			break
+		}
+		oldest := klines[len(klines)-1].Ts
+		if oldest <= param.StartTime {
+			break
+		}
+		after = oldest
+	}
+
+	// okx按时间倒序返回
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+func (client *RestClient) fetchHistoryKline(queryDict map[string]interface{}) ([]types.Kline, error) {
+	payload := utils.UrlEncodeParams(queryDict)
+	url := client.restUrl + fmt.Sprintf(FetchHistoryKlineUri, payload)
+
+	body, _, err := client.HttpGet(url)
+	if err != nil {
+		log.Errorf("ok get /api/v5/market/history-candles err:%v", err)
+		return nil, err
+	}
+
+	response := new(KlineRsp)
+	if err = sonic.Unmarshal(body, response); err != nil {
+		log.Errorf("ok get /api/v5/market/history-candles parser err:%v", err)
+		return nil, err
+	}
+
+	if response.Code != "0" {
+		err := fmt.Errorf("ok get /api/v5/market/history-candles fail, code:%s, msg:%s", response.Code, response.Msg)
+		return nil, err
+	}
+
+	result, err := klineTransform(response)
+	if err != nil {
+		err := fmt.Errorf("ok get /api/v5/market/history-candles transform err:%s", err)
+		return nil, err
+	}
+	return result, nil
+}
+
 func klineTransform(response *KlineRsp) ([]types.Kline, error) {
 	result := make([]types.Kline, 0, len(response.Data))
 	for _, dat := range response.Data {
@@ -232,13 +365,9 @@ func (client *RestClient) CreateBatchOrders(orders []*types.Order) ([]*types.Ord
 func formRequest(order *types.Order) map[string]interface{} {
 	oSide := OkxOrderSide[order.Side.Name()]
 	oType := OkxOrderType[order.Type.Name()]
-	tdModel := "cross" // 现货
-	if strings.HasSuffix(order.Symbol, "_SWAP") {
-		tdModel = "cross"
-	}
 	result := map[string]interface{}{
 		"instId":  Symbol2OkInstId(order.Symbol),
-		"tdMode":  tdModel,
+		"tdMode":  order.MarginMode.Name(),
 		"side":    Side2Okx[oSide],
 		"ordType": Type2Okx[oType],
 		"px":      order.Price,
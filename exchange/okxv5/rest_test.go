@@ -0,0 +1,72 @@
+package okxv5
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// fakeHistoryKline 模拟history-candles，按时间倒序返回after之前的一页1分钟K线，最早的K线时间为first
+func fakeHistoryKline(now, first int64, calls *int) func(map[string]interface{}) ([]types.Kline, error) {
+	return func(query map[string]interface{}) ([]types.Kline, error) {
+		*calls++
+		after := now + 60000
+		if v, ok := query["after"]; ok {
+			after = v.(int64)
+		}
+		klines := make([]types.Kline, 0, maxHistoryKlineLimit)
+		// after为开区间
+		last := (after - 1) - (after-1)%60000
+		for ts := last; ts >= first && len(klines) < maxHistoryKlineLimit; ts -= 60000 {
+			klines = append(klines, types.Kline{Ts: ts})
+		}
+		return klines, nil
+	}
+}
+
+func TestPageHistoryKlineWithoutStartTime(t *testing.T) {
+	now := int64(1000 * 60000)
+	calls := 0
+	param := base.KlineParam{Symbol: "BTC_USDT_SWAP", Interval: "1m", Limit: 250}
+	klines, err := pageHistoryKline(param, fakeHistoryKline(now, 0, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 250 || calls != 3 {
+		t.Fatalf("got %d klines in %d calls, want 250 in 3", len(klines), calls)
+	}
+	if klines[0].Ts != now-249*60000 || klines[249].Ts != now {
+		t.Fatalf("unexpected range %d-%d", klines[0].Ts, klines[249].Ts)
+	}
+	for i := 1; i < len(klines); i++ {
+		if klines[i].Ts != klines[i-1].Ts+60000 {
+			t.Fatalf("klines not continuous at %d", i)
+		}
+	}
+}
+
+func TestPageHistoryKlineRange(t *testing.T) {
+	now := int64(1000 * 60000)
+	calls := 0
+	param := base.KlineParam{
+		Symbol:    "BTC_USDT_SWAP",
+		Interval:  "1m",
+		StartTime: now - 149*60000,
+		EndTime:   now - 10*60000,
+	}
+	klines, err := pageHistoryKline(param, fakeHistoryKline(now, 0, &calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 140 || klines[0].Ts != param.StartTime || klines[len(klines)-1].Ts != param.EndTime {
+		t.Fatalf("got %d klines %d-%d", len(klines), klines[0].Ts, klines[len(klines)-1].Ts)
+	}
+
+	// 没有更早的数据时停止翻页
+	calls = 0
+	klines, err = pageHistoryKline(base.KlineParam{Symbol: "BTC_USDT_SWAP", Interval: "1m"}, fakeHistoryKline(now, now-49*60000, &calls))
+	if err != nil || len(klines) != 50 || calls != 1 {
+		t.Fatalf("got %d klines in %d calls, err %v", len(klines), calls, err)
+	}
+}
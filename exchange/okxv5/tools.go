@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cybernonce/gotrader/exchange/base"
 	"github.com/cybernonce/gotrader/trader/constant"
 )
 
@@ -16,9 +17,27 @@ var (
 	RestUrl  = "https://www.okx.com"
 	PubWsUrl = "wss://ws.okx.com:8443/ws/v5/public"
 	PriWsUrl = "wss://ws.okx.com:8443/ws/v5/private"
-	// RestUrl  = "https://coloapi3.okx.com"
-	// PubWsUrl = "wss://colows-d.okx.com/ws/v5/public"
-	// PriWsUrl = "wss://colows-d.okx.com/ws/v5/private"
+
+	// Environments 各环境的地址，通过ExchangeParameters.Environment选择
+	// 模拟盘rest与实盘同一域名，通过x-simulated-trading请求头区分
+	Environments = map[constant.Environment]base.Endpoints{
+		constant.EnvProduction: {
+			RestUrl:  RestUrl,
+			PubWsUrl: PubWsUrl,
+			PriWsUrl: PriWsUrl,
+		},
+		constant.EnvDemo: {
+			RestUrl:  RestUrl,
+			PubWsUrl: "wss://wspap.okx.com:8443/ws/v5/public",
+			PriWsUrl: "wss://wspap.okx.com:8443/ws/v5/private",
+			Head:     map[string]string{"x-simulated-trading": "1"},
+		},
+		constant.EnvColo: {
+			RestUrl:  "https://coloapi3.okx.com",
+			PubWsUrl: "wss://colows-d.okx.com/ws/v5/public",
+			PriWsUrl: "wss://colows-d.okx.com/ws/v5/private",
+		},
+	}
 
 	OkxOrderSide = map[string]string{
 		constant.OrderBuy.Name():   "BUY",
@@ -74,6 +93,26 @@ var (
 		"filled":           constant.OrderFilled,
 	}
 
+	// 止盈止损单都使用conditional，由tp/sl参数区分
+	AlgoType2Okx = map[string]string{
+		constant.StopLoss.Name():     "conditional",
+		constant.TakeProfit.Name():   "conditional",
+		constant.OCO.Name():          "oco",
+		constant.Trigger.Name():      "trigger",
+		constant.TrailingStop.Name(): "move_order_stop",
+	}
+
+	// effective表示已触发并生成订单，不代表订单已成交
+	Okex2AlgoStatus = map[string]constant.OrderStatus{
+		"live":                constant.OrderOpen,
+		"pause":               constant.OrderOpen,
+		"partially_effective": constant.OrderPartialFilled,
+		"effective":           constant.OrderTriggered,
+		"canceled":            constant.OrderCanceled,
+		"order_failed":        constant.OrderFailed,
+		"partially_failed":    constant.OrderFailed,
+	}
+
 	Okex2MarginMode = map[string]string{
 		"isolated": "FIXED",
 		"cross":    "CROSSED",
@@ -94,6 +133,7 @@ var (
 
 const (
 	FetchKlineUri              = "/api/v5/market/candles?%s"
+	FetchHistoryKlineUri       = "/api/v5/market/history-candles?%s"
 	OrderBookRest              = "/api/v5/market/books?%s"
 	SymbolsRest                = "/api/v5/public/instruments"
 	FetchFundingRateUri        = "/api/v5/public/funding-rate"
@@ -108,6 +148,13 @@ const (
 	CreateBatchOrderUri        = "/api/v5/trade/batch-orders"
 	CancelSingleOrderUri       = "/api/v5/trade/cancel-order"
 	CancelBatchOrderUri        = "/api/v5/trade/cancel-batch-orders"
+	AmendBatchOrderUri         = "/api/v5/trade/amend-batch-orders"
+	CreateAlgoOrderUri         = "/api/v5/trade/order-algo"
+	CancelAlgoOrderUri         = "/api/v5/trade/cancel-algos"
+	FetchOpenAlgoOrderUri      = "/api/v5/trade/orders-algo-pending"
+	SetLeverageUri             = "/api/v5/account/set-leverage"
+	SetPositionModeUri         = "/api/v5/account/set-position-mode"
+	FetchAccountConfigUri      = "/api/v5/account/config"
 	FetchOpenOrderUri          = "/api/v5/trade/orders-pending"
 	FetchOrderWithIdUri        = "/api/v5/trade/order"
 	FetchOrderDefault          = "/api/v5/trade/orders-history-archive"
@@ -131,11 +178,15 @@ func IsoTime() string {
 	return iso
 }
 
+// OkInstId2Symbol BTC-USDT => BTC_USDT, BTC-USDT-SWAP => BTC_USDT_SWAP, BTC-USD-240329 => BTC_USD_240329
 func OkInstId2Symbol(instId string) string {
 	tmp := strings.Split(instId, "-")
 	if len(tmp) == 2 {
 		return fmt.Sprintf("%s_%s", tmp[0], tmp[1])
 	} else if len(tmp) == 3 {
+		if isExpiry(tmp[2]) {
+			return fmt.Sprintf("%s_%s_%s", tmp[0], tmp[1], tmp[2])
+		}
 		return fmt.Sprintf("%s_%s_SWAP", tmp[0], tmp[1])
 	}
 	panic("bad instId:" + instId)
@@ -146,11 +197,50 @@ func Symbol2OkInstId(symbol string) string {
 	if len(tmp) == 2 {
 		return fmt.Sprintf("%s-%s", tmp[0], tmp[1])
 	} else if len(tmp) == 3 {
+		if isExpiry(tmp[2]) {
+			return fmt.Sprintf("%s-%s-%s", tmp[0], tmp[1], tmp[2])
+		}
 		return fmt.Sprintf("%s-%s-SWAP", tmp[0], tmp[1])
 	}
 	panic("bad symbol:" + symbol)
 }
 
+// isExpiry 交割合约的到期日，格式 YYMMDD
+func isExpiry(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// InstType 根据instId判断产品类型 SPOT/SWAP/FUTURES
+func InstType(instId string) string {
+	tmp := strings.Split(instId, "-")
+	if len(tmp) == 3 {
+		if isExpiry(tmp[2]) {
+			return "FUTURES"
+		}
+		return "SWAP"
+	}
+	return "SPOT"
+}
+
+func instId2ExchangeType(instId string) constant.ExchangeType {
+	switch InstType(instId) {
+	case "SWAP":
+		return constant.OkxV5Swap
+	case "FUTURES":
+		return constant.OkxV5Future
+	default:
+		return constant.OkxV5Spot
+	}
+}
+
+func IsFutureSymbol(symbol string) bool {
+	return InstType(Symbol2OkInstId(symbol)) == "FUTURES"
+}
+
 func BaseQuote(symbol string) (string, string) {
 	tmp := strings.Split(symbol, "_")
 	return tmp[0], tmp[1]
@@ -0,0 +1,51 @@
+package okxv5
+
+import "testing"
+
+func TestSymbolInstIdConvert(t *testing.T) {
+	cases := []struct {
+		symbol   string
+		instId   string
+		instType string
+	}{
+		{"BTC_USDT", "BTC-USDT", "SPOT"},
+		{"BTC_USDT_SWAP", "BTC-USDT-SWAP", "SWAP"},
+		{"BTC_USD_240329", "BTC-USD-240329", "FUTURES"},
+	}
+	for _, c := range cases {
+		if instId := Symbol2OkInstId(c.symbol); instId != c.instId {
+			t.Errorf("Symbol2OkInstId(%s) = %s, want %s", c.symbol, instId, c.instId)
+		}
+		if symbol := OkInstId2Symbol(c.instId); symbol != c.symbol {
+			t.Errorf("OkInstId2Symbol(%s) = %s, want %s", c.instId, symbol, c.symbol)
+		}
+		if instType := InstType(c.instId); instType != c.instType {
+			t.Errorf("InstType(%s) = %s, want %s", c.instId, instType, c.instType)
+		}
+	}
+}
+
+func TestIndexInstId(t *testing.T) {
+	for _, symbol := range []string{"BTC_USDT", "BTC_USDT_SWAP"} {
+		if instId := indexInstId(symbol); instId != "BTC-USDT" {
+			t.Errorf("indexInstId(%s) = %s", symbol, instId)
+		}
+	}
+	args := (&OkImp{}).Subscribe("BTC_USD_240329", IndexTickersChannel)["args"].([]map[string]string)
+	if args[0]["instId"] != "BTC-USD" {
+		t.Errorf("index-tickers instId = %s", args[0]["instId"])
+	}
+}
+
+func TestLiquidationInstType(t *testing.T) {
+	cases := map[string]string{
+		"BTC_USDT":       "MARGIN",
+		"BTC_USDT_SWAP":  "SWAP",
+		"BTC_USD_240329": "FUTURES",
+	}
+	for symbol, instType := range cases {
+		if got := liquidationInstType(symbol); got != instType {
+			t.Errorf("liquidationInstType(%s) = %s, want %s", symbol, got, instType)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type OkCurrency struct {
+	Ccy    string `json:"ccy"`
+	Chain  string `json:"chain"`
+	CanDep bool   `json:"canDep"`
+	CanWd  bool   `json:"canWd"`
+	MinFee string `json:"minFee"`
+	MinWd  string `json:"minWd"`
+	MaxWd  string `json:"maxWd"`
+}
+
+type OkDepositAddress struct {
+	Ccy      string `json:"ccy"`
+	Chain    string `json:"chain"`
+	Addr     string `json:"addr"`
+	Tag      string `json:"tag"`
+	Memo     string `json:"memo"`
+	Selected bool   `json:"selected"` // 是否为默认充值地址
+}
+
+type OkWithdrawResult struct {
+	WdId     string `json:"wdId"`
+	Ccy      string `json:"ccy"`
+	ClientId string `json:"clientId"`
+	Amt      string `json:"amt"`
+	Chain    string `json:"chain"`
+}
+
+type OkWalletRecord struct {
+	DepId    string `json:"depId"`
+	WdId     string `json:"wdId"`
+	ClientId string `json:"clientId"`
+	Ccy      string `json:"ccy"`
+	Chain    string `json:"chain"`
+	Amt      string `json:"amt"`
+	Fee      string `json:"fee"`
+	To       string `json:"to"`
+	Tag      string `json:"tag"`
+	Memo     string `json:"memo"`
+	TxId     string `json:"txId"`
+	State    string `json:"state"`
+	Ts       string `json:"ts"`
+}
+
+type CurrenciesRsp struct {
+	BaseOkRsp
+	Data []*OkCurrency `json:"data"`
+}
+
+func (t *CurrenciesRsp) valid() bool {
+	return t.Code == "0"
+}
+
+type DepositAddressRsp struct {
+	BaseOkRsp
+	Data []*OkDepositAddress `json:"data"`
+}
+
+func (t *DepositAddressRsp) valid() bool {
+	return t.Code == "0"
+}
+
+type WithdrawRsp struct {
+	BaseOkRsp
+	Data []*OkWithdrawResult `json:"data"`
+}
+
+type WalletRecordRsp struct {
+	BaseOkRsp
+	Data []*OkWalletRecord `json:"data"`
+}
+
+func (t *WalletRecordRsp) valid() bool {
+	return t.Code == "0"
+}
+
+var (
+	// 0:等待确认 1:确认到账 2:充值成功 8:暂停充值 11:黑名单 12:账户或充值被冻结 13:子账户充值拦截 14:KYC限额
+	Okx2DepositStatus = map[string]constant.WalletStatus{
+		"0":  constant.WalletPending,
+		"1":  constant.WalletSuccess,
+		"2":  constant.WalletSuccess,
+		"8":  constant.WalletPending,
+		"11": constant.WalletFailed,
+		"12": constant.WalletPending,
+		"13": constant.WalletFailed,
+		"14": constant.WalletPending,
+	}
+	// -3:撤销中 -2:已撤销 -1:失败 0:等待提币 1:提币中 2:提币成功 其他为等待审核
+	Okx2WithdrawStatus = map[string]constant.WalletStatus{
+		"-3": constant.WalletPending,
+		"-2": constant.WalletCanceled,
+		"-1": constant.WalletFailed,
+		"2":  constant.WalletSuccess,
+	}
+)
+
+func (client *RestClient) FetchCurrencies() ([]*types.Currency, error) {
+	response := new(CurrenciesRsp)
+	if err := client.getJson(PrivateCurrenciesUri, response); err != nil {
+		return nil, err
+	}
+
+	// 接口按币种+链返回，按币种合并
+	result := make([]*types.Currency, 0)
+	index := make(map[string]*types.Currency)
+	for _, item := range response.Data {
+		currency, ok := index[item.Ccy]
+		if !ok {
+			currency = &types.Currency{Coin: item.Ccy}
+			index[item.Ccy] = currency
+			result = append(result, currency)
+		}
+		fee, _ := utils.ParseFloat(item.MinFee)
+		min, _ := utils.ParseFloat(item.MinWd)
+		max, _ := utils.ParseFloat(item.MaxWd)
+		currency.Networks = append(currency.Networks, &types.CurrencyNetwork{
+			Chain:       item.Chain,
+			CanDeposit:  item.CanDep,
+			CanWithdraw: item.CanWd,
+			WithdrawFee: fee,
+			WithdrawMin: min,
+			WithdrawMax: max,
+		})
+	}
+	return result, nil
+}
+
+// FetchDepositAddress chain为空时返回默认充值地址，如 USDT-TRC20
+func (client *RestClient) FetchDepositAddress(coin string, chain string) (*types.DepositAddress, error) {
+	uri := fmt.Sprintf("%s?ccy=%s", PrivateDepositAddrUri, strings.ToUpper(coin))
+	response := new(DepositAddressRsp)
+	if err := client.getJson(uri, response); err != nil {
+		return nil, err
+	}
+	for _, item := range response.Data {
+		if (chain == "" && item.Selected) || (chain != "" && item.Chain == chain) {
+			tag := item.Tag
+			if tag == "" {
+				tag = item.Memo
+			}
+			return &types.DepositAddress{
+				Coin:    item.Ccy,
+				Chain:   item.Chain,
+				Address: item.Addr,
+				Tag:     tag,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("ok get /api/v5/asset/deposit-address no address for %s %s", coin, chain)
+}
+
+// Withdraw 链上提币，需要带tag的币种地址格式为 address:tag
+func (client *RestClient) Withdraw(param base.WithdrawParam) (string, error) {
+	toAddr := param.Address
+	if param.Tag != "" {
+		toAddr = fmt.Sprintf("%s:%s", param.Address, param.Tag)
+	}
+	query := map[string]interface{}{
+		"ccy":    strings.ToUpper(param.Coin),
+		"amt":    strconv.FormatFloat(param.Amount, 'f', -1, 64),
+		"dest":   "4",
+		"toAddr": toAddr,
+		"chain":  param.Chain,
+	}
+	if param.ClientId != "" {
+		query["clientId"] = param.ClientId
+	}
+	payload, _ := sonic.Marshal(query)
+	uri := PrivateWithDrawUri
+	body, _, err := client.HttpRequest(http.MethodPost, uri, payload)
+	if err != nil {
+		log.Errorf("okx post /api/v5/asset/withdrawal err: %v", err)
+		return "", err
+	}
+
+	response := new(WithdrawRsp)
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("okx post /api/v5/asset/withdrawal parser err: %v", err)
+		return "", err
+	}
+	if response.Code != "0" || len(response.Data) == 0 {
+		return "", fmt.Errorf("okx post /api/v5/asset/withdrawal fail, code:%s, msg:%s", response.Code, response.Msg)
+	}
+	return response.Data[0].WdId, nil
+}
+
+func (client *RestClient) FetchDepositHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	uri := fmt.Sprintf("%s?%s", FetchDepositHistoryUri, utils.UrlEncodeParams(walletRecordQuery(param)))
+	response := new(WalletRecordRsp)
+	if err := client.getJson(uri, response); err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.WalletRecord, 0, len(response.Data))
+	for _, item := range response.Data {
+		record := walletRecordTransform(item)
+		record.Id = item.DepId
+		record.Type = constant.Deposit
+		record.Status = Okx2DepositStatus[item.State]
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+func (client *RestClient) FetchWithdrawHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error) {
+	uri := fmt.Sprintf("%s?%s", FetchWithDrawHistoryUri, utils.UrlEncodeParams(walletRecordQuery(param)))
+	response := new(WalletRecordRsp)
+	if err := client.getJson(uri, response); err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.WalletRecord, 0, len(response.Data))
+	for _, item := range response.Data {
+		record := walletRecordTransform(item)
+		record.Id = item.WdId
+		record.Type = constant.Withdraw
+		if status, ok := Okx2WithdrawStatus[item.State]; ok {
+			record.Status = status
+		} else {
+			record.Status = constant.WalletPending
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// walletRecordQuery after/before为毫秒时间戳，分别查询该时间之前/之后的记录，单次最多100条
+func walletRecordQuery(param base.WalletRecordParam) map[string]interface{} {
+	query := map[string]interface{}{}
+	if param.Coin != "" {
+		query["ccy"] = strings.ToUpper(param.Coin)
+	}
+	if param.StartTime > 0 {
+		query["before"] = param.StartTime
+	}
+	if param.EndTime > 0 {
+		query["after"] = param.EndTime
+	}
+	if param.Limit > 0 {
+		query["limit"] = param.Limit
+	}
+	return query
+}
+
+func walletRecordTransform(item *OkWalletRecord) *types.WalletRecord {
+	amount, _ := utils.ParseFloat(item.Amt)
+	fee, _ := utils.ParseFloat(item.Fee)
+	ts, _ := strconv.ParseInt(item.Ts, 10, 64)
+	tag := item.Tag
+	if tag == "" {
+		tag = item.Memo
+	}
+	return &types.WalletRecord{
+		Coin:      item.Ccy,
+		Chain:     item.Chain,
+		Address:   item.To,
+		Tag:       tag,
+		TxId:      item.TxId,
+		Amount:    amount,
+		Fee:       fee,
+		RawStatus: item.State,
+		ClientId:  item.ClientId,
+		Ts:        ts,
+	}
+}
+
+func (client *RestClient) getJson(uri string, response interface{ valid() bool }) error {
+	body, _, err := client.HttpRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		log.Errorf("ok get %s err:%v", uri, err)
+		return err
+	}
+	if err = json.Unmarshal(body, response); err != nil {
+		log.Errorf("ok get %s parser err:%v", uri, err)
+		return err
+	}
+	if !response.valid() {
+		return fmt.Errorf("ok get %s fail: %s", uri, body)
+	}
+	return nil
+}
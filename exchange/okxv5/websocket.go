@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/cybernonce/gotrader/pkg/utils"
@@ -16,10 +15,13 @@ import (
 )
 
 type OkWsData struct {
-	Event string `json:"event"`
-	Code  string `json:"code"`
-	Msg   string `json:"msg"`
-	Arg   struct {
+	Id     string `json:"id"` // 下单类请求的id，用于匹配响应
+	Op     string `json:"op"`
+	Event  string `json:"event"`
+	Code   string `json:"code"`
+	Msg    string `json:"msg"`
+	Action string `json:"action"` // 增量深度频道 snapshot/update
+	Arg    struct {
 		Channel string `json:"channel"`
 		InstId  string `json:"instId"`
 	} `json:"arg"`
@@ -33,15 +35,33 @@ type OkImp struct {
 	isPrivate  bool
 	pingTimer  *time.Timer
 	rspHandle  func(interface{})
+
+	// 深度频道
+	restClient *RestClient
+	books      map[string]*localBook
 }
 
-func NewOkPubWsClient(rspHandle func(interface{})) *ws.WsClient {
+func NewOkPubWsClient(url string, rspHandle func(interface{})) *ws.WsClient {
 	imp := &OkImp{rspHandle: rspHandle}
-	client := ws.NewWsClient(PubWsUrl, imp, constant.OkxV5Spot, 20*time.Second, 30*time.Second)
+	client := ws.NewWsClient(url, imp, constant.OkxV5Spot, 20*time.Second, 30*time.Second)
 	return client
 }
 
-func NewOkPriWsClient(accessKey, secretKey, passphrase string, rspHandle func(interface{})) *ws.WsClient {
+// NewOkBookWsClient 深度频道专用的公共连接，tbt深度频道需要登录，传入apiKey时连接后自动登录
+func NewOkBookWsClient(url, accessKey, secretKey, passphrase string, restClient *RestClient, rspHandle func(interface{})) *ws.WsClient {
+	imp := &OkImp{
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		passphrase: passphrase,
+		rspHandle:  rspHandle,
+		restClient: restClient,
+		books:      make(map[string]*localBook),
+	}
+	client := ws.NewWsClient(url, imp, constant.OkxV5Spot, 20*time.Second, 30*time.Second)
+	return client
+}
+
+func NewOkPriWsClient(url, accessKey, secretKey, passphrase string, rspHandle func(interface{})) *ws.WsClient {
 	imp := &OkImp{
 		accessKey:  accessKey,
 		secretKey:  secretKey,
@@ -49,7 +69,7 @@ func NewOkPriWsClient(accessKey, secretKey, passphrase string, rspHandle func(in
 		rspHandle:  rspHandle,
 		isPrivate:  true,
 	}
-	client := ws.NewWsClient(PriWsUrl, imp, constant.OkxV5Spot, 20*time.Second, 30*time.Second)
+	client := ws.NewWsClient(url, imp, constant.OkxV5Spot, 20*time.Second, 30*time.Second)
 	return client
 }
 
@@ -61,6 +81,13 @@ func (ok *OkImp) Ping(cli *ws.WsClient) {
 func (ok *OkImp) OnConnected(cli *ws.WsClient, typ ws.ConnectType) {
 	if !ok.isPrivate {
 		log.Info("ok public ws connected")
+		if ok.books != nil {
+			// 重连后会重新推送快照
+			ok.books = make(map[string]*localBook)
+			if ok.accessKey != "" {
+				ok.Login(cli)
+			}
+		}
 		return
 	}
 	log.Info("ok private ws connected")
@@ -68,14 +95,31 @@ func (ok *OkImp) OnConnected(cli *ws.WsClient, typ ws.ConnectType) {
 }
 
 func (ok *OkImp) Subscribe(symbol string, topic string) map[string]interface{} {
+	switch topic {
+	case AccountChannel, PositionsChannel, BalanceAndPositionChannel:
+		return map[string]interface{}{
+			"op":   "subscribe",
+			"args": []map[string]string{accountSubscribeArgs(symbol, topic)},
+		}
+	case LiquidationOrdersChannel:
+		// symbol传入instType
+		return map[string]interface{}{
+			"op":   "subscribe",
+			"args": []map[string]string{{"channel": topic, "instType": symbol}},
+		}
+	}
+
 	args := []map[string]string{
 		{
 			"channel": topic,
 			"instId":  Symbol2OkInstId(symbol),
 		},
 	}
-	if topic == "orders" {
-		args[0]["instType"] = "SWAP"
+	if topic == "orders" || topic == "orders-algo" {
+		args[0]["instType"] = InstType(args[0]["instId"])
+	}
+	if topic == IndexTickersChannel {
+		args[0]["instId"] = indexInstId(symbol)
 	}
 
 	params := map[string]interface{}{
@@ -104,6 +148,12 @@ func (ok *OkImp) Handle(cli *ws.WsClient, bs []byte) {
 		return
 	}
 
+	// 下单类请求的响应，部分失败时code不为0，需要先处理
+	if dat.Id != "" && dat.Op != "" {
+		ok.onOpResponse(&dat)
+		return
+	}
+
 	if (dat.Code != "" && dat.Code != "0") || dat.Event == "error" {
 		err := fmt.Errorf("code:%s, msg:%s", dat.Code, dat.Msg)
 		log.WithError(err).Error("ok ws data error")
@@ -125,8 +175,30 @@ func (ok *OkImp) Handle(cli *ws.WsClient, bs []byte) {
 		ok.onBboTbtRecv(dat.Arg.InstId, dat.Data)
 	case "orders":
 		ok.onOrders(dat.Arg.InstId, dat.Data)
+	case "orders-algo":
+		ok.onAlgoOrders(dat.Data)
 	case "trades":
 		ok.onTrades(dat.Arg.InstId, dat.Data)
+	case AccountChannel:
+		ok.onAccount(dat.Data)
+	case PositionsChannel:
+		ok.onPositions(dat.Data)
+	case BalanceAndPositionChannel:
+		ok.onBalanceAndPosition(dat.Data)
+	case "books", "books-l2-tbt", "books50-l2-tbt":
+		ok.onBooks(cli, dat.Arg.Channel, dat.Arg.InstId, dat.Action, dat.Data)
+	case "books5":
+		ok.onBooks5(dat.Arg.InstId, dat.Data)
+	case FundingRateChannel:
+		ok.onFundingRate(dat.Data)
+	case MarkPriceChannel:
+		ok.onMarkPrice(dat.Data)
+	case IndexTickersChannel:
+		ok.onIndexTickers(dat.Data)
+	case OpenInterestChannel:
+		ok.onOpenInterest(dat.Data)
+	case LiquidationOrdersChannel:
+		ok.onLiquidationOrders(dat.Data)
 	default:
 		log.WithField("dat", string(dat.Data)).Warn("unknown ok message")
 	}
@@ -185,13 +257,9 @@ func (ok *OkImp) onBboTbtRecv(instId string, dat json.RawMessage) {
 		bidPrice, _ = strconv.ParseFloat(bid1[0], 64)
 		bidQty, _   = strconv.ParseFloat(bid1[1], 64)
 		ts, _       = strconv.ParseInt(ticker.Ts, 10, 64)
-		exchange    = constant.OkxV5Spot
+		exchange    = instId2ExchangeType(instId)
 	)
 
-	if strings.Contains(instId, "-SWAP") {
-		exchange = constant.OkxV5Swap
-	}
-
 	evt := &types.BookTicker{
 		Symbol:     OkInstId2Symbol(instId),
 		Exchange:   exchange,
@@ -283,10 +351,7 @@ func (ok *OkImp) onTrades(instId string, dat json.RawMessage) {
 		tradeSize, _ := strconv.ParseFloat(trade.Size, 64)
 		tradeCount, _ := strconv.ParseInt(trade.Count, 10, 64)
 		tradeTs, _ := strconv.ParseInt(trade.Ts, 10, 64)
-		tradeExchange := constant.OkxV5Spot
-		if strings.Contains(instId, "-SWAP") {
-			tradeExchange = constant.OkxV5Swap
-		}
+		tradeExchange := instId2ExchangeType(instId)
 		evt := &types.Trade{
 			Symbol:     OkInstId2Symbol(instId),
 			MarketType: tradeExchange,
@@ -0,0 +1,107 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+
+	"github.com/bytedance/sonic"
+)
+
+// 账户级私有频道，不区分交易对
+const (
+	AccountChannel            = "account"
+	PositionsChannel          = "positions"
+	BalanceAndPositionChannel = "balance_and_position"
+)
+
+// OkBalanceAndPosition balance_and_position 频道只推送发生变化的币种余额和仓位
+type OkBalanceAndPosition struct {
+	PTime     string `json:"pTime"`
+	EventType string `json:"eventType"` // snapshot/delivered/exercised/transferred/filled/liquidation...
+	BalData   []struct {
+		Ccy     string `json:"ccy"`
+		CashBal string `json:"cashBal"`
+		UTime   string `json:"uTime"`
+	} `json:"balData"`
+	PosData []PositionData `json:"posData"`
+}
+
+// accountSubscribeArgs positions频道传入symbol时只订阅该合约的仓位
+func accountSubscribeArgs(symbol string, topic string) map[string]string {
+	arg := map[string]string{
+		"channel": topic,
+	}
+	if topic == PositionsChannel {
+		arg["instType"] = "ANY"
+		if symbol != "" {
+			arg["instId"] = Symbol2OkInstId(symbol)
+			arg["instType"] = InstType(arg["instId"])
+		}
+	}
+	return arg
+}
+
+func (ok *OkImp) onAccount(dat json.RawMessage) {
+	var balances []*OkBalance
+	if err := sonic.Unmarshal(dat, &balances); err != nil {
+		log.WithError(err).Error("unmarshal ok account failed")
+		return
+	}
+	for _, bal := range balances {
+		ok.rspHandle(okBalanceTransform(bal))
+	}
+}
+
+func (ok *OkImp) onPositions(dat json.RawMessage) {
+	var positions []PositionData
+	if err := sonic.Unmarshal(dat, &positions); err != nil {
+		log.WithError(err).Error("unmarshal ok positions failed")
+		return
+	}
+	// 首次订阅时没有持仓会推送空数组
+	if len(positions) == 0 {
+		return
+	}
+	ok.rspHandle(positionsTransform(positions, true))
+}
+
+func (ok *OkImp) onBalanceAndPosition(dat json.RawMessage) {
+	var events []OkBalanceAndPosition
+	if err := sonic.Unmarshal(dat, &events); err != nil {
+		log.WithError(err).Error("unmarshal ok balance_and_position failed")
+		return
+	}
+	for _, evt := range events {
+		if len(evt.BalData) > 0 {
+			assets := make(map[string]types.Asset, len(evt.BalData))
+			for _, bal := range evt.BalData {
+				// 该频道只有币种余额，没有冻结和权益信息
+				cashBal, _ := utils.ParseFloat(bal.CashBal)
+				assets[bal.Ccy] = types.Asset{
+					Coin:  bal.Ccy,
+					Free:  cashBal,
+					Total: cashBal,
+				}
+			}
+			ok.rspHandle(&types.Assets{Assets: assets})
+		}
+		if len(evt.PosData) > 0 {
+			result := make([]*types.Position, 0, len(evt.PosData))
+			for _, item := range evt.PosData {
+				pos, _ := utils.ParseFloat(item.Pos)
+				avgPx, _ := utils.ParseFloat(item.AvgPx)
+				result = append(result, &types.Position{
+					MarginMode: Okex2MarginMode[item.MgnMode],
+					Symbol:     OkInstId2Symbol(item.InstId),
+					Side:       getPositionSide(item.PosSide, pos),
+					Position:   math.Abs(pos),
+					AvgCost:    avgPx,
+				})
+			}
+			ok.rspHandle(result)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package okxv5
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 买卖模式平仓后推送pos为0的持仓，不应被当作空仓
+func TestOnPositionsNetClose(t *testing.T) {
+	var positions []*types.Position
+	ok := &OkImp{rspHandle: func(data interface{}) { positions = data.([]*types.Position) }}
+
+	msg := `[{"instId":"BTC-USDT-SWAP","instType":"SWAP","mgnMode":"cross","posSide":"net","pos":"0","avgPx":"","upl":"0","liqPx":"","last":"42000"},
+		{"instId":"ETH-USDT-SWAP","instType":"SWAP","mgnMode":"cross","posSide":"net","pos":"-3","avgPx":"2200","upl":"1.2","liqPx":"3000","last":"2190"}]`
+	ok.onPositions([]byte(msg))
+	if len(positions) != 2 {
+		t.Fatalf("got %d positions, want 2", len(positions))
+	}
+	if positions[0].Side != "" || positions[0].Position != 0 {
+		t.Fatalf("closed net position %+v", positions[0])
+	}
+	if positions[1].Side != constant.Short.Name() || positions[1].Position != 3 {
+		t.Fatalf("short net position %+v", positions[1])
+	}
+}
+
+func TestOnBalanceAndPositionNetClose(t *testing.T) {
+	var positions []*types.Position
+	ok := &OkImp{rspHandle: func(data interface{}) {
+		if v, isPos := data.([]*types.Position); isPos {
+			positions = v
+		}
+	}}
+
+	msg := `[{"pTime":"1597026383085","eventType":"filled","balData":[{"ccy":"USDT","cashBal":"1000","uTime":"1597026383085"}],
+		"posData":[{"instId":"BTC-USDT-SWAP","mgnMode":"cross","posSide":"net","pos":"0","avgPx":""}]}]`
+	ok.onBalanceAndPosition([]byte(msg))
+	if len(positions) != 1 || positions[0].Side != "" || positions[0].Symbol != "BTC_USDT_SWAP" {
+		t.Fatalf("unexpected positions %+v", positions)
+	}
+	if side := getPositionSide("net", 0); side != "" {
+		t.Fatalf("getPositionSide(net, 0) = %s", side)
+	}
+}
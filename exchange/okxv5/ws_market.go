@@ -0,0 +1,222 @@
+package okxv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+
+	"github.com/bytedance/sonic"
+)
+
+// 合约公共行情频道
+const (
+	FundingRateChannel  = "funding-rate"
+	MarkPriceChannel    = "mark-price"
+	IndexTickersChannel = "index-tickers"
+	OpenInterestChannel = "open-interest"
+
+	// 强平频道按产品类型订阅，推送该类型下全部交易对的强平单
+	LiquidationOrdersChannel = "liquidation-orders"
+)
+
+// indexInstId 指数频道的instId为 BTC-USDT，合约symbol取其标的指数
+func indexInstId(symbol string) string {
+	base, quote := BaseQuote(symbol)
+	return fmt.Sprintf("%s-%s", base, quote)
+}
+
+// liquidationInstTypes 订阅全部交易对时按交易所类型选择instType
+var liquidationInstTypes = map[constant.ExchangeType]string{
+	constant.OkxV5Spot:   "MARGIN",
+	constant.OkxV5Swap:   "SWAP",
+	constant.OkxV5Future: "FUTURES",
+}
+
+// liquidationInstType 现货杠杆的强平单在MARGIN下
+func liquidationInstType(symbol string) string {
+	instType := InstType(Symbol2OkInstId(symbol))
+	if instType == "SPOT" {
+		return "MARGIN"
+	}
+	return instType
+}
+
+func (ok *OkImp) onFundingRate(dat json.RawMessage) {
+	type fundingRate struct {
+		FundingRate
+		MinFundingRate string `json:"minFundingRate"`
+		MaxFundingRate string `json:"maxFundingRate"`
+		Premium        string `json:"premium"`
+		Ts             string `json:"ts"`
+	}
+
+	var rates []fundingRate
+	if err := sonic.Unmarshal(dat, &rates); err != nil {
+		log.WithError(err).Error("unmarshal ok funding-rate failed")
+		return
+	}
+
+	for _, fr := range rates {
+		rate, _ := strconv.ParseFloat(fr.FundingRate.FundingRate, 64)
+		nextRate, _ := parseStringToFloat(fr.NextFundingRate)
+		fundingTime, _ := strconv.ParseInt(fr.FundingTime, 10, 64)
+		nextFundingTime, _ := parseStringToInt(fr.NextFundingTime)
+		minRate, _ := parseStringToFloat(fr.MinFundingRate)
+		maxRate, _ := parseStringToFloat(fr.MaxFundingRate)
+		premium, _ := parseStringToFloat(fr.Premium)
+		ts, _ := parseStringToInt(fr.Ts)
+		ok.rspHandle(&types.FundingRate{
+			Symbol:          OkInstId2Symbol(fr.InstID),
+			Exchange:        instId2ExchangeType(fr.InstID),
+			Method:          fr.Method,
+			FundingRate:     rate,
+			FundingTime:     fundingTime,
+			NextFundingRate: nextRate,
+			NextFundingTime: nextFundingTime,
+			MinFundingRate:  minRate,
+			MaxFundingRate:  maxRate,
+			Premium:         premium,
+			Ts:              ts,
+		})
+	}
+}
+
+func (ok *OkImp) onMarkPrice(dat json.RawMessage) {
+	curTs := utils.Millisec(time.Now())
+
+	type markPrice struct {
+		InstId string `json:"instId"`
+		MarkPx string `json:"markPx"`
+		Ts     string `json:"ts"`
+	}
+
+	var prices []markPrice
+	if err := sonic.Unmarshal(dat, &prices); err != nil {
+		log.WithError(err).Error("unmarshal ok mark-price failed")
+		return
+	}
+
+	for _, p := range prices {
+		price, _ := strconv.ParseFloat(p.MarkPx, 64)
+		ts, _ := strconv.ParseInt(p.Ts, 10, 64)
+		ok.rspHandle(&types.MarkPrice{
+			Symbol:     OkInstId2Symbol(p.InstId),
+			Exchange:   instId2ExchangeType(p.InstId),
+			MarkPrice:  price,
+			ExchangeTs: ts * 1000,
+			Ts:         curTs,
+		})
+	}
+}
+
+func (ok *OkImp) onIndexTickers(dat json.RawMessage) {
+	curTs := utils.Millisec(time.Now())
+
+	type indexTicker struct {
+		InstId  string `json:"instId"`
+		IdxPx   string `json:"idxPx"`
+		Open24h string `json:"open24h"`
+		High24h string `json:"high24h"`
+		Low24h  string `json:"low24h"`
+		Ts      string `json:"ts"`
+	}
+
+	var tickers []indexTicker
+	if err := sonic.Unmarshal(dat, &tickers); err != nil {
+		log.WithError(err).Error("unmarshal ok index-tickers failed")
+		return
+	}
+
+	for _, t := range tickers {
+		price, _ := strconv.ParseFloat(t.IdxPx, 64)
+		open, _ := parseStringToFloat(t.Open24h)
+		high, _ := parseStringToFloat(t.High24h)
+		low, _ := parseStringToFloat(t.Low24h)
+		ts, _ := strconv.ParseInt(t.Ts, 10, 64)
+		// Exchange 由订阅的交易所填充
+		ok.rspHandle(&types.IndexPrice{
+			Symbol:     OkInstId2Symbol(t.InstId),
+			IndexPrice: price,
+			Open24h:    open,
+			High24h:    high,
+			Low24h:     low,
+			ExchangeTs: ts * 1000,
+			Ts:         curTs,
+		})
+	}
+}
+
+func (ok *OkImp) onOpenInterest(dat json.RawMessage) {
+	curTs := utils.Millisec(time.Now())
+
+	type openInterest struct {
+		InstId string `json:"instId"`
+		Oi     string `json:"oi"`
+		OiCcy  string `json:"oiCcy"`
+		Ts     string `json:"ts"`
+	}
+
+	var ois []openInterest
+	if err := sonic.Unmarshal(dat, &ois); err != nil {
+		log.WithError(err).Error("unmarshal ok open-interest failed")
+		return
+	}
+
+	for _, oi := range ois {
+		contracts, _ := strconv.ParseFloat(oi.Oi, 64)
+		ccy, _ := parseStringToFloat(oi.OiCcy)
+		ts, _ := strconv.ParseInt(oi.Ts, 10, 64)
+		ok.rspHandle(&types.OpenInterest{
+			Symbol:          OkInstId2Symbol(oi.InstId),
+			Exchange:        instId2ExchangeType(oi.InstId),
+			OpenInterest:    contracts,
+			OpenInterestCcy: ccy,
+			ExchangeTs:      ts * 1000,
+			Ts:              curTs,
+		})
+	}
+}
+
+func (ok *OkImp) onLiquidationOrders(dat json.RawMessage) {
+	curTs := utils.Millisec(time.Now())
+
+	type liquidation struct {
+		InstId  string `json:"instId"`
+		Details []struct {
+			Side string `json:"side"`
+			BkPx string `json:"bkPx"`
+			Sz   string `json:"sz"`
+			Ts   string `json:"ts"`
+		} `json:"details"`
+	}
+
+	var liquidations []liquidation
+	if err := sonic.Unmarshal(dat, &liquidations); err != nil {
+		log.WithError(err).Error("unmarshal ok liquidation-orders failed")
+		return
+	}
+
+	for _, l := range liquidations {
+		symbol := OkInstId2Symbol(l.InstId)
+		exchangeType := instId2ExchangeType(l.InstId)
+		for _, d := range l.Details {
+			price, _ := strconv.ParseFloat(d.BkPx, 64)
+			size, _ := strconv.ParseFloat(d.Sz, 64)
+			ts, _ := strconv.ParseInt(d.Ts, 10, 64)
+			ok.rspHandle(&types.Liquidation{
+				Symbol:     symbol,
+				Exchange:   exchangeType,
+				Side:       convertOrderSide(Okx2Side[d.Side]),
+				Price:      price,
+				Size:       size,
+				ExchangeTs: ts * 1000,
+				Ts:         curTs,
+			})
+		}
+	}
+}
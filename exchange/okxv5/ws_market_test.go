@@ -0,0 +1,94 @@
+package okxv5
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestOnFundingRate(t *testing.T) {
+	var rate *types.FundingRate
+	ok := &OkImp{rspHandle: func(data interface{}) { rate = data.(*types.FundingRate) }}
+	msg := `[{"fundingRate":"0.0001515","fundingTime":"1700006400000","instId":"BTC-USDT-SWAP","instType":"SWAP",
+		"method":"next_period","maxFundingRate":"0.00375","minFundingRate":"-0.00375","nextFundingRate":"0.0002",
+		"nextFundingTime":"1700035200000","premium":"0.0001233","settState":"settled","settFundingRate":"0.0001","ts":"1700000000123"}]`
+	ok.onFundingRate([]byte(msg))
+	if rate == nil || rate.Symbol != "BTC_USDT_SWAP" || rate.Exchange != constant.OkxV5Swap {
+		t.Fatalf("unexpected funding rate %+v", rate)
+	}
+	if rate.FundingRate != 0.0001515 || rate.NextFundingRate != 0.0002 || rate.FundingTime != 1700006400000 ||
+		rate.NextFundingTime != 1700035200000 || rate.MinFundingRate != -0.00375 || rate.MaxFundingRate != 0.00375 ||
+		rate.Premium != 0.0001233 || rate.Ts != 1700000000123 || rate.Method != "next_period" {
+		t.Fatalf("unexpected funding rate %+v", rate)
+	}
+}
+
+func TestOnMarkPrice(t *testing.T) {
+	tests := []struct {
+		msg      string
+		symbol   string
+		exchange constant.ExchangeType
+		price    float64
+	}{
+		{
+			msg:    `[{"instType":"FUTURES","instId":"BTC-USD-240628","markPx":"42310.6","ts":"1700000000123"}]`,
+			symbol: "BTC_USD_240628", exchange: constant.OkxV5Future, price: 42310.6,
+		},
+		{
+			msg:    `[{"instType":"SWAP","instId":"ETH-USDT-SWAP","markPx":"2200.15","ts":"1700000000123"}]`,
+			symbol: "ETH_USDT_SWAP", exchange: constant.OkxV5Swap, price: 2200.15,
+		},
+	}
+	for _, tt := range tests {
+		var price *types.MarkPrice
+		ok := &OkImp{rspHandle: func(data interface{}) { price = data.(*types.MarkPrice) }}
+		ok.onMarkPrice([]byte(tt.msg))
+		if price == nil || price.Symbol != tt.symbol || price.Exchange != tt.exchange ||
+			price.MarkPrice != tt.price || price.ExchangeTs != 1700000000123000 || price.Ts == 0 {
+			t.Fatalf("unexpected mark price %+v", price)
+		}
+	}
+}
+
+func TestOnIndexTickers(t *testing.T) {
+	var price *types.IndexPrice
+	ok := &OkImp{rspHandle: func(data interface{}) { price = data.(*types.IndexPrice) }}
+	msg := `[{"instId":"BTC-USDT","idxPx":"42300.1","high24h":"43000","low24h":"41000.5","open24h":"41500","sodUtc0":"41800","sodUtc8":"41900","ts":"1700000000123"}]`
+	ok.onIndexTickers([]byte(msg))
+	if price == nil || price.Symbol != "BTC_USDT" || price.IndexPrice != 42300.1 || price.Open24h != 41500 ||
+		price.High24h != 43000 || price.Low24h != 41000.5 || price.ExchangeTs != 1700000000123000 {
+		t.Fatalf("unexpected index price %+v", price)
+	}
+}
+
+func TestOnOpenInterest(t *testing.T) {
+	var oi *types.OpenInterest
+	ok := &OkImp{rspHandle: func(data interface{}) { oi = data.(*types.OpenInterest) }}
+	ok.onOpenInterest([]byte(`[{"instType":"SWAP","instId":"ETH-USDT-SWAP","oi":"2125419.2","oiCcy":"212541.92","oiUsd":"470000000","ts":"1700000000123"}]`))
+	if oi == nil || oi.Symbol != "ETH_USDT_SWAP" || oi.Exchange != constant.OkxV5Swap || oi.OpenInterest != 2125419.2 ||
+		oi.OpenInterestCcy != 212541.92 || oi.ExchangeTs != 1700000000123000 {
+		t.Fatalf("unexpected open interest %+v", oi)
+	}
+}
+
+func TestOnLiquidationOrders(t *testing.T) {
+	var liquidations []*types.Liquidation
+	ok := &OkImp{rspHandle: func(data interface{}) { liquidations = append(liquidations, data.(*types.Liquidation)) }}
+	msg := `[{"details":[{"bkLoss":"0","bkPx":"0.007831","ccy":"","posSide":"short","side":"buy","sz":"13","ts":"1692266434010"},
+		{"bkLoss":"0","bkPx":"0.0078","ccy":"","posSide":"long","side":"sell","sz":"2","ts":"1692266434020"}],
+		"instFamily":"IOST-USDT","instId":"IOST-USDT-SWAP","instType":"SWAP","uly":"IOST-USDT"}]`
+	ok.onLiquidationOrders([]byte(msg))
+	if len(liquidations) != 2 {
+		t.Fatalf("got %d liquidations, want 2", len(liquidations))
+	}
+	l := liquidations[0]
+	if l.Symbol != "IOST_USDT_SWAP" || l.Exchange != constant.OkxV5Swap || l.Side != constant.OrderBuy ||
+		l.Price != 0.007831 || l.Size != 13 || l.ExchangeTs != 1692266434010000 {
+		t.Fatalf("unexpected liquidation %+v", l)
+	}
+	l = liquidations[1]
+	if l.Side != constant.OrderSell || l.Price != 0.0078 || l.Size != 2 || l.ExchangeTs != 1692266434020000 {
+		t.Fatalf("unexpected liquidation %+v", l)
+	}
+}
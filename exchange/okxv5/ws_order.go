@@ -0,0 +1,168 @@
+package okxv5
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 私有ws下单类请求
+const (
+	WsOpOrder             = "order"
+	WsOpBatchOrders       = "batch-orders"
+	WsOpCancelOrder       = "cancel-order"
+	WsOpBatchCancelOrders = "batch-cancel-orders"
+	WsOpAmendOrder        = "amend-order"
+	WsOpBatchAmendOrders  = "batch-amend-orders"
+)
+
+// OkWsOpResult 下单类请求的响应
+type OkWsOpResult struct {
+	Id      string
+	Op      string
+	Code    string
+	Msg     string
+	Results []*types.OrderResult
+}
+
+func (ok *OkImp) onOpResponse(dat *OkWsData) {
+	var items []*CreateOrderResult
+	if len(dat.Data) > 0 {
+		if err := sonic.Unmarshal(dat.Data, &items); err != nil {
+			log.WithError(err).Errorf("unmarshal ok %s response failed", dat.Op)
+		}
+	}
+
+	result := &OkWsOpResult{
+		Id:      dat.Id,
+		Op:      dat.Op,
+		Code:    dat.Code,
+		Msg:     dat.Msg,
+		Results: make([]*types.OrderResult, 0, len(items)),
+	}
+	for _, item := range items {
+		info := orderTransform("", item)
+		code, _ := strconv.ParseInt(item.SCode, 10, 32)
+		info.ErrCode = int32(code)
+		result.Results = append(result.Results, info)
+	}
+	ok.rspHandle(result)
+}
+
+// WsOrderGateway 通过已登录的私有ws下单、撤单、改单，按请求id匹配响应
+type WsOrderGateway struct {
+	cli     *ws.WsClient
+	seq     int64
+	mutex   sync.Mutex
+	pending map[string]func(*OkWsOpResult, error)
+}
+
+func NewWsOrderGateway(cli *ws.WsClient) *WsOrderGateway {
+	return &WsOrderGateway{
+		cli:     cli,
+		pending: make(map[string]func(*OkWsOpResult, error)),
+	}
+}
+
+// Send 发送请求，收到响应或超时后调用callback，callback只会被调用一次
+func (g *WsOrderGateway) Send(op string, args []map[string]interface{}, timeout time.Duration, callback func(*OkWsOpResult, error)) error {
+	id := strconv.FormatInt(atomic.AddInt64(&g.seq, 1), 10)
+
+	g.mutex.Lock()
+	g.pending[id] = callback
+	g.mutex.Unlock()
+
+	req := map[string]interface{}{
+		"id":   id,
+		"op":   op,
+		"args": args,
+	}
+	if err := g.cli.Write(req); err != nil {
+		g.take(id)
+		return fmt.Errorf("ok ws %s write err: %s", op, err)
+	}
+
+	time.AfterFunc(timeout, func() {
+		if cb := g.take(id); cb != nil {
+			cb(nil, fmt.Errorf("ok ws %s id:%s timeout after %s", op, id, timeout))
+		}
+	})
+	return nil
+}
+
+// Call 阻塞等待响应，超时返回错误
+func (g *WsOrderGateway) Call(op string, args []map[string]interface{}, timeout time.Duration) (*OkWsOpResult, error) {
+	type response struct {
+		result *OkWsOpResult
+		err    error
+	}
+	ch := make(chan response, 1)
+	err := g.Send(op, args, timeout, func(result *OkWsOpResult, err error) {
+		ch <- response{result, err}
+	})
+	if err != nil {
+		return nil, err
+	}
+	rsp := <-ch
+	return rsp.result, rsp.err
+}
+
+func (g *WsOrderGateway) OnResponse(result *OkWsOpResult) {
+	cb := g.take(result.Id)
+	if cb == nil {
+		log.Warnf("ok ws %s response id:%s not found, maybe timeout", result.Op, result.Id)
+		return
+	}
+	// 请求整体失败时没有逐单结果
+	if result.Code != "0" && len(result.Results) == 0 {
+		cb(result, fmt.Errorf("ok ws %s fail, code:%s, msg:%s", result.Op, result.Code, result.Msg))
+		return
+	}
+	cb(result, nil)
+}
+
+func (g *WsOrderGateway) take(id string) func(*OkWsOpResult, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	cb, ok := g.pending[id]
+	if ok {
+		delete(g.pending, id)
+	}
+	return cb
+}
+
+func formAmendRequest(order *types.Order) map[string]interface{} {
+	result := map[string]interface{}{
+		"instId": Symbol2OkInstId(order.Symbol),
+	}
+	if order.OrderID != "" {
+		result["ordId"] = order.OrderID
+	} else {
+		result["clOrdId"] = order.ClientID
+	}
+	if order.Price != "" {
+		result["newPx"] = order.Price
+	}
+	if order.OrigQty != "" {
+		result["newSz"] = order.OrigQty
+	}
+	return result
+}
+
+// wsOrderArgs 单个订单使用单笔接口，多个订单使用批量接口
+func wsOrderArgs(orders []*types.Order, single, batch string, form func(*types.Order) map[string]interface{}) (string, []map[string]interface{}) {
+	args := make([]map[string]interface{}, 0, len(orders))
+	for _, order := range orders {
+		args = append(args, form(order))
+	}
+	if len(orders) == 1 {
+		return single, args
+	}
+	return batch, args
+}
@@ -0,0 +1,55 @@
+package pionex
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type CancelOrderRsp struct {
+	BasePionexRsp
+}
+
+// CancelBatchOrders pionex撤单只支持orderId，没有orderId时先按clientId查询订单
+func (client *RestClient) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	startTime := time.Now()
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, client.cancelOrder(order))
+	}
+	log.Infof("HTTP cost time: %v", time.Since(startTime))
+	return result, nil
+}
+
+func (client *RestClient) cancelOrder(order *types.Order) *types.OrderResult {
+	info := &types.OrderResult{
+		OrderId:  order.OrderID,
+		ClientId: order.ClientID,
+	}
+	if info.OrderId == "" {
+		exist, err := client.FetchOrder(order)
+		if err != nil {
+			info.ErrMsg = err.Error()
+			return info
+		}
+		info.OrderId = exist.OrderID
+	}
+	// body中orderId为数字
+	orderId, err := strconv.ParseInt(info.OrderId, 10, 64)
+	if err != nil {
+		info.ErrMsg = err.Error()
+		return info
+	}
+	param := map[string]interface{}{
+		"symbol":  Symbol2Pionex(order.Symbol),
+		"orderId": orderId,
+	}
+	if err := client.request(http.MethodDelete, CancelOrderUri, nil, param, new(CancelOrderRsp)); err != nil {
+		info.ErrMsg = err.Error()
+		return info
+	}
+	info.IsSuccess = true
+	return info
+}
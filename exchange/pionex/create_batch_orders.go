@@ -0,0 +1,148 @@
+package pionex
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type OrderResult struct {
+	OrderId       int64  `json:"orderId"`
+	ClientOrderId string `json:"clientOrderId"`
+}
+
+type CreateOrderRsp struct {
+	BasePionexRsp
+	Data OrderResult `json:"data"`
+}
+
+// MassOrderRsp 整批成功或失败，orderIds与请求的订单按顺序对应
+type MassOrderRsp struct {
+	BasePionexRsp
+	Data struct {
+		OrderIds []*OrderResult `json:"orderIds"`
+	} `json:"data"`
+}
+
+// CreateBatchOrders 同一交易对的普通限价单使用massOrder，其他订单逐个下单
+func (client *RestClient) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	startTime := time.Now()
+	defer func() {
+		log.Infof("HTTP cost time: %v", time.Since(startTime))
+	}()
+	if canMassOrder(orders) {
+		return client.createMassOrders(orders)
+	}
+	result := make([]*types.OrderResult, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, client.createOrder(order))
+	}
+	return result, nil
+}
+
+func canMassOrder(orders []*types.Order) bool {
+	if len(orders) < 2 {
+		return false
+	}
+	for _, order := range orders {
+		if order.Type != constant.Limit || order.Symbol != orders[0].Symbol {
+			return false
+		}
+	}
+	return true
+}
+
+// createOrder 单个订单失败不影响其他订单，错误记录在结果中
+func (client *RestClient) createOrder(order *types.Order) *types.OrderResult {
+	info := &types.OrderResult{ClientId: order.ClientID}
+	param, err := client.formRequest(order)
+	if err != nil {
+		info.ErrMsg = err.Error()
+		return info
+	}
+	response := new(CreateOrderRsp)
+	if err := client.request(http.MethodPost, CreateOrderUri, nil, param, response); err != nil {
+		info.ErrMsg = err.Error()
+		return info
+	}
+	info.IsSuccess = true
+	info.OrderId = strconv.FormatInt(response.Data.OrderId, 10)
+	return info
+}
+
+func (client *RestClient) createMassOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	result := make([]*types.OrderResult, 0, len(orders))
+	for start := 0; start < len(orders); start += maxMassOrders {
+		end := start + maxMassOrders
+		if end > len(orders) {
+			end = len(orders)
+		}
+		items := make([]map[string]interface{}, 0, end-start)
+		for _, order := range orders[start:end] {
+			// canMassOrder保证都是普通限价单
+			item, _ := client.formRequest(order)
+			delete(item, "symbol")
+			items = append(items, item)
+		}
+		param := map[string]interface{}{
+			"symbol": Symbol2Pionex(orders[start].Symbol),
+			"orders": items,
+		}
+		response := new(MassOrderRsp)
+		if err := client.request(http.MethodPost, CreateMassOrderUri, nil, param, response); err != nil {
+			if start == 0 {
+				return nil, err
+			}
+			// 之前的批次已经生效，返回已有结果，失败批次的订单逐个返回错误
+			for _, order := range orders[start:end] {
+				result = append(result, &types.OrderResult{ClientId: order.ClientID, ErrMsg: err.Error()})
+			}
+			return result, nil
+		}
+		for i, order := range orders[start:end] {
+			info := &types.OrderResult{ClientId: order.ClientID}
+			if i < len(response.Data.OrderIds) {
+				info.IsSuccess = true
+				info.OrderId = strconv.FormatInt(response.Data.OrderIds[i].OrderId, 10)
+			}
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+// formRequest 市价买单使用Amount(quote数量)，市价卖单使用OrigQty，只支持限价、市价和IOC
+func (client *RestClient) formRequest(order *types.Order) (map[string]interface{}, error) {
+	result := map[string]interface{}{
+		"symbol": Symbol2Pionex(order.Symbol),
+		"side":   Side2Pionex[order.Side],
+	}
+	switch order.Type {
+	case constant.Market:
+		result["type"] = "MARKET"
+		if order.Side == constant.OrderBuy {
+			result["amount"] = order.Amount
+		} else {
+			result["size"] = order.OrigQty
+		}
+	case constant.IOC:
+		result["type"] = "LIMIT"
+		result["IOC"] = true
+	case constant.Limit, constant.GTC:
+		result["type"] = "LIMIT"
+	default:
+		return nil, fmt.Errorf("pionex order type %s not supported", order.Type.Name())
+	}
+	if order.Type != constant.Market {
+		result["price"] = order.Price
+		result["size"] = order.OrigQty
+	}
+	if order.ClientID != "" {
+		result["clientOrderId"] = order.ClientID
+	}
+	return result, nil
+}
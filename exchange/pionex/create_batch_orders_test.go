@@ -0,0 +1,25 @@
+package pionex
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestFormRequestOrderType(t *testing.T) {
+	client := &RestClient{}
+	order := &types.Order{Symbol: "BTC_USDT", Side: constant.OrderBuy, Price: "100", OrigQty: "1", Type: constant.Limit}
+	if param, err := client.formRequest(order); err != nil || param["type"] != "LIMIT" {
+		t.Fatalf("limit order %v %v", param, err)
+	}
+	for _, typ := range []constant.OrderType{constant.PostOnly, constant.FOK} {
+		order.Type = typ
+		if _, err := client.formRequest(order); err == nil {
+			t.Fatalf("%s should not be supported", typ.Name())
+		}
+		if info := client.createOrder(order); info.IsSuccess || info.ErrMsg == "" {
+			t.Fatalf("%s should return error result %+v", typ.Name(), info)
+		}
+	}
+}
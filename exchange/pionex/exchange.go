@@ -0,0 +1,280 @@
+package pionex
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type PionexSpotExchange struct {
+	exchangeType constant.ExchangeType
+
+	restClient  *RestClient
+	pubWsClient *ws.WsClient
+	priWsClient *ws.WsClient
+
+	// BookTicker和OrderBook共用DEPTH频道，按订阅的交易对分发
+	depthMutex        sync.RWMutex
+	bookTickerSymbols map[string]bool
+	orderBookSymbols  map[string]bool
+
+	// callbacks
+	onBooktickerCallback func(*types.BookTicker)
+	onTradeCallback      func([]*types.Trade)
+	onOrderBookCallback  func(*types.OrderBook)
+	onOrderCallback      func([]*types.Order)
+	onBalanceCallback    func(*types.Assets)
+}
+
+func NewPionexSpot(params *types.ExchangeParameters) *PionexSpotExchange {
+	apiKey := params.AccessKey
+	secretKey := params.SecretKey
+	endpoints := base.MustResolveEndpoints(params, Environments)
+	transport := base.MustNewTransport(params)
+
+	// new client
+	client := NewRestClient(apiKey, secretKey)
+	client.SetEndpoints(endpoints)
+	client.SetHttpClient(transport.HttpClient)
+	exchange := &PionexSpotExchange{
+		exchangeType:      constant.PionexSpot,
+		restClient:        client,
+		bookTickerSymbols: make(map[string]bool),
+		orderBookSymbols:  make(map[string]bool),
+	}
+
+	// pubWsClient
+	pubWsClient := NewPionexPubWsClient(endpoints.PubWsUrl, exchange.OnPubWsHandle)
+	pubWsClient.SetDialer(transport.WsDialer)
+	if err := pubWsClient.Dial(ws.Connect); err != nil {
+		log.Errorf("pubWsClient.Dial err %s", err)
+	} else {
+		exchange.pubWsClient = pubWsClient
+		log.Infof("pubWsClient.Dial success")
+	}
+
+	// priWsClient
+	if len(apiKey) > 0 {
+		priWsClient := NewPionexPriWsClient(endpoints.PriWsUrl, apiKey, secretKey, exchange.OnPriWsHandle)
+		priWsClient.SetDialer(transport.WsDialer)
+		if err := priWsClient.Dial(ws.Connect); err != nil {
+			log.Errorf("priWsClient.Dial err %s", err)
+		} else {
+			exchange.priWsClient = priWsClient
+			log.Infof("priWsClient.Dial success")
+		}
+	}
+	return exchange
+}
+
+func (pionex *PionexSpotExchange) GetName() (name string) {
+	return pionex.exchangeType.Name()
+}
+
+func (pionex *PionexSpotExchange) GetType() (typ constant.ExchangeType) {
+	return pionex.exchangeType
+}
+
+func (pionex *PionexSpotExchange) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
+	return pionex.restClient.FetchKline(symbol, interval, limit)
+}
+
+func (pionex *PionexSpotExchange) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	return pionex.restClient.FetchHistoryKline(param)
+}
+
+func (pionex *PionexSpotExchange) FetchFundingRate(symbol string) (*types.FundingRate, error) {
+	return nil, fmt.Errorf("not impl")
+}
+
+func (pionex *PionexSpotExchange) FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error) {
+	return nil, fmt.Errorf("not impl")
+}
+
+func (pionex *PionexSpotExchange) FetchSymbols() ([]*types.SymbolInfo, error) {
+	return pionex.restClient.FetchSymbols()
+}
+
+func (pionex *PionexSpotExchange) FetchTickers() ([]*types.Ticker, error) {
+	return pionex.restClient.FetchTickers()
+}
+
+func (pionex *PionexSpotExchange) FetchBalance() (*types.Assets, error) {
+	return pionex.restClient.FetchBalance()
+}
+
+func (pionex *PionexSpotExchange) FetchAssetBalance() (*types.Assets, error) {
+	return pionex.restClient.FetchAssetBalance()
+}
+
+func (pionex *PionexSpotExchange) FetchPositons() ([]*types.Position, error) {
+	return nil, fmt.Errorf("not impl")
+}
+
+func (pionex *PionexSpotExchange) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	return pionex.restClient.FetchOpenOrders(symbol)
+}
+
+func (pionex *PionexSpotExchange) FetchOrder(order *types.Order) (*types.Order, error) {
+	return pionex.restClient.FetchOrder(order)
+}
+
+func (pionex *PionexSpotExchange) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	return pionex.restClient.FetchUserTrades(param)
+}
+
+func (pionex *PionexSpotExchange) CreateBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return pionex.restClient.CreateBatchOrders(orders)
+}
+
+func (pionex *PionexSpotExchange) CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error) {
+	return pionex.restClient.CancelBatchOrders(orders)
+}
+
+// AmendBatchOrders pionex不支持改单，撤单后重新下单
+func (pionex *PionexSpotExchange) AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) {
+	return base.CancelReplace(orders, pionex.restClient.CancelBatchOrders, pionex.restClient.FetchOrder, pionex.restClient.CreateBatchOrders)
+}
+
+func (pionex *PionexSpotExchange) PrivateTransfer(transfer base.TransferParam) (string, error) {
+	return "", fmt.Errorf("not impl")
+}
+
+func (pionex *PionexSpotExchange) SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error {
+	return fmt.Errorf("not impl")
+}
+
+func (pionex *PionexSpotExchange) SetPositionMode(mode constant.PositionMode) error {
+	return fmt.Errorf("not impl")
+}
+
+func (pionex *PionexSpotExchange) Subscribe(params map[string]interface{}) error {
+	if pionex.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	if err := pionex.pubWsClient.Write(params); err != nil {
+		return fmt.Errorf("Subscribe err: %s", err)
+	}
+	return nil
+}
+
+// SubscribeBookTicker 没有单独的一档行情频道，取DEPTH的第一档
+func (pionex *PionexSpotExchange) SubscribeBookTicker(symbols []string, callback func(*types.BookTicker)) error {
+	return pionex.subscribeDepth(symbols, pionex.bookTickerSymbols, func() { pionex.onBooktickerCallback = callback })
+}
+
+func (pionex *PionexSpotExchange) SubscribeTrades(symbols []string, callback func([]*types.Trade)) error {
+	if pionex.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	pionex.onTradeCallback = callback
+	for _, symbol := range symbols {
+		pionex.pubWsClient.Subscribe(symbol, TradeTopic)
+	}
+	return nil
+}
+
+func (pionex *PionexSpotExchange) SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) error {
+	return pionex.subscribeDepth(symbols, pionex.orderBookSymbols, func() { pionex.onOrderBookCallback = callback })
+}
+
+func (pionex *PionexSpotExchange) subscribeDepth(symbols []string, subscribed map[string]bool, setCallback func()) error {
+	if pionex.pubWsClient == nil {
+		return fmt.Errorf("pubWsClient is nil")
+	}
+	setCallback()
+	pionex.depthMutex.Lock()
+	for _, symbol := range symbols {
+		subscribed[symbol] = true
+	}
+	pionex.depthMutex.Unlock()
+	for _, symbol := range symbols {
+		pionex.pubWsClient.Subscribe(symbol, DepthTopic)
+	}
+	return nil
+}
+
+// SubscribeOrders ORDER频道需要按交易对订阅
+func (pionex *PionexSpotExchange) SubscribeOrders(symbols []string, callback func(orders []*types.Order)) error {
+	if pionex.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	pionex.onOrderCallback = callback
+	for _, symbol := range symbols {
+		pionex.priWsClient.Subscribe(symbol, OrderTopic)
+	}
+	return nil
+}
+
+func (pionex *PionexSpotExchange) SubscribeBalance(callback func(*types.Assets)) error {
+	if pionex.priWsClient == nil {
+		return fmt.Errorf("priWsClient is nil")
+	}
+	pionex.onBalanceCallback = callback
+	pionex.priWsClient.Subscribe("", BalanceTopic)
+	return nil
+}
+
+func (pionex *PionexSpotExchange) SubscribePositions(callback func([]*types.Position)) error {
+	return fmt.Errorf("not impl")
+}
+
+func (pionex *PionexSpotExchange) OnPubWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case *types.OrderBook:
+		pionex.onDepth(v)
+	case []*types.Trade:
+		if pionex.onTradeCallback != nil {
+			pionex.onTradeCallback(v)
+		} else {
+			log.Errorf("onTrade Callback not set")
+		}
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
+
+func (pionex *PionexSpotExchange) onDepth(book *types.OrderBook) {
+	pionex.depthMutex.RLock()
+	isBookTicker := pionex.bookTickerSymbols[book.Symbol]
+	isOrderBook := pionex.orderBookSymbols[book.Symbol]
+	pionex.depthMutex.RUnlock()
+
+	if isBookTicker {
+		if pionex.onBooktickerCallback != nil {
+			pionex.onBooktickerCallback(toBookTicker(book))
+		} else {
+			log.Errorf("OnBookTicker Callback not set")
+		}
+	}
+	if isOrderBook {
+		if pionex.onOrderBookCallback != nil {
+			pionex.onOrderBookCallback(book)
+		} else {
+			log.Errorf("onOrderBook Callback not set")
+		}
+	}
+}
+
+func (pionex *PionexSpotExchange) OnPriWsHandle(data interface{}) {
+	switch v := data.(type) {
+	case []*types.Order:
+		if pionex.onOrderCallback != nil {
+			pionex.onOrderCallback(v)
+		} else {
+			log.Errorf("onOrder Callback not set")
+		}
+	case *types.Assets:
+		if pionex.onBalanceCallback != nil {
+			pionex.onBalanceCallback(v)
+		} else {
+			log.Errorf("onBalance Callback not set")
+		}
+	default:
+		log.Errorf("Unknown type %s", v)
+	}
+}
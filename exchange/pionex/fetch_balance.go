@@ -0,0 +1,56 @@
+package pionex
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// PionexBalance rest和ws BALANCE频道共用
+type PionexBalance struct {
+	Coin   string `json:"coin"`
+	Free   string `json:"free"`
+	Frozen string `json:"frozen"`
+}
+
+type BalanceRsp struct {
+	BasePionexRsp
+	Data struct {
+		Balances []*PionexBalance `json:"balances"`
+	} `json:"data"`
+}
+
+func (client *RestClient) FetchBalance() (*types.Assets, error) {
+	response := new(BalanceRsp)
+	if err := client.request(http.MethodGet, FetchBalanceUri, nil, nil, response); err != nil {
+		return nil, err
+	}
+	return balanceTransform(response.Data.Balances), nil
+}
+
+// FetchAssetBalance pionex只有交易账户
+func (client *RestClient) FetchAssetBalance() (*types.Assets, error) {
+	return nil, fmt.Errorf("not impl")
+}
+
+func balanceTransform(balances []*PionexBalance) *types.Assets {
+	assets := make(map[string]types.Asset, len(balances))
+	for _, item := range balances {
+		free, _ := utils.ParseFloat(item.Free)
+		frozen, _ := utils.ParseFloat(item.Frozen)
+		if free+frozen == 0 {
+			continue
+		}
+		coin := strings.ToUpper(item.Coin)
+		assets[coin] = types.Asset{
+			Coin:   coin,
+			Free:   free,
+			Frozen: frozen,
+			Total:  free + frozen,
+		}
+	}
+	return &types.Assets{Assets: assets}
+}
@@ -0,0 +1,110 @@
+package pionex
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// 单页最多返回的K线数量
+const maxKlineLimit = 500
+
+type Kline struct {
+	Time   int64  `json:"time"`
+	Open   string `json:"open"`
+	Close  string `json:"close"`
+	High   string `json:"high"`
+	Low    string `json:"low"`
+	Volume string `json:"volume"`
+}
+
+type KlineRsp struct {
+	BasePionexRsp
+	Data struct {
+		Klines []*Kline `json:"klines"`
+	} `json:"data"`
+}
+
+// FetchKline interval支持pionex原始周期(1M/60M/1D)和通用周期(1m/1h/1d)，按时间正序返回
+func (client *RestClient) FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error) {
+	if limit <= 0 || limit > maxKlineLimit {
+		limit = maxKlineLimit
+	}
+	queryDict := map[string]interface{}{
+		"symbol":   Symbol2Pionex(symbol),
+		"interval": pionexInterval(interval),
+		"limit":    limit,
+	}
+	response := new(KlineRsp)
+	if err := client.publicGet(FetchKlineUri, queryDict, response); err != nil {
+		return nil, err
+	}
+	if len(response.Data.Klines) == 0 {
+		return nil, fmt.Errorf("pionex get /api/v1/market/klines empty")
+	}
+	return klineTransform(response.Data.Klines, pionexInterval(interval)), nil
+}
+
+// FetchHistoryKline 指定StartTime时向后翻页拉取区间内的K线，否则从EndTime向前翻页直到取满Limit根，结果按时间正序返回
+// pionex只支持endTime，返回endTime之前最近的limit根，向后翻页时按周期截断endTime
+func (client *RestClient) FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) {
+	interval := pionexInterval(param.Interval)
+	return base.PageKlines(param, maxKlineLimit, func(startTime, endTime, limit int64) ([]types.Kline, error) {
+		queryDict := map[string]interface{}{
+			"symbol":   Symbol2Pionex(param.Symbol),
+			"interval": interval,
+			"endTime":  base.ForwardPageEnd(startTime, endTime, limit, intervalMillis(interval)),
+			"limit":    limit,
+		}
+		response := new(KlineRsp)
+		if err := client.publicGet(FetchKlineUri, queryDict, response); err != nil {
+			return nil, err
+		}
+		klines := klineTransform(response.Data.Klines, interval)
+		for i, k := range klines {
+			if k.Ts >= startTime {
+				return klines[i:], nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+func pionexInterval(interval string) string {
+	if v, ok := Interval2Pionex[interval]; ok {
+		return v
+	}
+	return interval
+}
+
+// klineTransform 未收盘的K线Confirm为0
+func klineTransform(list []*Kline, interval string) []types.Kline {
+	now := time.Now().UnixMilli()
+	period := intervalMillis(interval)
+	result := make([]types.Kline, 0, len(list))
+	for _, dat := range list {
+		open, _ := utils.ParseFloat(dat.Open)
+		high, _ := utils.ParseFloat(dat.High)
+		low, _ := utils.ParseFloat(dat.Low)
+		close, _ := utils.ParseFloat(dat.Close)
+		vol, _ := utils.ParseFloat(dat.Volume)
+		k := types.Kline{
+			Ts:    dat.Time,
+			Open:  open,
+			High:  high,
+			Low:   low,
+			Close: close,
+			Vol:   vol,
+		}
+		if period > 0 && dat.Time+period <= now {
+			k.Confirm = 1
+		}
+		result = append(result, k)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Ts < result[j].Ts })
+	return result
+}
@@ -0,0 +1,137 @@
+package pionex
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// PionexOrder rest查询和ws ORDER频道共用
+type PionexOrder struct {
+	OrderId       int64  `json:"orderId"`
+	Symbol        string `json:"symbol"`
+	Type          string `json:"type"`
+	Side          string `json:"side"`
+	Price         string `json:"price"`
+	Size          string `json:"size"`
+	Amount        string `json:"amount"`
+	FilledSize    string `json:"filledSize"`
+	FilledAmount  string `json:"filledAmount"`
+	Fee           string `json:"fee"`
+	FeeCoin       string `json:"feeCoin"`
+	Status        string `json:"status"` // OPEN/CLOSED
+	IOC           bool   `json:"IOC"`
+	ClientOrderId string `json:"clientOrderId"`
+	CreateTime    int64  `json:"createTime"`
+	UpdateTime    int64  `json:"updateTime"`
+}
+
+type OrderRsp struct {
+	BasePionexRsp
+	Data PionexOrder `json:"data"`
+}
+
+type OpenOrdersRsp struct {
+	BasePionexRsp
+	Data struct {
+		Orders []*PionexOrder `json:"orders"`
+	} `json:"data"`
+}
+
+// FetchOpenOrders 接口必须指定交易对
+func (client *RestClient) FetchOpenOrders(symbol string) ([]*types.Order, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("pionex fetch open orders need symbol")
+	}
+	queryDict := map[string]interface{}{
+		"symbol": Symbol2Pionex(symbol),
+	}
+	response := new(OpenOrdersRsp)
+	if err := client.request(http.MethodGet, FetchOpenOrderUri, queryDict, nil, response); err != nil {
+		return nil, err
+	}
+	result := make([]*types.Order, 0, len(response.Data.Orders))
+	for _, item := range response.Data.Orders {
+		result = append(result, pionexOrderTransform(item))
+	}
+	return result, nil
+}
+
+// FetchOrder 优先按orderId查询，没有时按clientOrderId查询
+func (client *RestClient) FetchOrder(order *types.Order) (*types.Order, error) {
+	var uri string
+	queryDict := map[string]interface{}{}
+	if order.OrderID != "" {
+		uri = FetchOrderUri
+		queryDict["orderId"] = order.OrderID
+	} else if order.ClientID != "" {
+		uri = FetchOrderByClientIdUri
+		queryDict["symbol"] = Symbol2Pionex(order.Symbol)
+		queryDict["clientOrderId"] = order.ClientID
+	} else {
+		return nil, fmt.Errorf("pionex fetch order need orderId or clientId")
+	}
+	response := new(OrderRsp)
+	if err := client.request(http.MethodGet, uri, queryDict, nil, response); err != nil {
+		return nil, err
+	}
+	return pionexOrderTransform(&response.Data), nil
+}
+
+func pionexOrderTransform(item *PionexOrder) *types.Order {
+	orderType := constant.Limit
+	if item.Type == "MARKET" {
+		orderType = constant.Market
+	} else if item.IOC {
+		orderType = constant.IOC
+	}
+	avgPrice := ""
+	filledSize, _ := utils.ParseFloat(item.FilledSize)
+	filledAmount, _ := utils.ParseFloat(item.FilledAmount)
+	if filledSize > 0 {
+		avgPrice = strconv.FormatFloat(filledAmount/filledSize, 'f', -1, 64)
+	}
+	return &types.Order{
+		Symbol:      Pionex2Symbol(item.Symbol),
+		Exchange:    constant.PionexSpot,
+		MarketType:  "spot",
+		Type:        orderType,
+		OrderID:     strconv.FormatInt(item.OrderId, 10),
+		ClientID:    item.ClientOrderId,
+		Side:        Pionex2Side[item.Side],
+		Price:       item.Price,
+		OrigQty:     item.Size,
+		Amount:      item.Amount,
+		ExecutedQty: item.FilledSize,
+		ExecutedAmt: item.FilledAmount,
+		AvgPrice:    avgPrice,
+		Fee:         item.Fee,
+		Status:      pionexOrderStatus(item.Status, item.Size, item.FilledSize),
+		CreateAt:    item.CreateTime,
+		UpdateAt:    item.UpdateTime,
+	}
+}
+
+// pionexOrderStatus pionex只有OPEN/CLOSED两种状态，需结合成交数量判断
+func pionexOrderStatus(status string, size string, filledSize string) constant.OrderStatus {
+	origQty, _ := utils.ParseFloat(size)
+	filledQty, _ := utils.ParseFloat(filledSize)
+	switch status {
+	case "OPEN":
+		if filledQty > 0 {
+			return constant.OrderPartialFilled
+		}
+		return constant.OrderOpen
+	case "CLOSED":
+		// 市价买单按金额下单，size为0
+		if filledQty > 0 && filledQty >= origQty {
+			return constant.OrderFilled
+		}
+		return constant.OrderCanceled
+	}
+	return constant.OrderSubmit
+}
@@ -0,0 +1,66 @@
+package pionex
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type Symbol struct {
+	Symbol         string `json:"symbol"`
+	Type           string `json:"type"`
+	BaseCurrency   string `json:"baseCurrency"`
+	QuoteCurrency  string `json:"quoteCurrency"`
+	BasePrecision  int32  `json:"basePrecision"`
+	QuotePrecision int32  `json:"quotePrecision"`
+	MinTradeSize   string `json:"minTradeSize"`
+	MaxTradeSize   string `json:"maxTradeSize"`
+	Enable         bool   `json:"enable"`
+}
+
+type SymbolsRsp struct {
+	BasePionexRsp
+	Data struct {
+		Symbols []*Symbol `json:"symbols"`
+	} `json:"data"`
+}
+
+func (client *RestClient) FetchSymbols() ([]*types.SymbolInfo, error) {
+	response := new(SymbolsRsp)
+	if err := client.publicGet(FetchSymbolsUri, nil, response); err != nil {
+		return nil, err
+	}
+	if len(response.Data.Symbols) == 0 {
+		return nil, fmt.Errorf("pionex get /api/v1/common/symbols empty")
+	}
+	return symbolTransform(response), nil
+}
+
+func symbolTransform(response *SymbolsRsp) []*types.SymbolInfo {
+	result := make([]*types.SymbolInfo, 0, len(response.Data.Symbols))
+	for _, item := range response.Data.Symbols {
+		if !item.Enable || item.Type != "SPOT" {
+			continue
+		}
+		minCnt, _ := utils.ParseFloat(item.MinTradeSize)
+		maxCnt, _ := utils.ParseFloat(item.MaxTradeSize)
+		baseCoin := strings.ToLower(item.BaseCurrency)
+		quoteCoin := strings.ToLower(item.QuoteCurrency)
+		info := &types.SymbolInfo{
+			Base:       baseCoin,
+			Quote:      quoteCoin,
+			Symbol:     Pionex2Symbol(item.Symbol),
+			FaceVal:    1,
+			Multiplier: 1,
+			PxPrec:     item.QuotePrecision,
+			QtyPrec:    item.BasePrecision,
+			MinCnt:     minCnt,
+			MaxCnt:     maxCnt,
+			Name:       baseCoin + "_" + quoteCoin,
+		}
+		result = append(result, info)
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package pionex
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+type Ticker struct {
+	Symbol string `json:"symbol"`
+	Time   int64  `json:"time"`
+	Open   string `json:"open"`
+	Close  string `json:"close"`
+	High   string `json:"high"`
+	Low    string `json:"low"`
+	Volume string `json:"volume"`
+}
+
+type TickerRsp struct {
+	BasePionexRsp
+	Data struct {
+		Tickers []*Ticker `json:"tickers"`
+	} `json:"data"`
+}
+
+type BookTicker struct {
+	Symbol    string `json:"symbol"`
+	BidPrice  string `json:"bidPrice"`
+	BidSize   string `json:"bidSize"`
+	AskPrice  string `json:"askPrice"`
+	AskSize   string `json:"askSize"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type BookTickerRsp struct {
+	BasePionexRsp
+	Data struct {
+		Tickers []*BookTicker `json:"tickers"`
+	} `json:"data"`
+}
+
+// FetchTickers 24小时行情接口没有买卖一档，需要合并bookTickers
+func (client *RestClient) FetchTickers() ([]*types.Ticker, error) {
+	response := new(TickerRsp)
+	if err := client.publicGet(FetchTickersUri, nil, response); err != nil {
+		return nil, err
+	}
+	if len(response.Data.Tickers) == 0 {
+		return nil, fmt.Errorf("pionex get /api/v1/market/tickers empty")
+	}
+
+	bookResponse := new(BookTickerRsp)
+	if err := client.publicGet(FetchBookTickersUri, nil, bookResponse); err != nil {
+		return nil, err
+	}
+	books := make(map[string]*BookTicker, len(bookResponse.Data.Tickers))
+	for _, item := range bookResponse.Data.Tickers {
+		books[item.Symbol] = item
+	}
+	return tickersTransform(response, books), nil
+}
+
+func tickersTransform(response *TickerRsp, books map[string]*BookTicker) []*types.Ticker {
+	result := make([]*types.Ticker, 0, len(response.Data.Tickers))
+	for _, item := range response.Data.Tickers {
+		open, _ := utils.ParseFloat(item.Open)
+		high, _ := utils.ParseFloat(item.High)
+		low, _ := utils.ParseFloat(item.Low)
+		vol, _ := utils.ParseFloat(item.Volume)
+		lastPrice, _ := utils.ParseFloat(item.Close)
+		ticker := &types.Ticker{
+			Symbol:     Pionex2Symbol(item.Symbol),
+			MarketType: "spot",
+			Open:       open,
+			High:       high,
+			Low:        low,
+			Vol:        vol,
+			LastPrice:  lastPrice,
+			ExchangeTs: item.Time,
+			Ts:         utils.Millisec(time.Now()),
+		}
+		if book, ok := books[item.Symbol]; ok {
+			ticker.AskPrice, _ = utils.ParseFloat(book.AskPrice)
+			ticker.AskSize, _ = utils.ParseFloat(book.AskSize)
+			ticker.BidPrice, _ = utils.ParseFloat(book.BidPrice)
+			ticker.BidSize, _ = utils.ParseFloat(book.BidSize)
+		}
+		result = append(result, ticker)
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package pionex
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+// PionexFill 成交明细
+type PionexFill struct {
+	Id        int64  `json:"id"`
+	OrderId   int64  `json:"orderId"`
+	Symbol    string `json:"symbol"`
+	Side      string `json:"side"`
+	Role      string `json:"role"` // TAKER/MAKER
+	Price     string `json:"price"`
+	Size      string `json:"size"`
+	Fee       string `json:"fee"`
+	FeeCoin   string `json:"feeCoin"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type FillsRsp struct {
+	BasePionexRsp
+	Data struct {
+		Fills []*PionexFill `json:"fills"`
+	} `json:"data"`
+}
+
+// FetchUserTrades 接口必须指定交易对，不支持按订单过滤，OrderID在本地过滤，结果按时间正序
+// 超过Limit时返回区间内最近的Limit条
+func (client *RestClient) FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) {
+	if param.Symbol == "" {
+		return nil, fmt.Errorf("pionex fetch user trades need symbol")
+	}
+	queryDict := map[string]interface{}{
+		"symbol": Symbol2Pionex(param.Symbol),
+	}
+	if param.StartTime > 0 {
+		queryDict["startTime"] = param.StartTime
+	}
+	if param.EndTime > 0 {
+		queryDict["endTime"] = param.EndTime
+	}
+	response := new(FillsRsp)
+	if err := client.request(http.MethodGet, FetchUserTradesUri, queryDict, nil, response); err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.Fill, 0, len(response.Data.Fills))
+	for _, item := range response.Data.Fills {
+		fill := fillTransform(item)
+		if param.OrderID != "" && fill.OrderID != param.OrderID {
+			continue
+		}
+		result = append(result, fill)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Ts < result[j].Ts })
+	if param.Limit > 0 && int64(len(result)) > param.Limit {
+		result = result[int64(len(result))-param.Limit:]
+	}
+	return result, nil
+}
+
+func fillTransform(item *PionexFill) *types.Fill {
+	price, _ := utils.ParseFloat(item.Price)
+	qty, _ := utils.ParseFloat(item.Size)
+	fee, _ := utils.ParseFloat(item.Fee)
+	fill := &types.Fill{
+		Symbol:   Pionex2Symbol(item.Symbol),
+		Exchange: constant.PionexSpot,
+		TradeID:  strconv.FormatInt(item.Id, 10),
+		OrderID:  strconv.FormatInt(item.OrderId, 10),
+		Side:     Pionex2Side[item.Side],
+		Price:    price,
+		Qty:      qty,
+		Fee:      fee,
+		FeeCoin:  item.FeeCoin,
+		Role:     constant.Taker,
+		Ts:       item.Timestamp,
+	}
+	if item.Role == "MAKER" {
+		fill.Role = constant.Maker
+	}
+	return fill
+}
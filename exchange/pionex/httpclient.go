@@ -0,0 +1,127 @@
+package pionex
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/httpx"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+var httpClient = httpx.NewClient()
+
+type RestClient struct {
+	apiKey       string
+	secretKey    string
+	exchangeType constant.ExchangeType
+	restUrl      string
+	httpClient   httpx.Client
+}
+
+// BasePionexRsp result为false时code/message为错误信息
+type BasePionexRsp struct {
+	Result    bool   `json:"result"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+func (t *BasePionexRsp) base() *BasePionexRsp {
+	return t
+}
+
+type pionexRsp interface {
+	base() *BasePionexRsp
+}
+
+func NewRestClient(apiKey, secretKey string) *RestClient {
+	client := &RestClient{
+		apiKey:       apiKey,
+		secretKey:    secretKey,
+		exchangeType: constant.PionexSpot,
+		restUrl:      RestUrl,
+		httpClient:   httpClient,
+	}
+	return client
+}
+
+// SetEndpoints 使用指定环境的rest地址
+func (client *RestClient) SetEndpoints(endpoints base.Endpoints) {
+	client.restUrl = endpoints.RestUrl
+}
+
+// SetHttpClient 使用交易所实例独立的http客户端(代理、超时、出口IP)
+func (client *RestClient) SetHttpClient(httpClient httpx.Client) {
+	client.httpClient = httpClient
+}
+
+// HttpRequest query需要带timestamp，POST/DELETE的参数为json body，都参与签名
+func (client *RestClient) HttpRequest(method string, uri string, query map[string]interface{}, payload map[string]interface{}) ([]byte, *http.Response, error) {
+	if query == nil {
+		query = map[string]interface{}{}
+	}
+	query["timestamp"] = time.Now().UnixMilli()
+	path := pathUrl(uri, query)
+
+	var body []byte
+	if payload != nil {
+		body, _ = sonic.Marshal(payload)
+	}
+	head := map[string]string{
+		"Content-Type":     "application/json",
+		"PIONEX-KEY":       client.apiKey,
+		"PIONEX-SIGNATURE": sign(method, path, string(body), client.secretKey),
+	}
+	args := &httpx.Request{
+		Url:    client.restUrl + path,
+		Head:   head,
+		Method: method,
+		Body:   body,
+	}
+	res, httpRes, err := client.httpClient.Request(args)
+	if err != nil {
+		return nil, httpRes, err
+	}
+	return *res, httpRes, err
+}
+
+func (client *RestClient) HttpGet(url string) ([]byte, *http.Response, error) {
+	body, res, err := client.httpClient.Get(url)
+	if err != nil {
+		return nil, res, err
+	}
+	return *body, res, nil
+}
+
+// publicGet 行情类接口不需要签名
+func (client *RestClient) publicGet(uri string, query map[string]interface{}, response pionexRsp) error {
+	body, _, err := client.HttpGet(client.restUrl + pathUrl(uri, query))
+	if err != nil {
+		log.Errorf("pionex get %s err:%v", uri, err)
+		return err
+	}
+	return parseResponse(http.MethodGet, uri, body, response)
+}
+
+func (client *RestClient) request(method string, uri string, query map[string]interface{}, payload map[string]interface{}, response pionexRsp) error {
+	body, _, err := client.HttpRequest(method, uri, query, payload)
+	if err != nil {
+		log.Errorf("pionex %s %s err:%v", method, uri, err)
+		return err
+	}
+	return parseResponse(method, uri, body, response)
+}
+
+func parseResponse(method string, uri string, body []byte, response pionexRsp) error {
+	if err := sonic.Unmarshal(body, response); err != nil {
+		log.Errorf("pionex %s %s parser err:%v", method, uri, err)
+		return err
+	}
+	if rsp := response.base(); !rsp.Result {
+		return fmt.Errorf("pionex %s %s fail, code:%s, msg:%s", method, uri, rsp.Code, rsp.Message)
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package pionex
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("package", "pionex")
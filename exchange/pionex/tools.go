@@ -0,0 +1,109 @@
+package pionex
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybernonce/gotrader/exchange/base"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+var (
+	RestUrl  = "https://api.pionex.com"
+	PubWsUrl = "wss://ws.pionex.com/wsPub"
+	PriWsUrl = "wss://ws.pionex.com/ws"
+
+	// Environments pionex没有测试网，只有实盘地址
+	Environments = map[constant.Environment]base.Endpoints{
+		constant.EnvProduction: {
+			RestUrl:  RestUrl,
+			PubWsUrl: PubWsUrl,
+			PriWsUrl: PriWsUrl,
+		},
+	}
+
+	Side2Pionex = map[constant.OrderSide]string{
+		constant.OrderBuy:  "BUY",
+		constant.OrderSell: "SELL",
+	}
+
+	Pionex2Side = map[string]constant.OrderSide{
+		"BUY":  constant.OrderBuy,
+		"SELL": constant.OrderSell,
+	}
+
+	// 通用周期转为pionex的周期，其他值原样传入
+	Interval2Pionex = map[string]string{
+		"1m":  "1M",
+		"5m":  "5M",
+		"15m": "15M",
+		"30m": "30M",
+		"1h":  "60M",
+		"4h":  "4H",
+		"8h":  "8H",
+		"12h": "12H",
+		"1d":  "1D",
+	}
+)
+
+const (
+	FetchSymbolsUri         = "/api/v1/common/symbols"
+	FetchTickersUri         = "/api/v1/market/tickers"
+	FetchBookTickersUri     = "/api/v1/market/bookTickers"
+	FetchKlineUri           = "/api/v1/market/klines"
+	FetchBalanceUri         = "/api/v1/account/balances"
+	CreateOrderUri          = "/api/v1/trade/order"
+	CreateMassOrderUri      = "/api/v1/trade/massOrder"
+	CancelOrderUri          = "/api/v1/trade/order"
+	FetchOrderUri           = "/api/v1/trade/order"
+	FetchOrderByClientIdUri = "/api/v1/trade/orderByClientOrderId"
+	FetchOpenOrderUri       = "/api/v1/trade/openOrders"
+	FetchUserTradesUri      = "/api/v1/trade/fills"
+
+	// massOrder单次最多的订单数，只支持同一交易对的限价单
+	maxMassOrders = 20
+)
+
+// pionex交易对格式与本地一致 BTC_USDT
+func Symbol2Pionex(symbol string) string {
+	return strings.ToUpper(symbol)
+}
+
+func Pionex2Symbol(symbol string) string {
+	return symbol
+}
+
+// pathUrl query按key排序后拼接在path后面，参与签名
+func pathUrl(uri string, param map[string]interface{}) string {
+	if len(param) == 0 {
+		return uri
+	}
+	return uri + "?" + utils.UrlEncodeParams(param)
+}
+
+// sign 签名字符串为 METHOD + PATH_URL + body，hex(hmac_sha256)
+func sign(method string, pathUrl string, body string, secretKey string) string {
+	return utils.GenHexDigest(utils.HmacSha256(method+pathUrl+body, secretKey))
+}
+
+// intervalMillis pionex周期转为毫秒，格式为数字+单位(M/H/D)
+func intervalMillis(interval string) int64 {
+	if len(interval) < 2 {
+		return 0
+	}
+	n, err := strconv.ParseInt(interval[:len(interval)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	switch interval[len(interval)-1] {
+	case 'M':
+		return n * int64(time.Minute/time.Millisecond)
+	case 'H':
+		return n * int64(time.Hour/time.Millisecond)
+	case 'D':
+		return n * int64(24*time.Hour/time.Millisecond)
+	}
+	return 0
+}
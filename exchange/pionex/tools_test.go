@@ -0,0 +1,65 @@
+package pionex
+
+import (
+	"testing"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
+func TestPathUrl(t *testing.T) {
+	param := map[string]interface{}{
+		"timestamp": 1655896754515,
+		"symbol":    "BTC_USDT",
+	}
+	want := "/api/v1/trade/allOrders?symbol=BTC_USDT&timestamp=1655896754515"
+	if got := pathUrl("/api/v1/trade/allOrders", param); got != want {
+		t.Fatalf("pathUrl = %s, want %s", got, want)
+	}
+	if got := pathUrl("/api/v1/common/symbols", nil); got != "/api/v1/common/symbols" {
+		t.Fatalf("pathUrl without param = %s", got)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// 相同参数签名结果固定，为64位hex
+	s1 := sign("GET", "/api/v1/trade/allOrders?symbol=BTC_USDT&timestamp=1655896754515", "", "secret")
+	s2 := sign("GET", "/api/v1/trade/allOrders?symbol=BTC_USDT&timestamp=1655896754515", "", "secret")
+	if s1 != s2 || len(s1) != 64 {
+		t.Fatalf("unexpected sign %s %s", s1, s2)
+	}
+}
+
+func TestIntervalMillis(t *testing.T) {
+	cases := map[string]int64{
+		"1M":  60000,
+		"60M": 3600000,
+		"4H":  14400000,
+		"1D":  86400000,
+		"x":   0,
+	}
+	for interval, want := range cases {
+		if got := intervalMillis(interval); got != want {
+			t.Errorf("intervalMillis(%s) = %d, want %d", interval, got, want)
+		}
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	cases := []struct {
+		status     string
+		size       string
+		filledSize string
+		want       constant.OrderStatus
+	}{
+		{"OPEN", "1", "0", constant.OrderOpen},
+		{"OPEN", "1", "0.5", constant.OrderPartialFilled},
+		{"CLOSED", "1", "1", constant.OrderFilled},
+		{"CLOSED", "1", "0.5", constant.OrderCanceled},
+		{"CLOSED", "0", "0.01", constant.OrderFilled},
+	}
+	for _, c := range cases {
+		if got := pionexOrderStatus(c.status, c.size, c.filledSize); got != c.want {
+			t.Errorf("pionexOrderStatus(%s, %s, %s) = %s, want %s", c.status, c.size, c.filledSize, got.Name(), c.want.Name())
+		}
+	}
+}
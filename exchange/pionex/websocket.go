@@ -0,0 +1,253 @@
+package pionex
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/pkg/ws"
+	"github.com/cybernonce/gotrader/trader/constant"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+const (
+	DepthTopic   = "DEPTH"
+	TradeTopic   = "TRADE"
+	OrderTopic   = "ORDER"
+	BalanceTopic = "BALANCE"
+
+	// DEPTH频道每次推送完整的深度快照
+	depthLimit = 20
+)
+
+type PionexWsData struct {
+	Op        string          `json:"op"`
+	Topic     string          `json:"topic"`
+	Symbol    string          `json:"symbol"`
+	Type      string          `json:"type"` // 订阅结果 SUBSCRIBED/ERROR
+	Code      string          `json:"code"`
+	Message   string          `json:"message"`
+	Timestamp int64           `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type PionexImp struct {
+	url       string
+	accessKey string
+	secretKey string
+	isPrivate bool
+	rspHandle func(interface{})
+}
+
+func NewPionexPubWsClient(url string, rspHandle func(interface{})) *ws.WsClient {
+	imp := &PionexImp{
+		url:       url,
+		rspHandle: rspHandle,
+	}
+	return ws.NewWsClient(url, imp, constant.PionexSpot, 15*time.Second, 60*time.Second)
+}
+
+// NewPionexPriWsClient 私有连接在url中签名，每次连接前重新生成
+func NewPionexPriWsClient(url, accessKey, secretKey string, rspHandle func(interface{})) *ws.WsClient {
+	imp := &PionexImp{
+		url:       url,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		isPrivate: true,
+		rspHandle: rspHandle,
+	}
+	client := ws.NewWsClient(url, imp, constant.PionexSpot, 15*time.Second, 60*time.Second)
+	client.SetUrlFunc(imp.privateUrl)
+	return client
+}
+
+// privateUrl 签名字符串为 PATH_URL + "websocket_auth"，PATH取自连接地址
+func (pionex *PionexImp) privateUrl() string {
+	path := "/"
+	if u, err := url.Parse(pionex.url); err == nil && u.Path != "" {
+		path = u.Path
+	}
+	query := map[string]interface{}{
+		"key":       pionex.accessKey,
+		"timestamp": time.Now().UnixMilli(),
+	}
+	encoded := utils.UrlEncodeParams(query)
+	signature := utils.GenHexDigest(utils.HmacSha256(path+"?"+encoded+"websocket_auth", pionex.secretKey))
+	return pionex.url + "?" + encoded + "&signature=" + signature
+}
+
+// Ping 由服务端发起PING，客户端回复PONG，这里不需要主动发送
+func (pionex *PionexImp) Ping(cli *ws.WsClient) {}
+
+func (pionex *PionexImp) OnConnected(cli *ws.WsClient, typ ws.ConnectType) {
+	if pionex.isPrivate {
+		log.Info("pionex private ws connected")
+		return
+	}
+	log.Info("pionex public ws connected")
+}
+
+// Subscribe BALANCE频道symbol为空
+func (pionex *PionexImp) Subscribe(symbol string, topic string) map[string]interface{} {
+	result := map[string]interface{}{
+		"op":    "SUBSCRIBE",
+		"topic": topic,
+	}
+	if symbol != "" {
+		result["symbol"] = Symbol2Pionex(symbol)
+	}
+	if topic == DepthTopic {
+		result["limit"] = depthLimit
+	}
+	return result
+}
+
+func (pionex *PionexImp) Handle(cli *ws.WsClient, bs []byte) {
+	var dat PionexWsData
+	if err := sonic.Unmarshal(bs, &dat); err != nil {
+		log.WithError(err).Error("unmarshal pionex ws data failed")
+		return
+	}
+
+	if dat.Op == "PING" {
+		cli.SetRecvPongTime(time.Now())
+		cli.Write(map[string]interface{}{"op": "PONG", "timestamp": dat.Timestamp})
+		return
+	}
+	if dat.Type != "" {
+		if dat.Type == "ERROR" {
+			log.WithField("topic", dat.Topic).Errorf("pionex ws error: %s %s", dat.Code, dat.Message)
+		} else {
+			log.WithField("topic", dat.Topic).Infof("pionex ws %s", dat.Type)
+		}
+		return
+	}
+
+	switch dat.Topic {
+	case DepthTopic:
+		pionex.onDepth(&dat)
+	case TradeTopic:
+		pionex.onTrades(dat.Data)
+	case OrderTopic:
+		pionex.onOrder(dat.Data)
+	case BalanceTopic:
+		pionex.onBalance(dat.Data)
+	default:
+		log.WithField("dat", string(bs)).Warn("unknown pionex message")
+	}
+}
+
+// onDepth 推送完整的订单簿，由exchange按订阅转为BookTicker或OrderBook
+func (pionex *PionexImp) onDepth(dat *PionexWsData) {
+	var data struct {
+		Bids [][]string `json:"bids"`
+		Asks [][]string `json:"asks"`
+	}
+	if err := sonic.Unmarshal(dat.Data, &data); err != nil {
+		log.WithError(err).Error("unmarshal pionex depth failed")
+		return
+	}
+	pionex.rspHandle(&types.OrderBook{
+		Symbol:     Pionex2Symbol(dat.Symbol),
+		Exchange:   constant.PionexSpot,
+		Asks:       depthTransform(data.Asks),
+		Bids:       depthTransform(data.Bids),
+		ExchangeTs: dat.Timestamp * 1000,
+		Ts:         utils.Microsec(time.Now()),
+	})
+}
+
+func depthTransform(levels [][]string) []types.OrderBookItem {
+	result := make([]types.OrderBookItem, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		price, _ := strconv.ParseFloat(level[0], 64)
+		qty, _ := strconv.ParseFloat(level[1], 64)
+		result = append(result, types.OrderBookItem{Price: price, Qty: qty})
+	}
+	return result
+}
+
+// toBookTicker 取订单簿的一档
+func toBookTicker(book *types.OrderBook) *types.BookTicker {
+	ticker := &types.BookTicker{
+		Symbol:     book.Symbol,
+		Exchange:   book.Exchange,
+		ExchangeTs: book.ExchangeTs,
+		LocalTs:    book.Ts,
+		EventTs:    utils.Microsec(time.Now()),
+	}
+	if len(book.Asks) > 0 {
+		ticker.AskPrice = book.Asks[0].Price
+		ticker.AskQty = book.Asks[0].Qty
+	}
+	if len(book.Bids) > 0 {
+		ticker.BidPrice = book.Bids[0].Price
+		ticker.BidQty = book.Bids[0].Qty
+	}
+	return ticker
+}
+
+func (pionex *PionexImp) onTrades(dat json.RawMessage) {
+	curTs := utils.Microsec(time.Now())
+
+	type Trade struct {
+		Symbol    string `json:"symbol"`
+		TradeId   string `json:"tradeId"`
+		Price     string `json:"price"`
+		Size      string `json:"size"`
+		Side      string `json:"side"`
+		Timestamp int64  `json:"timestamp"`
+	}
+
+	var trades []Trade
+	if err := sonic.Unmarshal(dat, &trades); err != nil {
+		log.WithError(err).Error("unmarshal pionex trades failed")
+		return
+	}
+
+	result := make([]*types.Trade, 0, len(trades))
+	for _, trade := range trades {
+		price, _ := strconv.ParseFloat(trade.Price, 64)
+		size, _ := strconv.ParseFloat(trade.Size, 64)
+		evt := &types.Trade{
+			Symbol:     Pionex2Symbol(trade.Symbol),
+			MarketType: constant.PionexSpot,
+			TradeID:    trade.TradeId,
+			Side:       Pionex2Side[trade.Side],
+			Price:      price,
+			Size:       size,
+			Count:      1,
+			ExchangeTs: trade.Timestamp * 1000,
+			LocalTs:    curTs,
+			EventTs:    utils.Microsec(time.Now()),
+		}
+		result = append(result, evt)
+	}
+	pionex.rspHandle(result)
+}
+
+func (pionex *PionexImp) onOrder(dat json.RawMessage) {
+	var order PionexOrder
+	if err := sonic.Unmarshal(dat, &order); err != nil {
+		log.WithError(err).Error("unmarshal pionex order failed")
+		return
+	}
+	pionex.rspHandle([]*types.Order{pionexOrderTransform(&order)})
+}
+
+func (pionex *PionexImp) onBalance(dat json.RawMessage) {
+	var data struct {
+		Balances []*PionexBalance `json:"balances"`
+	}
+	if err := sonic.Unmarshal(dat, &data); err != nil {
+		log.WithError(err).Error("unmarshal pionex balance failed")
+		return
+	}
+	pionex.rspHandle(balanceTransform(data.Balances))
+}
@@ -0,0 +1,40 @@
+package pionex
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/cybernonce/gotrader/pkg/utils"
+	"github.com/cybernonce/gotrader/trader/types"
+)
+
+func TestPrivateUrlSignPath(t *testing.T) {
+	imp := &PionexImp{url: "wss://proxy.example.com/pionex/ws", accessKey: "key", secretKey: "secret"}
+	u, err := url.Parse(imp.privateUrl())
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	encoded := utils.UrlEncodeParams(map[string]interface{}{"key": query.Get("key"), "timestamp": query.Get("timestamp")})
+	expect := utils.GenHexDigest(utils.HmacSha256("/pionex/ws?"+encoded+"websocket_auth", "secret"))
+	if query.Get("signature") != expect {
+		t.Fatalf("signature %s, want %s", query.Get("signature"), expect)
+	}
+}
+
+func TestDepthMicrosecond(t *testing.T) {
+	var book *types.OrderBook
+	imp := &PionexImp{rspHandle: func(v interface{}) { book = v.(*types.OrderBook) }}
+	imp.onDepth(&PionexWsData{
+		Symbol:    "BTC_USDT",
+		Timestamp: 1700000000123,
+		Data:      []byte(`{"bids":[["100","1"]],"asks":[["101","2"]]}`),
+	})
+	if book == nil || book.ExchangeTs != 1700000000123000 || book.Ts < book.ExchangeTs {
+		t.Fatalf("unexpected book %+v", book)
+	}
+	ticker := toBookTicker(book)
+	if ticker.ExchangeTs != book.ExchangeTs || ticker.LocalTs != book.Ts || ticker.BidPrice != 100 || ticker.AskQty != 2 {
+		t.Fatalf("unexpected ticker %+v", ticker)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -50,6 +51,10 @@ type Config struct {
 	DialTimeout time.Duration
 	// 心跳时间
 	KeepAlive time.Duration
+	// 代理地址，支持http/socks5，为空时使用环境变量中的代理
+	ProxyURL string
+	// 本地出口IP，为空时由系统选择
+	LocalAddr string
 }
 
 type Request struct {
@@ -64,25 +69,65 @@ func GetDefaultConfig() Config {
 }
 
 func NewClient() Client {
-	return NewClientWithConfig(defaultConfig)
+	c, _ := NewClientWithConfig(defaultConfig)
+	return c
 }
 
-func NewClientWithConfig(config Config) Client {
+// NewClientWithConfig 代理地址或本地IP格式错误时返回错误
+func NewClientWithConfig(config Config) (Client, error) {
+	proxy, err := ProxyFunc(config.ProxyURL)
+	if err != nil {
+		return Client{}, err
+	}
+	dialer, err := NewDialer(config)
+	if err != nil {
+		return Client{}, err
+	}
 	c := &http.Client{
 		Timeout: config.RequestTimeout,
 		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   config.DialTimeout,
-				KeepAlive: config.KeepAlive,
-			}).DialContext,
+			Proxy:               proxy,
+			DialContext:         dialer.DialContext,
 			MaxIdleConns:        config.MaxIdleConns,
 			MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
 			MaxConnsPerHost:     config.MaxConnsPerHost,
 			IdleConnTimeout:     config.IdleConnTimeout,
 		},
 	}
-	return Client{c}
+	return Client{c}, nil
+}
+
+// ProxyFunc 按代理地址生成Transport.Proxy，为空时使用环境变量中的代理
+func ProxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("代理地址%s格式错误:%v", proxyURL, err)
+	}
+	switch u.Scheme {
+	case "http", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("不支持的代理协议:%s", proxyURL)
+	}
+	return http.ProxyURL(u), nil
+}
+
+// NewDialer LocalAddr不为空时从指定的本地IP发起连接，用于多IP出口
+func NewDialer(config Config) (*net.Dialer, error) {
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: config.KeepAlive,
+	}
+	if config.LocalAddr != "" {
+		ip := net.ParseIP(config.LocalAddr)
+		if ip == nil {
+			return nil, fmt.Errorf("本地IP%s格式错误", config.LocalAddr)
+		}
+		dialer.LocalAddr = &net.TCPAddr{IP: ip}
+	}
+	return dialer, nil
 }
 
 func (c *Client) Get(url string) (*[]byte, *http.Response, error) {
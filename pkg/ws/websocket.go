@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cybernonce/gotrader/pkg/httpx"
 	"github.com/cybernonce/gotrader/trader/constant"
 
 	"github.com/bytedance/sonic"
@@ -15,6 +16,8 @@ import (
 
 type WsClient struct {
 	url      string
+	urlFunc  func() string
+	dialer   *websocket.Dialer
 	Conn     *websocket.Conn
 	wch      chan []byte
 	imp      WsImp
@@ -27,10 +30,33 @@ type WsClient struct {
 	pingInterval time.Duration
 	pongTimeout  time.Duration
 
+	// 两次发送之间的最小间隔，用于满足交易所的消息频率限制
+	writeInterval time.Duration
+
 	mutex  sync.Mutex
 	quit   chan struct{}
 	closed bool
 	epoch  int64
+
+	// 主动重连标记，readLoop退出时据此决定是否重连
+	forceReconnect int32
+}
+
+// NewDialer 按代理、连接超时和本地IP创建拨号器，socks5代理由websocket库处理
+func NewDialer(config httpx.Config) (*websocket.Dialer, error) {
+	proxy, err := httpx.ProxyFunc(config.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	netDialer, err := httpx.NewDialer(config)
+	if err != nil {
+		return nil, err
+	}
+	return &websocket.Dialer{
+		Proxy:            proxy,
+		NetDialContext:   netDialer.DialContext,
+		HandshakeTimeout: time.Second * 10,
+	}, nil
 }
 
 // NewWsClient 新建Ws客户端
@@ -45,6 +71,21 @@ func NewWsClient(url string, imp WsImp, exchangeType constant.ExchangeType, ping
 	}
 }
 
+// SetDialer 使用交易所实例的代理和本地IP连接，需要在Dial之前设置
+func (ws *WsClient) SetDialer(dialer *websocket.Dialer) {
+	ws.dialer = dialer
+}
+
+// SetUrlFunc 连接地址带签名等时效参数时使用，每次连接(包括重连)前重新生成
+func (ws *WsClient) SetUrlFunc(f func() string) {
+	ws.urlFunc = f
+}
+
+// SetWriteInterval 订阅等消息在写协程中按间隔依次发送，不阻塞调用方
+func (ws *WsClient) SetWriteInterval(t time.Duration) {
+	ws.writeInterval = t
+}
+
 func (ws *WsClient) SetPingInterval(t time.Duration) {
 	ws.pingInterval = t
 }
@@ -67,11 +108,18 @@ func (ws *WsClient) WatchClosed() {
 }
 
 func (ws *WsClient) Dial(typ ConnectType) error {
-	dialer := &websocket.Dialer{
-		Proxy:            http.ProxyFromEnvironment,
-		HandshakeTimeout: time.Second * 10,
+	dialer := ws.dialer
+	if dialer == nil {
+		dialer = &websocket.Dialer{
+			Proxy:            http.ProxyFromEnvironment,
+			HandshakeTimeout: time.Second * 10,
+		}
+	}
+	url := ws.url
+	if ws.urlFunc != nil {
+		url = ws.urlFunc()
 	}
-	conn, _, err := dialer.Dial(ws.url, nil)
+	conn, _, err := dialer.Dial(url, nil)
 	if err != nil {
 		return fmt.Errorf("ws.Dial:%v", err)
 	}
@@ -82,6 +130,7 @@ func (ws *WsClient) Dial(typ ConnectType) error {
 	ws.recvPingTime = now
 	ws.recvPongTime = now
 	ws.quit = make(chan struct{})
+	atomic.StoreInt32(&ws.forceReconnect, 0)
 	if ws.subMap == nil {
 		ws.subMap = make(map[string][]string)
 	}
@@ -129,6 +178,12 @@ func (ws *WsClient) reconnect() {
 	}
 }
 
+// Reconnect 主动断开当前连接并重连，用于连接地址中的凭证失效等场景，重连后恢复订阅
+func (ws *WsClient) Reconnect() {
+	atomic.StoreInt32(&ws.forceReconnect, 1)
+	ws.Close()
+}
+
 func (ws *WsClient) Close() {
 	ws.mutex.Lock()
 	defer ws.mutex.Unlock()
@@ -174,7 +229,8 @@ func (ws *WsClient) readLoop() {
 	var needReconnect bool
 	defer func() {
 		ws.Close()
-		if needReconnect {
+		forced := atomic.CompareAndSwapInt32(&ws.forceReconnect, 1, 0)
+		if needReconnect || forced {
 			ws.reconnect() // 断开时重连
 		}
 	}()
@@ -210,15 +266,24 @@ func (ws *WsClient) writeLoop() {
 				log.WithError(err).Errorln("write failed")
 				return
 			}
+			if ws.writeInterval > 0 {
+				time.Sleep(ws.writeInterval)
+			}
 		}
 	}
 }
 
 func (ws *WsClient) Subscribe(symbol string, topic string) {
-	ovs, ok := ws.subMap[symbol]
-	if !ok {
-		ovs = append(ovs, topic)
-		ws.subMap[symbol] = ovs
+	// 同一个symbol可能订阅多个topic，都需要记录下来用于重连后重新订阅
+	subscribed := false
+	for _, t := range ws.subMap[symbol] {
+		if t == topic {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		ws.subMap[symbol] = append(ws.subMap[symbol], topic)
 	}
 	streams := ws.imp.Subscribe(symbol, topic)
 	ws.Write(streams)
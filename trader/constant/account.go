@@ -0,0 +1,40 @@
+package constant
+
+// MarginMode 保证金模式，零值为全仓，与之前下单时固定使用全仓保持一致
+type MarginMode int
+
+func (m MarginMode) Name() string {
+	switch m {
+	case MarginCross:
+		return "cross"
+	case MarginIsolated:
+		return "isolated"
+	case MarginCash:
+		return "cash"
+	}
+	return "unknown_marginMode"
+}
+
+const (
+	MarginCross    MarginMode = iota // 全仓
+	MarginIsolated                   // 逐仓
+	MarginCash                       // 现货非杠杆
+)
+
+// PositionMode 持仓模式
+type PositionMode int
+
+func (m PositionMode) Name() string {
+	switch m {
+	case OneWayMode:
+		return "one_way"
+	case HedgeMode:
+		return "hedge"
+	}
+	return "unknown_positionMode"
+}
+
+const (
+	OneWayMode PositionMode = iota // 单向持仓
+	HedgeMode                      // 双向持仓，通过Long/Short/CloseLong/CloseShort区分开平
+)
@@ -9,7 +9,10 @@ const (
 	Exchange_OkxV5Swap        = "okxV5Swap"
 	Exchange_BinanceSpot      = "binanceSpot"
 	Exchange_BinanceUFutures  = "binanceUFutures"
+	Exchange_BinanceCFutures  = "binanceCFutures"
 	Exchange_BinancePortfolio = "binancePortfolio"
+	Exchange_BybitV5Spot      = "bybitV5Spot"
+	Exchange_BybitV5Linear    = "bybitV5Linear"
 )
 
 type ExchangeType int
@@ -20,6 +23,8 @@ func (e ExchangeType) Name() string {
 		return Exchange_PionexSpot
 	case OkxV5Spot:
 		return Exchange_OkxV5Spot
+	case OkxV5Future:
+		return Exchange_OkxV5Future
 	case OkxV5Swap:
 		return Exchange_OkxV5Swap
 	case BinanceSpot:
@@ -28,6 +33,12 @@ func (e ExchangeType) Name() string {
 		return Exchange_BinanceUFutures
 	case BinancePortfolio:
 		return Exchange_BinancePortfolio
+	case BybitV5Spot:
+		return Exchange_BybitV5Spot
+	case BybitV5Linear:
+		return Exchange_BybitV5Linear
+	case BinanceCFutures:
+		return Exchange_BinanceCFutures
 	}
 	return "unknown"
 }
@@ -40,6 +51,9 @@ const (
 	BinanceSpot
 	BinanceUFutures
 	BinancePortfolio
+	BybitV5Spot
+	BybitV5Linear
+	BinanceCFutures
 )
 
 func MustConverToExchangeType(name string) ExchangeType {
@@ -48,9 +62,47 @@ func MustConverToExchangeType(name string) ExchangeType {
 		return PionexSpot
 	case Exchange_OkxV5Spot:
 		return OkxV5Spot
-	case Exchange_OkxV5Swap:
+	case Exchange_OkxV5Future:
 		return OkxV5Future
+	case Exchange_OkxV5Swap:
+		return OkxV5Swap
+	case Exchange_BinanceSpot:
+		return BinanceSpot
+	case Exchange_BinanceUFutures:
+		return BinanceUFutures
+	case Exchange_BinancePortfolio:
+		return BinancePortfolio
+	case Exchange_BybitV5Spot:
+		return BybitV5Spot
+	case Exchange_BybitV5Linear:
+		return BybitV5Linear
+	case Exchange_BinanceCFutures:
+		return BinanceCFutures
 	}
 	err := fmt.Errorf("unknonw exchange name:%s", name)
 	panic(err)
 }
+
+// Environment 交易所环境，零值为实盘
+type Environment int
+
+func (e Environment) Name() string {
+	switch e {
+	case EnvProduction:
+		return "production"
+	case EnvTestnet:
+		return "testnet"
+	case EnvDemo:
+		return "demo"
+	case EnvColo:
+		return "colo"
+	}
+	return "unknown_environment"
+}
+
+const (
+	EnvProduction Environment = iota // 实盘
+	EnvTestnet                       // 测试网，如binance testnet
+	EnvDemo                          // 模拟盘，如okx模拟交易
+	EnvColo                          // 托管机房专线地址
+)
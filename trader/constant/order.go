@@ -17,6 +17,16 @@ func (t OrderType) Name() string {
 		return "FOK"
 	case PostOnly:
 		return "POST_ONLY"
+	case StopLoss:
+		return "STOP_LOSS"
+	case TakeProfit:
+		return "TAKE_PROFIT"
+	case OCO:
+		return "OCO"
+	case Trigger:
+		return "TRIGGER"
+	case TrailingStop:
+		return "TRAILING_STOP"
 	}
 	return "unknown_orderType"
 }
@@ -28,8 +38,20 @@ const (
 	GTC
 	FOK
 	PostOnly
+
+	// 策略委托，触发后才生成普通订单
+	StopLoss     // 止损
+	TakeProfit   // 止盈
+	OCO          // 止盈止损二选一
+	Trigger      // 计划委托
+	TrailingStop // 移动止盈止损
 )
 
+// IsAlgo 是否为策略委托
+func (t OrderType) IsAlgo() bool {
+	return t >= StopLoss && t <= TrailingStop
+}
+
 // ORDER SIDE
 type OrderSide int
 
@@ -46,7 +68,7 @@ func (s OrderSide) Name() string {
 	case CloseLong:
 		return "CLOSELONG"
 	case CloseShort:
-		return "SHORT"
+		return "CLOSESHORT"
 	case All:
 		return "All"
 	}
@@ -86,6 +108,8 @@ func (s OrderStatus) Name() string {
 		return "cancelled"
 	case OrderClosed:
 		return "closed"
+	case OrderTriggered:
+		return "triggered"
 	}
 	return "unknown_orderStatus"
 }
@@ -94,7 +118,8 @@ func (s OrderStatus) IsOver() bool {
 	return s == OrderFilled ||
 		s == OrderFailed ||
 		s == OrderCanceled ||
-		s == OrderClosed
+		s == OrderClosed ||
+		s == OrderTriggered
 }
 
 const (
@@ -107,4 +132,23 @@ const (
 	OrderFailed
 	OrderCanceled
 	OrderClosed
+	OrderTriggered // 策略委托已触发并生成订单，订单的成交状态需要按OrderID查询
+)
+
+// AmendMode 改单实际走的路径
+type AmendMode int
+
+func (m AmendMode) Name() string {
+	switch m {
+	case AmendNative:
+		return "native"
+	case AmendCancelReplace:
+		return "cancel_replace"
+	}
+	return "unknown_amendMode"
+}
+
+const (
+	AmendNative        AmendMode = iota // 交易所原生改单，保留订单ID
+	AmendCancelReplace                  // 先撤单再下新单，订单ID会变化
 )
@@ -0,0 +1,47 @@
+package constant
+
+// WalletType 充提记录类型
+type WalletType int
+
+func (t WalletType) Name() string {
+	switch t {
+	case Deposit:
+		return "deposit"
+	case Withdraw:
+		return "withdraw"
+	}
+	return "unknown_walletType"
+}
+
+const (
+	Deposit WalletType = iota
+	Withdraw
+)
+
+// WalletStatus 统一各交易所的充提状态
+type WalletStatus int
+
+func (s WalletStatus) Name() string {
+	switch s {
+	case WalletPending:
+		return "pending"
+	case WalletSuccess:
+		return "success"
+	case WalletFailed:
+		return "failed"
+	case WalletCanceled:
+		return "canceled"
+	}
+	return "unknown_walletStatus"
+}
+
+func (s WalletStatus) IsOver() bool {
+	return s == WalletSuccess || s == WalletFailed || s == WalletCanceled
+}
+
+const (
+	WalletPending WalletStatus = iota // 处理中，包括等待确认和人工审核
+	WalletSuccess
+	WalletFailed
+	WalletCanceled
+)
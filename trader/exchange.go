@@ -12,6 +12,7 @@ type Exchange interface {
 
 	// rest Public
 	FetchKline(symbol string, interval string, limit int64) ([]types.Kline, error)
+	FetchHistoryKline(param base.KlineParam) ([]types.Kline, error) // 按时间正序返回，自动分页
 	FetchFundingRate(symbol string) (*types.FundingRate, error)
 	FetchFundingRateHistory(symbol string, limit int64) ([]*types.FundingRate, error)
 	FetchSymbols() ([]*types.SymbolInfo, error)
@@ -21,12 +22,40 @@ type Exchange interface {
 	FetchBalance() (*types.Assets, error)
 	FetchAssetBalance() (*types.Assets, error)
 	FetchPositons() ([]*types.Position, error)
+	FetchOpenOrders(symbol string) ([]*types.Order, error)            // symbol为空时查询全部交易对
+	FetchOrder(order *types.Order) (*types.Order, error)              // 按OrderID或ClientID查询
+	FetchUserTrades(param base.UserTradeParam) ([]*types.Fill, error) // 按时间正序返回，自动分页，Limit语义同KlineParam
 	CreateBatchOrders([]*types.Order) ([]*types.OrderResult, error)
 	CancelBatchOrders(orders []*types.Order) ([]*types.OrderResult, error)
+	AmendBatchOrders(orders []*types.Order) ([]*types.AmendResult, error) // Price/OrigQty为改单后的值，不支持原生改单的交易所撤单后重新下单
 	PrivateTransfer(transfer base.TransferParam) (string, error)
+	SetLeverage(symbol string, leverage int64, marginMode constant.MarginMode) error // 同时设置交易对的保证金模式
+	SetPositionMode(mode constant.PositionMode) error
 
 	// ws
 	Subscribe(params map[string]interface{}) (err error)
 	SubscribeBookTicker(symbols []string, callback func(*types.BookTicker)) (err error)
+	SubscribeTrades(symbols []string, callback func([]*types.Trade)) (err error)
+	SubscribeOrderBook(symbols []string, callback func(*types.OrderBook)) (err error) // 推送完整深度快照
 	SubscribeOrders(symbols []string, callback func(orders []*types.Order)) (err error)
+	SubscribeBalance(callback func(*types.Assets)) (err error)       // 余额变动推送，可能只包含发生变化的币种
+	SubscribePositions(callback func([]*types.Position)) (err error) // 持仓变动推送，持仓为0表示已平仓
+}
+
+// Wallet 充提币接口，支持的交易所可以通过类型断言获取
+type Wallet interface {
+	FetchCurrencies() ([]*types.Currency, error)
+	FetchDepositAddress(coin string, chain string) (*types.DepositAddress, error)
+	Withdraw(param base.WithdrawParam) (string, error) // 返回提币ID
+	FetchDepositHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error)
+	FetchWithdrawHistory(param base.WalletRecordParam) ([]*types.WalletRecord, error)
+}
+
+// DerivativesStream 合约行情推送接口，支持的交易所可以通过类型断言获取
+type DerivativesStream interface {
+	SubscribeFundingRates(symbols []string, callback func(*types.FundingRate)) (err error)
+	SubscribeMarkPrices(symbols []string, callback func(*types.MarkPrice)) (err error)
+	SubscribeIndexPrices(symbols []string, callback func(*types.IndexPrice)) (err error) // symbol为合约时订阅其标的指数
+	SubscribeOpenInterest(symbols []string, callback func(*types.OpenInterest)) (err error)
+	SubscribeLiquidations(symbols []string, callback func(*types.Liquidation)) (err error) // symbols为空时推送全部合约的强平单
 }
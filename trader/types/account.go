@@ -0,0 +1,10 @@
+package types
+
+import "github.com/cybernonce/gotrader/trader/constant"
+
+// AccountConfig 账户配置
+type AccountConfig struct {
+	Uid          string
+	PositionMode constant.PositionMode
+	AccountLevel string // OKX账户模式 1:简单交易 2:单币种保证金 3:跨币种保证金 4:组合保证金
+}
@@ -0,0 +1,36 @@
+package types
+
+import "github.com/cybernonce/gotrader/trader/constant"
+
+// AlgoOrder 策略委托，价格为-1表示触发后按市价委托
+type AlgoOrder struct {
+	Symbol     string
+	Exchange   constant.ExchangeType
+	Type       constant.OrderType // StopLoss/TakeProfit/OCO/Trigger/TrailingStop
+	AlgoID     string
+	ClientID   string
+	Side       constant.OrderSide
+	OrigQty    string
+	ReduceOnly bool
+	MarginMode constant.MarginMode
+
+	// Trigger
+	TriggerPrice string
+	OrderPrice   string
+
+	// StopLoss/TakeProfit/OCO
+	TpTriggerPrice string
+	TpOrderPrice   string
+	SlTriggerPrice string
+	SlOrderPrice   string
+
+	// TrailingStop，回调幅度比例和价距二选一
+	CallbackRatio  string
+	CallbackSpread string
+	ActivePrice    string // 激活价格，为空时立即激活
+
+	Status   constant.OrderStatus
+	OrderID  string // 触发后生成的订单ID
+	CreateAt int64
+	UpdateAt int64
+}
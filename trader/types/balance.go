@@ -16,3 +16,20 @@ type Assets struct {
 	AccountMargin float64
 	Borrowed      float64
 }
+
+// RiskLevel 统一账户风险等级变化推送
+type RiskLevel struct {
+	UniMMR       float64 // 统一账户维持保证金率
+	Status       string  // MARGIN_CALL/SUPPLY_MARGIN/REDUCE_ONLY/ACTIVE_LIQUIDATION/FORCE_LIQUIDATION/BANKRUPTED
+	Equity       float64 // 账户权益(USD)
+	ActualEquity float64 // 不考虑质押率的实际权益(USD)
+	MaintMargin  float64 // 维持保证金(USD)
+	Ts           int64
+}
+
+// BalanceUpdate 充提、划转引起的余额变化推送，Delta为变化量
+type BalanceUpdate struct {
+	Coin  string
+	Delta float64
+	Ts    int64 // 毫秒
+}
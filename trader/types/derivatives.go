@@ -0,0 +1,35 @@
+package types
+
+import "github.com/cybernonce/gotrader/trader/constant"
+
+// MarkPrice 合约标记价格，binance同时推送指数价格，okx的IndexPrice为0
+type MarkPrice struct {
+	Symbol     string
+	Exchange   constant.ExchangeType
+	MarkPrice  float64
+	IndexPrice float64
+	ExchangeTs int64 // 微秒
+	Ts         int64 // 本地收到的时间，毫秒
+}
+
+// IndexPrice 指数价格，Symbol为指数名称，如 BTC_USDT/BTC_USD
+type IndexPrice struct {
+	Symbol     string
+	Exchange   constant.ExchangeType
+	IndexPrice float64
+	Open24h    float64 // 仅okx推送
+	High24h    float64
+	Low24h     float64
+	ExchangeTs int64 // 微秒
+	Ts         int64 // 本地收到的时间，毫秒
+}
+
+// OpenInterest 合约持仓量
+type OpenInterest struct {
+	Symbol          string
+	Exchange        constant.ExchangeType
+	OpenInterest    float64 // 张数
+	OpenInterestCcy float64 // 币的数量
+	ExchangeTs      int64   // 微秒
+	Ts              int64   // 本地收到的时间，毫秒
+}
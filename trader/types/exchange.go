@@ -1,10 +1,28 @@
 package types
 
+import (
+	"time"
+
+	"github.com/cybernonce/gotrader/trader/constant"
+)
+
 type ExchangeParameters struct {
 	DebugMode  bool
 	ProxyURL   string // example: socks5://127.0.0.1:1080 | http://127.0.0.1:1080
+	LocalAddr  string // 本地出口IP，同一进程的不同账户可以从不同IP访问
 	AccessKey  string
 	SecretKey  string
 	Passphrase string
 	MarketType string // 市场类型，用于binance portfolio margin
+
+	// 超时时间，为0时使用httpx的默认值
+	RequestTimeout time.Duration // rest请求的总超时
+	DialTimeout    time.Duration // rest和ws建立tcp连接的超时
+
+	// Environment 连接的环境，每个交易所实例单独设置
+	Environment constant.Environment
+	// 自定义地址，不为空时覆盖Environment对应的地址
+	RestUrl  string
+	PubWsUrl string
+	PriWsUrl string
 }
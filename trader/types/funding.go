@@ -1,10 +1,18 @@
 package types
 
+import "github.com/cybernonce/gotrader/trader/constant"
+
+// FundingRate 资金费率，ws推送时Exchange/Ts有值，Min/Max/Premium仅okx推送
 type FundingRate struct {
 	Symbol          string
+	Exchange        constant.ExchangeType
 	Method          string
 	FundingRate     float64
 	FundingTime     int64
 	NextFundingRate float64
 	NextFundingTime int64
+	MinFundingRate  float64
+	MaxFundingRate  float64
+	Premium         float64 // 溢价指数
+	Ts              int64   // 数据更新时间，毫秒
 }
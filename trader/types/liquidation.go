@@ -0,0 +1,14 @@
+package types
+
+import "github.com/cybernonce/gotrader/trader/constant"
+
+// Liquidation 强平单，Side为强平单的方向，多仓被强平时为卖
+type Liquidation struct {
+	Symbol     string
+	Exchange   constant.ExchangeType
+	Side       constant.OrderSide
+	Price      float64 // binance为成交均价，okx为破产价格
+	Size       float64 // okx和币本位为合约张数，U本位为币的数量
+	ExchangeTs int64   // 微秒
+	Ts         int64   // 本地收到的时间，毫秒
+}
@@ -19,7 +19,9 @@ type Order struct {
 	ExecutedAmt string
 	AvgPrice    string               `json:"avgPrice"`
 	Fee         string               `json:"fee"`
-	Status      constant.OrderStatus `json:"status"` // 自定义的订单状态，统一各交易所订单状态
+	Status      constant.OrderStatus `json:"status"`     // 自定义的订单状态，统一各交易所订单状态
+	ReduceOnly  bool                 `json:"reduceOnly"` // 只减仓，双向持仓模式下使用Long/Short/CloseLong/CloseShort方向
+	MarginMode  constant.MarginMode  `json:"marginMode"` // 保证金模式，币安按交易对设置，下单时不使用
 
 	TargetPrice   float64 // 目标价格
 	HedgeClientId string  // 对冲订单ID
@@ -82,3 +84,11 @@ type OrderResult struct {
 	ErrCode   int32
 	ErrMsg    string
 }
+
+// AmendResult 改单结果，OrderId/ClientId为改单后的订单，撤单重下时与原订单不同
+type AmendResult struct {
+	OrderResult
+	Mode         constant.AmendMode
+	OrigOrderId  string // 原订单ID
+	OrigClientId string // 原订单clientId
+}
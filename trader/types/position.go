@@ -4,7 +4,7 @@ type Position struct {
 	MarginMode       string  // 保证金模式
 	Symbol           string  // 交易对或合约标识符
 	LiquidationPx    float64 // 清算价格
-	Side             string  // 持仓方向，LONG/SHOTY
+	Side             string  // 持仓方向，LONG/SHORT，单向持仓平仓推送时为空
 	Position         float64 // 当前持仓量
 	FrozenPosition   float64 // 冻结的持仓量
 	AvgCost          float64 // 平均成本
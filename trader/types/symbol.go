@@ -11,4 +11,5 @@ type SymbolInfo struct {
 	MinCnt     float64 // 最小交易量
 	MaxCnt     float64 // 最大交易量
 	Name       string  // 交易对名称
+	Expiry     int64   // 交割合约到期时间(毫秒)，永续和现货为0
 }
@@ -13,4 +13,20 @@ type Trade struct {
 	ExchangeTs int64
 	LocalTs    int64
 	EventTs    int64
-}
\ No newline at end of file
+}
+
+// Fill 账户的成交明细
+type Fill struct {
+	Symbol   string
+	Exchange constant.ExchangeType
+	TradeID  string
+	OrderID  string
+	ClientID string
+	Side     constant.OrderSide
+	Price    float64
+	Qty      float64
+	Fee      float64 // 手续费，正数表示支出，负数为返佣
+	FeeCoin  string
+	Role     constant.RoleType
+	Ts       int64 // 成交时间，毫秒
+}
@@ -0,0 +1,43 @@
+package types
+
+import "github.com/cybernonce/gotrader/trader/constant"
+
+// Currency 币种在各条链上的充提配置
+type Currency struct {
+	Coin     string
+	Networks []*CurrencyNetwork
+}
+
+// CurrencyNetwork 链名称使用交易所的原始名称，如币安的TRX、OKX的USDT-TRC20
+type CurrencyNetwork struct {
+	Chain       string
+	CanDeposit  bool
+	CanWithdraw bool
+	WithdrawFee float64
+	WithdrawMin float64
+	WithdrawMax float64
+}
+
+type DepositAddress struct {
+	Coin    string
+	Chain   string
+	Address string
+	Tag     string // memo/tag，不需要时为空
+}
+
+// WalletRecord 充值或提币记录
+type WalletRecord struct {
+	Id        string
+	Type      constant.WalletType
+	Coin      string
+	Chain     string
+	Address   string
+	Tag       string
+	TxId      string
+	Amount    float64
+	Fee       float64
+	Status    constant.WalletStatus
+	RawStatus string // 交易所原始状态
+	ClientId  string
+	Ts        int64 // 申请或到账时间，毫秒
+}